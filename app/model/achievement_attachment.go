@@ -0,0 +1,50 @@
+package model
+
+// Kategori lampiran prestasi
+const (
+	AttachmentCertificate      = "certificate"
+	AttachmentPhoto            = "photo"
+	AttachmentAssignmentLetter = "assignment_letter"
+	AttachmentOther            = "other"
+)
+
+var AttachmentCategories = []string{
+	AttachmentCertificate,
+	AttachmentPhoto,
+	AttachmentAssignmentLetter,
+	AttachmentOther,
+}
+
+// ValidAttachmentCategory memeriksa apakah category dikenal
+func ValidAttachmentCategory(category string) bool {
+	for _, c := range AttachmentCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Status pembuatan thumbnail/preview lampiran
+const (
+	PreviewPending     = "pending"
+	PreviewReady       = "ready"
+	PreviewFailed      = "failed"
+	PreviewUnsupported = "unsupported"
+)
+
+// StorageKeys semua key storage milik lampiran (file asli + turunannya)
+func (a Attachment) StorageKeys() []string {
+	keys := []string{a.FileURL}
+	for _, k := range []string{a.ThumbnailURL, a.PreviewURL} {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// ReorderAttachmentsRequest urutan baru lampiran; harus berisi semua ID lampiran tepat satu kali
+type ReorderAttachmentsRequest struct {
+	AttachmentIDs []string `json:"attachmentIds"`
+}
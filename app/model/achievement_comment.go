@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// AchievementComment komentar pada thread diskusi prestasi (mahasiswa <-> dosen wali)
+type AchievementComment struct {
+	ID               string    `json:"id"`
+	AchievementRefID string    `json:"achievementRefId"`
+	AuthorID         string    `json:"authorId"`
+	AuthorName       string    `json:"authorName"`
+	AuthorRole       string    `json:"authorRole"`
+	Body             string    `json:"body"`
+	Field            *string   `json:"field"` // opsional, mis. "details.eventDate" atau "attachments"
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+type CreateCommentRequest struct {
+	Body  string  `json:"body"`
+	Field *string `json:"field"`
+}
+
+// RequestRevisionRequest dosen wali meminta mahasiswa memperbaiki prestasi
+type RequestRevisionRequest struct {
+	Note  string  `json:"note"`
+	Field *string `json:"field"`
+}
@@ -0,0 +1,13 @@
+package model
+
+// DuplicateMatch prestasi lain yang kemungkinan sama dengan prestasi yang diperiksa
+type DuplicateMatch struct {
+	ReferenceID    string   `json:"referenceId"`
+	AchievementID  string   `json:"achievementId"`
+	StudentID      string   `json:"studentId"`
+	Title          string   `json:"title"`
+	Status         string   `json:"status"`
+	Score          float64  `json:"score"`
+	HighConfidence bool     `json:"highConfidence"`
+	Reasons        []string `json:"reasons"`
+}
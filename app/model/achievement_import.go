@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+// Field tujuan import prestasi. Kolom details ditulis "details.<key>".
+const (
+	ImportFieldNIM             = "nim"
+	ImportFieldAchievementType = "achievementType"
+	ImportFieldTitle           = "title"
+	ImportFieldDescription     = "description"
+	ImportFieldTags            = "tags"
+	// Tanggal prestasi; dipakai sebagai created_at / submitted_at / verified_at reference
+	ImportFieldDate     = "date"
+	ImportDetailsPrefix = "details."
+)
+
+// ImportMapping pemetaan field tujuan -> judul kolom di file
+// (mis. {"nim": "NIM", "title": "Nama Lomba", "details.rank": "Juara"})
+type ImportMapping map[string]string
+
+// ImportOptions parameter satu kali import
+type ImportOptions struct {
+	FileName string
+	Sheet    string // XLSX; kosong = sheet pertama
+	Mapping  ImportMapping
+	// Status awal prestasi hasil import: draft | submitted | verified
+	Status string
+	DryRun bool
+	// Admin yang menjalankan import; kosong jika dari CLI
+	ActorID   string
+	ActorRole string
+}
+
+// ImportRow satu baris file yang sudah dipetakan ke field tujuan
+type ImportRow struct {
+	Row             int // nomor baris di file (header = 1)
+	NIM             string
+	AchievementType string
+	Title           string
+	Description     string
+	Tags            []string
+	Date            string
+	Details         map[string]string
+}
+
+// ImportRowError kesalahan satu baris; baris yang punya error tidak diimpor
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	NIM     string `json:"nim"`
+	Field   string `json:"field"`
+	Column  string `json:"column"` // judul kolom di file
+	Message string `json:"message"`
+}
+
+// ImportRowResult baris yang (akan) diimpor. ReferenceID kosong saat dry run.
+type ImportRowResult struct {
+	Row             int    `json:"row"`
+	NIM             string `json:"nim"`
+	StudentID       string `json:"studentId"`
+	AchievementType string `json:"achievementType"`
+	Title           string `json:"title"`
+	Points          int    `json:"points"`
+	ReferenceID     string `json:"referenceId,omitempty"`
+}
+
+// AchievementImport ringkasan satu kali import. Imported pada dry run adalah
+// jumlah baris yang lolos validasi. Error per baris disimpan agar laporannya
+// bisa diunduh kembali (GET /achievements/imports/:id/errors).
+type AchievementImport struct {
+	ID            string            `json:"id"`
+	FileName      string            `json:"fileName"`
+	InitialStatus string            `json:"initialStatus"`
+	DryRun        bool              `json:"dryRun"`
+	TotalRows     int               `json:"totalRows"`
+	Imported      int               `json:"imported"`
+	Failed        int               `json:"failed"`
+	Errors        []ImportRowError  `json:"errors"`
+	Rows          []ImportRowResult `json:"rows,omitempty"`
+	CreatedBy     *string           `json:"createdBy"`
+	CreatedAt     time.Time         `json:"createdAt"`
+}
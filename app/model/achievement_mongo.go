@@ -7,10 +7,23 @@ import (
 )
 
 type Attachment struct {
+	// ID lampiran; lampiran lama tanpa ID dialamatkan dengan nomor urutnya
+	ID         string    `bson:"id,omitempty" json:"id,omitempty"`
 	FileName   string    `bson:"fileName" json:"fileName"`
 	FileURL    string    `bson:"fileUrl" json:"fileUrl"`
 	FileType   string    `bson:"fileType" json:"fileType"`
+	Size       int64     `bson:"size,omitempty" json:"size,omitempty"`
 	UploadedAt time.Time `bson:"uploadedAt" json:"uploadedAt"`
+	SHA256     string    `bson:"sha256,omitempty" json:"sha256,omitempty"`
+	// ID reference ke blob (content-addressed); kosong untuk lampiran lama
+	BlobRef    string    `bson:"blobRef,omitempty" json:"-"`
+	Category   string    `bson:"category,omitempty" json:"category,omitempty"`
+	Caption    string    `bson:"caption,omitempty" json:"caption,omitempty"`
+	// Key storage thumbnail (JPEG) dan preview halaman pertama PDF (PNG),
+	// dibuat asinkron setelah upload
+	ThumbnailURL  string `bson:"thumbnailUrl,omitempty" json:"thumbnailUrl,omitempty"`
+	PreviewURL    string `bson:"previewUrl,omitempty" json:"previewUrl,omitempty"`
+	PreviewStatus string `bson:"previewStatus,omitempty" json:"previewStatus,omitempty"`
 }
 
 type Achievement struct {
@@ -23,6 +36,13 @@ type Achievement struct {
 	Attachments     []Attachment       `bson:"attachments" json:"attachments"`
 	Tags            []string           `bson:"tags" json:"tags"`
 	Points          int                `bson:"points" json:"points"`
+	// Revisi isi terakhir dan revisi yang disetujui saat verifikasi
+	CurrentRevision int                `bson:"currentRevision" json:"currentRevision"`
+	VerifiedRevision *int              `bson:"verifiedRevision,omitempty" json:"verifiedRevision,omitempty"`
 	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+	// Anggota tim yang sudah konfirmasi (kosong untuk prestasi perorangan)
+	Team []TeamCredit `bson:"team,omitempty" json:"team,omitempty"`
+	// Diisi saat prestasi dipindah ke trash (soft delete)
+	DeletedAt *time.Time `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
 }
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AchievementRevision snapshot isi prestasi (immutable) setiap kali dibuat/diubah
+type AchievementRevision struct {
+	ID              primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	AchievementID   primitive.ObjectID     `bson:"achievementId" json:"achievementId"`
+	Revision        int                    `bson:"revision" json:"revision"`
+	AchievementType string                 `bson:"achievementType" json:"achievementType"`
+	Title           string                 `bson:"title" json:"title"`
+	Description     string                 `bson:"description" json:"description"`
+	Details         map[string]interface{} `bson:"details" json:"details"`
+	Tags            []string               `bson:"tags" json:"tags"`
+	EditedBy        string                 `bson:"editedBy,omitempty" json:"editedBy,omitempty"`
+	EditedByRole    string                 `bson:"editedByRole,omitempty" json:"editedByRole,omitempty"`
+	CreatedAt       time.Time              `bson:"createdAt" json:"createdAt"`
+}
+
+// FieldChange perubahan satu field antara dua revisi. Field details ditulis
+// sebagai "details.<key>".
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// RevisionDiff hasil perbandingan dua revisi
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// Operasi yang menulis ke Postgres dan Mongo sekaligus
+const (
+	SagaCreate  = "create"
+	SagaDelete  = "delete"
+	SagaRestore = "restore"
+	SagaPurge   = "purge"
+)
+
+// State saga: pending berarti langkah Mongo/Postgres belum dipastikan selesai
+// dan akan diselesaikan (atau dibatalkan) oleh proses recovery
+const (
+	SagaPending     = "pending"
+	SagaCompleted   = "completed"
+	SagaCompensated = "compensated"
+)
+
+// AchievementSaga mencatat penulisan dua-store agar bisa dipulihkan setelah restart
+type AchievementSaga struct {
+	ID                 string    `json:"id"`
+	Operation          string    `json:"operation"`
+	AchievementRefID   string    `json:"achievementRefId"`
+	MongoAchievementID string    `json:"mongoAchievementId"`
+	StudentID          string    `json:"studentId"`
+	State              string    `json:"state"`
+	Attempts           int       `json:"attempts"`
+	LastError          *string   `json:"lastError,omitempty"`
+	CreatedAt          time.Time `json:"createdAt"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+// ReconcileIssue satu temuan ketidakcocokan antara Postgres dan Mongo
+type ReconcileIssue struct {
+	Kind               string `json:"kind"`
+	AchievementRefID   string `json:"achievementRefId,omitempty"`
+	MongoAchievementID string `json:"mongoAchievementId,omitempty"`
+	StudentID          string `json:"studentId,omitempty"`
+	MongoStudentID     string `json:"mongoStudentId,omitempty"`
+	Repaired           bool   `json:"repaired"`
+	Error              string `json:"error,omitempty"`
+}
+
+// Jenis temuan reconcile
+const (
+	IssueOrphanDocument  = "orphan_document"
+	IssueMissingDocument = "missing_document"
+	IssueStudentMismatch = "student_mismatch"
+	IssueDeletedMismatch = "deleted_mismatch"
+)
+
+// ReconcileReport hasil pemeriksaan konsistensi kedua store
+type ReconcileReport struct {
+	SagasRecovered int              `json:"sagasRecovered"`
+	References     int              `json:"references"`
+	Documents      int              `json:"documents"`
+	Issues         []ReconcileIssue `json:"issues"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Status SLA verifikasi prestasi yang sedang diajukan
+const (
+	SLAOnTrack   = "on_track"
+	SLAOverdue   = "overdue"
+	SLAEscalated = "escalated"
+)
+
+// AchievementSLA penanda yang sudah dikirim scheduler untuk satu tahap
+// pengajuan. Hanya berlaku jika StartedAt sama dengan waktu tahap aktif mulai
+// menunggu (waktu submit untuk tahap pertama).
+type AchievementSLA struct {
+	AchievementRefID string
+	StartedAt        time.Time
+	OverdueAt        *time.Time
+	EscalatedAt      *time.Time
+}
+
+// SLAStatus status SLA prestasi berstatus submitted (field "sla" di detail).
+// Batas waktu dihitung dari StageStartedAt: setiap tahap persetujuan yang
+// diputuskan memulai SLA baru untuk tahap berikutnya.
+type SLAStatus struct {
+	State          string    `json:"state"` // on_track | overdue | escalated
+	SubmittedAt    time.Time `json:"submittedAt"`
+	StageStartedAt time.Time `json:"stageStartedAt"`
+	DueAt          time.Time `json:"dueAt"`
+	EscalateAt     time.Time `json:"escalateAt"`
+	WaitingDays    int       `json:"waitingDays"` // sejak StageStartedAt
+	// Waktu approver tahap aktif diberi notifikasi dan waktu eskalasi ke admin
+	AdvisorNotifiedAt *time.Time `json:"advisorNotifiedAt,omitempty"`
+	EscalatedAt       *time.Time `json:"escalatedAt,omitempty"`
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status achievement reference
+const (
+	StatusDraft             = "draft"
+	StatusSubmitted         = "submitted"
+	StatusRevisionRequested = "revision_requested"
+	StatusVerified          = "verified"
+	StatusRejected          = "rejected"
+	StatusDeleted           = "deleted"
+)
+
+// AchievementTransitions tabel transisi status yang sah (from -> daftar to)
+var AchievementTransitions = map[string][]string{
+	StatusDraft:             {StatusSubmitted, StatusDeleted},
+	StatusSubmitted:         {StatusVerified, StatusRejected, StatusRevisionRequested},
+	StatusRevisionRequested: {StatusSubmitted},
+	StatusRejected:          {StatusDraft},
+	StatusDeleted:           {StatusDraft}, // restore dari trash
+}
+
+// CanTransition mengecek apakah perpindahan status from -> to diperbolehkan
+func CanTransition(from, to string) bool {
+	for _, next := range AchievementTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange data perubahan status yang ditulis ke achievement_references
+type StatusChange struct {
+	From          string
+	To            string
+	SubmittedAt   *time.Time
+	VerifiedAt    *time.Time
+	VerifiedBy    *string
+	RejectionNote *string
+
+	// dicatat ke achievement_status_history
+	ActorID   string
+	ActorRole string
+	Note      *string
+	// Dosen wali asli jika pelaku bertindak sebagai delegasinya
+	OnBehalfOf string
+
+	// Tahap persetujuan yang diputuskan bersama perubahan ini. From == To
+	// (submitted) berarti hanya tahap tersebut yang disetujui.
+	ApprovalID string
+	// Tahap persetujuan pengajuan baru, ditulis saat submit
+	Approvals []AchievementApproval
+}
+
+// IsStageApproval true jika perubahan hanya menyetujui satu tahap persetujuan
+// tanpa mengubah status (masih ada tahap berikutnya)
+func (c StatusChange) IsStageApproval() bool {
+	return c.ApprovalID != "" && c.From == c.To
+}
+
+// AchievementStatusHistory satu baris riwayat perubahan status
+type AchievementStatusHistory struct {
+	ID               string    `json:"id"`
+	AchievementRefID string    `json:"achievementRefId"`
+	FromStatus       string    `json:"fromStatus"`
+	ToStatus         string    `json:"toStatus"`
+	ActorID          *string   `json:"actorId"`
+	ActorRole        *string   `json:"actorRole"`
+	OnBehalfOf       *string   `json:"onBehalfOf,omitempty"`
+	Note             *string   `json:"note"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// StatusTransitionError dikembalikan jika transisi tidak sah
+// atau status sudah diubah oleh request lain
+type StatusTransitionError struct {
+	Current   string `json:"currentStatus"`
+	Requested string `json:"requestedStatus"`
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change status from %s to %s", e.Current, e.Requested)
+}
+
+// BulkActionRequest verifikasi/penolakan banyak prestasi sekaligus
+type BulkActionRequest struct {
+	IDs  []string `json:"ids"`
+	Note string   `json:"note"`
+}
+
+// BulkItemResult hasil per item dari bulk action
+type BulkItemResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Status  string `json:"status,omitempty"`
+	Points  *int   `json:"points,omitempty"`
+	Stage   string `json:"stage,omitempty"` // tahap yang disetujui jika masih ada tahap berikutnya
+	Error   string `json:"error,omitempty"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// Peran anggota tim prestasi
+const (
+	TeamRoleLeader = "leader"
+	TeamRoleMember = "member"
+)
+
+// Status konfirmasi keikutsertaan anggota tim
+const (
+	MemberPending   = "pending"
+	MemberConfirmed = "confirmed"
+	MemberDeclined  = "declined"
+)
+
+// Pembagian poin prestasi tim
+const (
+	TeamPointsDuplicate = "duplicate" // setiap anggota mendapat poin penuh
+	TeamPointsSplit     = "split"     // poin dibagi rata, sisa pembagian untuk ketua
+)
+
+// Verifikasi prestasi tim
+const (
+	TeamVerifySubmitter  = "submitter"   // cukup dosen wali pengaju
+	TeamVerifyEachMember = "each_member" // dosen wali setiap anggota ikut menyetujui
+)
+
+// TeamMember anggota tim sebuah prestasi (tabel achievement_members)
+type TeamMember struct {
+	AchievementRefID string     `json:"achievementRefId"`
+	StudentID        string     `json:"studentId"`
+	Role             string     `json:"role"`   // leader | member
+	Status           string     `json:"status"` // pending | confirmed | declined
+	InvitedBy        *string    `json:"invitedBy"`
+	RespondedAt      *time.Time `json:"respondedAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+// TeamMemberRequest satu anggota pada PUT /achievements/:id/members
+type TeamMemberRequest struct {
+	StudentID string `json:"studentId"`
+	Role      string `json:"role"`
+}
+
+// UpdateTeamRequest body PUT /achievements/:id/members. Pemilik prestasi
+// selalu menjadi anggota; daftar kosong menjadikan prestasi perorangan.
+type UpdateTeamRequest struct {
+	Members []TeamMemberRequest `json:"members"`
+}
+
+// TeamCredit anggota tim yang sudah konfirmasi beserta poinnya, disalin ke
+// dokumen Mongo agar laporan bisa menghitung per anggota
+type TeamCredit struct {
+	StudentID string `bson:"studentId" json:"studentId"`
+	Role      string `bson:"role" json:"role"`
+	Points    int    `bson:"points" json:"points"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// AchievementType registry jenis prestasi beserta skema field details-nya
+type AchievementType struct {
+	Code        string       `json:"code"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Schema      DetailSchema `json:"schema"`
+	IsActive    bool         `json:"isActive"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+// DetailSchema definisi details bergaya JSON Schema (type object)
+type DetailSchema struct {
+	Required   []string                        `json:"required"`
+	Properties map[string]DetailSchemaProperty `json:"properties"`
+}
+
+// DetailSchemaProperty definisi satu field details.
+// Type: string | number | integer | boolean. Format "date" = YYYY-MM-DD.
+type DetailSchemaProperty struct {
+	Type        string   `json:"type"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+type AchievementTypeRequest struct {
+	Code        string       `json:"code"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Schema      DetailSchema `json:"schema"`
+	IsActive    *bool        `json:"isActive"`
+}
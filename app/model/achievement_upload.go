@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// TusVersion versi protokol tus yang didukung upload resumable
+const TusVersion = "1.0.0"
+
+// ResumableUpload upload lampiran bertahap (protokol tus). Data sementara
+// disimpan per chunk di storage lampiran sampai upload lengkap lalu
+// di-finalize menjadi lampiran.
+type ResumableUpload struct {
+	ID               string    `json:"id"`
+	AchievementRefID string    `json:"achievementRefId"`
+	UserID           string    `json:"userId"`
+	FileName         string    `json:"fileName"`
+	Category         string    `json:"category"`
+	Caption          string    `json:"caption"`
+	Length           int64     `json:"length"`
+	Offset           int64     `json:"offset"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// UploadChunk satu chunk data upload resumable di storage lampiran
+type UploadChunk struct {
+	StorageKey string
+	UploadID   string
+	Offset     int64
+	Size       int64
+}
@@ -24,17 +24,91 @@ type CreateAchievementRequest struct {
 	Points int `json:"points"` 
 }
 
+// UpdateAchievementRequest body PUT /achievements/:id. AchievementType kosong =
+// jenis tidak berubah; jika diisi harus jenis aktif di registry.
 type UpdateAchievementRequest struct {
-	Title       string                 `json:"title"`
-	Description string                 `json:"description"`
-	Details     map[string]interface{} `json:"details"`
-	Tags        []string               `json:"tags"`
+	AchievementType string                 `json:"achievementType"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	Details         map[string]interface{} `json:"details"`
+	Tags            []string               `json:"tags"`
 }
 
 type RejectAchievementRequest struct {
 	Note string `json:"note"`
 }
 
+// FilterAchievementRequest query param GET /achievements.
+// Status dan Tags boleh berisi beberapa nilai dipisah koma.
 type FilterAchievementRequest struct {
-	StudentID string `query:"studentId"`
+	StudentID       string `query:"studentId"`
+	ProgramStudy    string `query:"programStudy"`
+	Status          string `query:"status"`
+	AchievementType string `query:"achievementType"`
+	Tags            string `query:"tags"`
+	Q               string `query:"q"`
+	DateField       string `query:"dateField"` // createdAt | submittedAt | verifiedAt
+	From            string `query:"from"`      // YYYY-MM-DD
+	To              string `query:"to"`        // YYYY-MM-DD (inklusif)
+	Sort            string `query:"sort"`      // createdAt | submittedAt | verifiedAt | points
+	Order           string `query:"order"`     // asc | desc
+	Page            int    `query:"page"`
+	Limit           int    `query:"limit"`
+}
+
+// StageStartedColumn kolom turunan yang bisa dipakai sebagai DateColumn /
+// SortColumn: waktu tahap persetujuan aktif mulai menunggu (waktu submit untuk
+// tahap pertama)
+const StageStartedColumn = "stage_started_at"
+
+// ReferenceFilter filter achievement_references yang sudah diterjemahkan ke kolom SQL
+type ReferenceFilter struct {
+	StudentID    string   // scope Mahasiswa / filter studentId
+	AdvisorID    string   // scope Dosen Wali
+	DelegatorIDs []string // dosen wali yang sedang mendelegasikan ke AdvisorID
+	// IncludeMembers: StudentID / AdvisorID juga cocok dengan anggota tim yang
+	// sudah konfirmasi (dan dosen walinya), bukan hanya pemilik prestasi
+	IncludeMembers bool
+	ProgramStudy string
+	Statuses     []string
+	DateColumn   string
+	From         *time.Time
+	To           *time.Time
+
+	// Approver membatasi ke pengajuan yang melibatkan approver (role selain
+	// Mahasiswa/Dosen Wali/Admin). AwaitingApproval: hanya pengajuan yang tahap
+	// aktifnya menunggu keputusan user (Approver atau dosen wali AdvisorID).
+	Approver         *ApproverFilter
+	AwaitingApproval bool
+
+	// Tanpa Statuses, reference berstatus deleted (trash) tidak ikut kecuali IncludeDeleted
+	IncludeDeleted bool
+
+	// MongoIDs membatasi hasil ke dokumen Mongo tertentu (hasil filter Mongo).
+	// RestrictMongoIDs membedakan "tidak ada filter" dengan "filter tanpa hasil".
+	MongoIDs         []string
+	RestrictMongoIDs bool
+
+	SortColumn string
+	SortDesc   bool
+	Limit      int // 0 = tanpa paging
+	Offset     int
+}
+
+// ApproverFilter approver pada ReferenceFilter
+type ApproverFilter struct {
+	UserID string
+	Role   string
+}
+
+// MongoAchievementFilter filter yang hanya bisa dijalankan di Mongo
+type MongoAchievementFilter struct {
+	AchievementType string
+	Tags            []string
+	Q               string
+}
+
+// IsEmpty true jika tidak ada filter Mongo yang aktif
+func (f MongoAchievementFilter) IsEmpty() bool {
+	return f.AchievementType == "" && len(f.Tags) == 0 && f.Q == ""
 }
\ No newline at end of file
@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// Status delegasi, dihitung dari waktu dan pencabutan
+const (
+	DelegationScheduled = "scheduled"
+	DelegationActive    = "active"
+	DelegationExpired   = "expired"
+	DelegationRevoked   = "revoked"
+)
+
+// AdvisorDelegation delegasi verifikasi sementara dari dosen wali ke dosen lain.
+// AdvisorID dan DelegateID adalah user ID.
+type AdvisorDelegation struct {
+	ID         string     `json:"id"`
+	AdvisorID  string     `json:"advisorId"`
+	DelegateID string     `json:"delegateId"`
+	StartsAt   time.Time  `json:"startsAt"`
+	EndsAt     time.Time  `json:"endsAt"`
+	Reason     string     `json:"reason"`
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	RevokedBy  *string    `json:"revokedBy,omitempty"`
+	Status     string     `json:"status"`
+}
+
+// StatusAt status delegasi pada waktu now
+func (d AdvisorDelegation) StatusAt(now time.Time) string {
+	switch {
+	case d.RevokedAt != nil:
+		return DelegationRevoked
+	case now.Before(d.StartsAt):
+		return DelegationScheduled
+	case now.Before(d.EndsAt):
+		return DelegationActive
+	}
+	return DelegationExpired
+}
+
+// CreateDelegationRequest body POST /delegations
+type CreateDelegationRequest struct {
+	// Wajib untuk Admin; Dosen Wali selalu mendelegasikan mahasiswa bimbingannya sendiri
+	AdvisorID  string     `json:"advisorId"`
+	DelegateID string     `json:"delegateId"`
+	StartsAt   *time.Time `json:"startsAt"` // default sekarang
+	EndsAt     time.Time  `json:"endsAt"`
+	Reason     string     `json:"reason"`
+}
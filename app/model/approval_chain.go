@@ -0,0 +1,86 @@
+package model
+
+import "time"
+
+// Cakupan tahap persetujuan: siapa saja dengan role tahap yang boleh memutuskan
+const (
+	ScopeAdvisor      = "advisor"       // dosen wali mahasiswa (termasuk delegasinya)
+	ScopeProgramStudy = "program_study" // approver yang ditugaskan ke program studi mahasiswa
+	ScopeDepartment   = "department"    // approver yang ditugaskan ke departemen dosen wali mahasiswa
+	ScopeAll          = "all"           // semua user dengan role tahap
+
+	// Dosen wali anggota tim (scope_value = ID mahasiswa anggota); hanya
+	// dibuat saat submit prestasi tim, tidak bisa dipakai di rantai
+	ScopeMemberAdvisor = "member_advisor"
+)
+
+// Keputusan satu tahap persetujuan; nil = menunggu
+const (
+	ApprovalApproved          = "approved"
+	ApprovalRejected          = "rejected"
+	ApprovalRevisionRequested = "revision_requested"
+)
+
+// ApprovalStage satu tahap dalam rantai persetujuan
+type ApprovalStage struct {
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	Scope string `json:"scope"` // advisor | program_study | department | all
+}
+
+// DefaultApprovalStages dipakai jika tidak ada rantai aktif yang cocok:
+// verifikasi tunggal oleh dosen wali
+var DefaultApprovalStages = []ApprovalStage{
+	{Name: "Dosen Wali", Role: "Dosen Wali", Scope: ScopeAdvisor},
+}
+
+// ApprovalChain rantai persetujuan. Field pointer bernilai nil berarti "semua"
+// (wildcard); rantai yang paling spesifik yang akan dipakai.
+type ApprovalChain struct {
+	ID               string          `json:"id"`
+	AchievementType  *string         `json:"achievementType"`
+	CompetitionLevel *string         `json:"competitionLevel"`
+	Stages           []ApprovalStage `json:"stages"`
+	Description      string          `json:"description"`
+	IsActive         bool            `json:"isActive"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+}
+
+type ApprovalChainRequest struct {
+	AchievementType  *string         `json:"achievementType"`
+	CompetitionLevel *string         `json:"competitionLevel"`
+	Stages           []ApprovalStage `json:"stages"`
+	Description      string          `json:"description"`
+	IsActive         *bool           `json:"isActive"`
+}
+
+// AchievementApproval tahap persetujuan sebuah pengajuan. ScopeValue adalah
+// program studi / departemen / user ID dosen wali saat submit; nil jika tidak
+// bisa ditentukan (hanya Admin yang bisa memutuskan tahap tersebut).
+type AchievementApproval struct {
+	ID               string     `json:"id"`
+	AchievementRefID string     `json:"achievementRefId"`
+	SubmittedAt      time.Time  `json:"submittedAt"`
+	StageOrder       int        `json:"stageOrder"`
+	Name             string     `json:"name"`
+	Role             string     `json:"role"`
+	Scope            string     `json:"scope"`
+	ScopeValue       *string    `json:"scopeValue"`
+	Decision         *string    `json:"decision"`
+	ActorID          *string    `json:"actorId"`
+	OnBehalfOf       *string    `json:"onBehalfOf,omitempty"`
+	Note             *string    `json:"note"`
+	DecidedAt        *time.Time `json:"decidedAt"`
+}
+
+// ApproverScope penugasan approver ke satu program studi atau departemen
+type ApproverScope struct {
+	Scope string `json:"scope"` // program_study | department
+	Value string `json:"value"`
+}
+
+// ApproverScopesRequest body PUT /approval-chains/approvers/:userId/scopes
+type ApproverScopesRequest struct {
+	Scopes []ApproverScope `json:"scopes"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import "fmt"
+
+// AttachmentBlob isi file lampiran yang disimpan sekali per hash SHA-256
+type AttachmentBlob struct {
+	SHA256      string `json:"sha256"`
+	StorageKey  string `json:"storageKey"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"contentType"`
+}
+
+// BlobRef pemakaian blob oleh satu file lampiran. ID disimpan di
+// Attachment.BlobRef; mengganti file lampiran membuat ref baru.
+type BlobRef struct {
+	ID               string
+	AttachmentID     string
+	SHA256           string
+	StudentID        string
+	AchievementRefID string
+	Size             int64
+}
+
+// BlobQuota batas pemakaian yang diperiksa saat ref baru dicatat. Kuota 0 =
+// tidak dibatasi; Freed ukuran file lama yang akan dilepas (ganti file).
+type BlobQuota struct {
+	Student     int64
+	Achievement int64
+	Freed       int64
+}
+
+// QuotaExceededError dikembalikan jika ref baru melebihi kuota penyimpanan
+type QuotaExceededError struct {
+	Scope     string `json:"scope"`
+	Quota     int64  `json:"quotaBytes"`
+	Used      int64  `json:"usedBytes"`
+	Requested int64  `json:"requestedBytes"`
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s storage quota exceeded", e.Scope)
+}
+
+// AchievementStorageUsage pemakaian penyimpanan lampiran satu prestasi
+type AchievementStorageUsage struct {
+	AchievementRefID string `json:"achievementRefId"`
+	UsedBytes        int64  `json:"usedBytes"`
+	Attachments      int    `json:"attachments"`
+	QuotaBytes       int64  `json:"quotaBytes"`
+}
+
+// StorageUsage pemakaian penyimpanan lampiran seorang mahasiswa (GET /me/storage).
+// Kuota 0 = tidak dibatasi.
+type StorageUsage struct {
+	UsedBytes    int64                     `json:"usedBytes"`
+	Attachments  int                       `json:"attachments"`
+	QuotaBytes   int64                     `json:"quotaBytes"`
+	Achievements []AchievementStorageUsage `json:"achievements"`
+}
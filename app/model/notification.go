@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Jenis notifikasi
+const (
+	NotificationSLAOverdue   = "sla_overdue"
+	NotificationSLAEscalated = "sla_escalated"
+	NotificationTeamInvite   = "team_invitation"
+)
+
+// Notification notifikasi in-app untuk seorang user
+type Notification struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"userId"`
+	Type             string     `json:"type"`
+	Title            string     `json:"title"`
+	Message          string     `json:"message"`
+	AchievementRefID *string    `json:"achievementRefId,omitempty"`
+	ReadAt           *time.Time `json:"readAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// PointRule aturan poin prestasi. Field pointer bernilai nil berarti "semua"
+// (wildcard); rule yang paling spesifik yang akan dipakai.
+type PointRule struct {
+	ID               string    `json:"id"`
+	AchievementType  *string   `json:"achievementType"`
+	CompetitionLevel *string   `json:"competitionLevel"`
+	Rank             *int      `json:"rank"`
+	IsTeam           *bool     `json:"isTeam"`
+	Points           int       `json:"points"`
+	TeamPolicy       *string   `json:"teamPolicy"` // duplicate | split; nil = default aplikasi
+	Description      string    `json:"description"`
+	IsActive         bool      `json:"isActive"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+type PointRuleRequest struct {
+	AchievementType  *string `json:"achievementType"`
+	CompetitionLevel *string `json:"competitionLevel"`
+	Rank             *int    `json:"rank"`
+	IsTeam           *bool   `json:"isTeam"`
+	Points           int     `json:"points"`
+	TeamPolicy       *string `json:"teamPolicy"`
+	Description      string  `json:"description"`
+	IsActive         *bool   `json:"isActive"`
+}
+
+// PointDryRunRequest: isi ReferenceID untuk prestasi yang sudah ada,
+// atau AchievementType + Details untuk simulasi
+type PointDryRunRequest struct {
+	ReferenceID     string                 `json:"referenceId"`
+	AchievementType string                 `json:"achievementType"`
+	Details         map[string]interface{} `json:"details"`
+}
+
+type PointDryRunResponse struct {
+	Points      int        `json:"points"`
+	MatchedRule *PointRule `json:"matchedRule"`
+}
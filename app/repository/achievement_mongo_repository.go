@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"go-fiber/app/model"
@@ -9,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoAchievementRepository struct {
@@ -43,6 +45,16 @@ func (r *MongoAchievementRepository) DeleteAchievement(ctx context.Context, id p
 	return err
 }
 
+// SetDeleted menandai dokumen sebagai soft-deleted (at != nil) atau memulihkannya (at == nil)
+func (r *MongoAchievementRepository) SetDeleted(ctx context.Context, id primitive.ObjectID, at *time.Time) error {
+	update := bson.M{"$set": bson.M{"deletedAt": at, "updatedAt": time.Now()}}
+	if at == nil {
+		update = bson.M{"$set": bson.M{"updatedAt": time.Now()}, "$unset": bson.M{"deletedAt": ""}}
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
 func (r *MongoAchievementRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*model.Achievement, error) {
 	var a model.Achievement
 	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&a)
@@ -52,35 +64,230 @@ func (r *MongoAchievementRepository) FindByID(ctx context.Context, id primitive.
 	return &a, nil
 }
 
-func (r *MongoAchievementRepository) AddAttachment(
+// FindByIDs mengambil banyak dokumen sekaligus dengan satu query $in.
+// Urutan hasil tidak dijamin; ID yang tidak ditemukan dilewati.
+func (r *MongoAchievementRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]model.Achievement, error) {
+	list := []model.Achievement{}
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindAllOwners mengambil _id, studentId, createdAt dan deletedAt semua dokumen (untuk reconcile)
+func (r *MongoAchievementRepository) FindAllOwners(ctx context.Context) ([]model.Achievement, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "studentId": 1, "createdAt": 1, "deletedAt": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.Achievement{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// AddAttachments menambahkan satu atau lebih lampiran di akhir daftar
+func (r *MongoAchievementRepository) AddAttachments(
     ctx context.Context,
     id primitive.ObjectID,
-    att model.Attachment,
+    atts []model.Attachment,
 ) error {
-    var doc model.Achievement
-    err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+    // Dokumen lama bisa menyimpan attachments = null, $push butuh array
+    _, err := r.collection.UpdateOne(
+        ctx,
+        bson.M{"_id": id, "attachments": nil},
+        bson.M{"$set": bson.M{"attachments": []model.Attachment{}}},
+    )
     if err != nil {
         return err
     }
 
-    if doc.Attachments == nil {
-        doc.Attachments = []model.Attachment{}
-        _, err := r.collection.UpdateOne(
-            ctx,
-            bson.M{"_id": id},
-            bson.M{"$set": bson.M{"attachments": doc.Attachments}},
-        )
-        if err != nil {
-            return err
-        }
+    res, err := r.collection.UpdateOne(
+        ctx,
+        bson.M{"_id": id},
+        bson.M{
+            "$push": bson.M{"attachments": bson.M{"$each": atts}},
+            "$set":  bson.M{"updatedAt": time.Now()},
+        },
+    )
+    if err != nil {
+        return err
+    }
+    if res.MatchedCount == 0 {
+        return mongo.ErrNoDocuments
     }
+    return nil
+}
 
-    // 3. Push attachment baru
-    _, err = r.collection.UpdateOne(
+// ReplaceAttachment mengganti lampiran dengan ID yang sama (file dan/atau metadata)
+func (r *MongoAchievementRepository) ReplaceAttachment(ctx context.Context, id primitive.ObjectID, att model.Attachment) error {
+    res, err := r.collection.UpdateOne(
         ctx,
-        bson.M{"_id": id},
-        bson.M{"$push": bson.M{"attachments": att}},
+        bson.M{"_id": id, "attachments.id": att.ID},
+        bson.M{"$set": bson.M{"attachments.$": att, "updatedAt": time.Now()}},
+    )
+    if err != nil {
+        return err
+    }
+    if res.MatchedCount == 0 {
+        return mongo.ErrNoDocuments
+    }
+    return nil
+}
+
+// RemoveAttachment menghapus lampiran dari dokumen
+func (r *MongoAchievementRepository) RemoveAttachment(ctx context.Context, id primitive.ObjectID, attachmentID string) error {
+    res, err := r.collection.UpdateOne(
+        ctx,
+        bson.M{"_id": id, "attachments.id": attachmentID},
+        bson.M{
+            "$pull": bson.M{"attachments": bson.M{"id": attachmentID}},
+            "$set":  bson.M{"updatedAt": time.Now()},
+        },
+    )
+    if err != nil {
+        return err
+    }
+    if res.MatchedCount == 0 {
+        return mongo.ErrNoDocuments
+    }
+    return nil
+}
+
+// SetAttachments menulis ulang seluruh daftar lampiran (urutan baru, pengisian ID).
+// Hanya berhasil jika dokumen masih berisi file yang sama dengan current; jika ada
+// upload/hapus/ganti file di antaranya, dikembalikan mongo.ErrNoDocuments.
+func (r *MongoAchievementRepository) SetAttachments(ctx context.Context, id primitive.ObjectID, current, list []model.Attachment) error {
+    keys := make([]string, 0, len(current))
+    for _, att := range current {
+        keys = append(keys, att.FileURL)
+    }
+    filter := bson.M{"_id": id, "attachments": bson.M{"$size": len(current)}}
+    if len(keys) > 0 {
+        filter["attachments.fileUrl"] = bson.M{"$all": keys}
+    }
+
+    res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"attachments": list, "updatedAt": time.Now()}})
+    if err != nil {
+        return err
+    }
+    if res.MatchedCount == 0 {
+        return mongo.ErrNoDocuments
+    }
+    return nil
+}
+
+// SetAttachmentPreview menyimpan hasil pembuatan thumbnail/preview. Hanya ditulis
+// jika lampiran masih menunjuk ke file yang sama (belum diganti atau dihapus);
+// jika tidak, dikembalikan mongo.ErrNoDocuments.
+func (r *MongoAchievementRepository) SetAttachmentPreview(ctx context.Context, id primitive.ObjectID, att model.Attachment) error {
+    res, err := r.collection.UpdateOne(
+        ctx,
+        bson.M{"_id": id, "attachments": bson.M{"$elemMatch": bson.M{"id": att.ID, "fileUrl": att.FileURL}}},
+        bson.M{"$set": bson.M{
+            "attachments.$.thumbnailUrl":  att.ThumbnailURL,
+            "attachments.$.previewUrl":    att.PreviewURL,
+            "attachments.$.previewStatus": att.PreviewStatus,
+        }},
     )
-    return err
+    if err != nil {
+        return err
+    }
+    if res.MatchedCount == 0 {
+        return mongo.ErrNoDocuments
+    }
+    return nil
+}
+
+// buildMongoFilter menerjemahkan filter list ke query Mongo
+func buildMongoFilter(f model.MongoAchievementFilter) bson.M {
+    filter := bson.M{"deletedAt": bson.M{"$exists": false}}
+    if f.AchievementType != "" {
+        filter["achievementType"] = f.AchievementType
+    }
+    if len(f.Tags) > 0 {
+        filter["tags"] = bson.M{"$all": f.Tags}
+    }
+    if f.Q != "" {
+        pattern := primitive.Regex{Pattern: regexp.QuoteMeta(f.Q), Options: "i"}
+        filter["$or"] = bson.A{
+            bson.M{"title": pattern},
+            bson.M{"description": pattern},
+        }
+    }
+    return filter
 }
 
+// FindIDs mengembalikan ID (hex) dokumen yang cocok dengan filter Mongo
+func (r *MongoAchievementRepository) FindIDs(ctx context.Context, f model.MongoAchievementFilter) ([]string, error) {
+    cursor, err := r.collection.Find(ctx, buildMongoFilter(f), options.Find().SetProjection(bson.M{"_id": 1}))
+    if err != nil {
+        return nil, err
+    }
+    defer cursor.Close(ctx)
+
+    ids := []string{}
+    for cursor.Next(ctx) {
+        var doc struct {
+            ID primitive.ObjectID `bson:"_id"`
+        }
+        if err := cursor.Decode(&doc); err != nil {
+            return nil, err
+        }
+        ids = append(ids, doc.ID.Hex())
+    }
+    return ids, cursor.Err()
+}
+
+// FindPageByPoints mengambil satu halaman dokumen (dibatasi ke ids) yang diurutkan
+// berdasarkan poin, beserta total dokumen yang cocok
+func (r *MongoAchievementRepository) FindPageByPoints(
+    ctx context.Context,
+    ids []primitive.ObjectID,
+    f model.MongoAchievementFilter,
+    desc bool,
+    skip, limit int64,
+) ([]model.Achievement, int64, error) {
+    filter := buildMongoFilter(f)
+    filter["_id"] = bson.M{"$in": ids}
+
+    total, err := r.collection.CountDocuments(ctx, filter)
+    if err != nil {
+        return nil, 0, err
+    }
+
+    order := 1
+    if desc {
+        order = -1
+    }
+    opts := options.Find().
+        SetSort(bson.D{{Key: "points", Value: order}, {Key: "_id", Value: 1}}).
+        SetSkip(skip).
+        SetLimit(limit)
+
+    cursor, err := r.collection.Find(ctx, filter, opts)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer cursor.Close(ctx)
+
+    list := []model.Achievement{}
+    if err := cursor.All(ctx, &list); err != nil {
+        return nil, 0, err
+    }
+    return list, total, nil
+}
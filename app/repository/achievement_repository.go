@@ -2,9 +2,13 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"go-fiber/app/model"
+
+	"github.com/lib/pq"
 )
 
 type AchievementRepository struct {
@@ -37,16 +41,165 @@ func (r *AchievementRepository) CreateReference(ref *model.AchievementReference)
 	return err
 }
 
-func (r *AchievementRepository) UpdateReferenceStatus(id, status string, submittedAt, verifiedAt *time.Time, verifiedBy, rejectionNote *string) error {
-	query := `
+// TransitionStatus mengubah status secara atomik: UPDATE hanya berhasil jika
+// status di database masih sama dengan change.From. Jika tidak ada baris yang
+// berubah, dikembalikan *model.StatusTransitionError berisi status terkini.
+// Riwayat perubahan ditulis ke achievement_status_history dalam transaksi yang sama.
+func (r *AchievementRepository) TransitionStatus(id string, change model.StatusChange) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := transitionStatusTx(tx, id, change); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TransitionStatusBatch menjalankan beberapa transisi dalam satu transaksi.
+// Tiap item dibungkus SAVEPOINT sehingga kegagalan satu item (mis. status sudah
+// berubah) tidak membatalkan item lain. Slice error sejajar dengan ids; error
+// kedua berarti seluruh batch gagal (tidak ada yang tersimpan).
+func (r *AchievementRepository) TransitionStatusBatch(ids []string, changes []model.StatusChange) ([]error, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results := make([]error, len(ids))
+	for i, id := range ids {
+		if _, err := tx.Exec(`SAVEPOINT bulk_item`); err != nil {
+			return nil, err
+		}
+		if err := transitionStatusTx(tx, id, changes[i]); err != nil {
+			results[i] = err
+			if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT bulk_item`); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, err := tx.Exec(`RELEASE SAVEPOINT bulk_item`); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func transitionStatusTx(tx *sql.Tx, id string, change model.StatusChange) error {
+	now := time.Now()
+
+	res, err := tx.Exec(`
 		UPDATE achievement_references
-		SET status=$1, submitted_at=$2, verified_at=$3, verified_by=$4, rejection_note=$5, updated_at=$6
-		WHERE id=$7
-	`
-	_, err := r.db.Exec(query, status, submittedAt, verifiedAt, verifiedBy, rejectionNote, time.Now(), id)
+		SET status=$1,
+		    submitted_at=COALESCE($2, submitted_at),
+		    verified_at=COALESCE($3, verified_at),
+		    verified_by=COALESCE($4, verified_by),
+		    rejection_note=$5,
+		    updated_at=$6
+		WHERE id=$7 AND status=$8
+	`,
+		change.To,
+		change.SubmittedAt,
+		change.VerifiedAt,
+		change.VerifiedBy,
+		change.RejectionNote,
+		now,
+		id,
+		change.From,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		var current string
+		if err := tx.QueryRow(`SELECT status FROM achievement_references WHERE id=$1`, id).Scan(&current); err != nil {
+			return err
+		}
+		return &model.StatusTransitionError{Current: current, Requested: change.To}
+	}
+
+	if change.ApprovalID != "" {
+		if err := decideApprovalTx(tx, id, change, now); err != nil {
+			return err
+		}
+	}
+	if len(change.Approvals) > 0 && change.SubmittedAt != nil {
+		if err := insertApprovalsTx(tx, id, *change.SubmittedAt, change.Approvals); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO achievement_status_history
+		(achievement_ref_id, from_status, to_status, actor_id, actor_role, on_behalf_of, note, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	`,
+		id,
+		change.From,
+		change.To,
+		nullString(change.ActorID),
+		nullString(change.ActorRole),
+		nullString(change.OnBehalfOf),
+		change.Note,
+		now,
+	)
 	return err
 }
 
+// FindStatusHistory mengambil riwayat status sebuah reference, urut dari yang terlama
+func (r *AchievementRepository) FindStatusHistory(refID string) ([]model.AchievementStatusHistory, error) {
+	rows, err := r.db.Query(`
+		SELECT id, achievement_ref_id, from_status, to_status, actor_id, actor_role, on_behalf_of, note, created_at
+		FROM achievement_status_history
+		WHERE achievement_ref_id=$1
+		ORDER BY created_at ASC
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementStatusHistory{}
+	for rows.Next() {
+		var h model.AchievementStatusHistory
+		if err := rows.Scan(
+			&h.ID,
+			&h.AchievementRefID,
+			&h.FromStatus,
+			&h.ToStatus,
+			&h.ActorID,
+			&h.ActorRole,
+			&h.OnBehalfOf,
+			&h.Note,
+			&h.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, h)
+	}
+	return list, rows.Err()
+}
+
+// nullString mengubah string kosong menjadi NULL
+func nullString(v string) interface{} {
+	if v == "" {
+		return nil
+	}
+	return v
+}
+
 func (r *AchievementRepository) FindReferenceByID(id string) (*model.AchievementReference, error) {
 	var ref model.AchievementReference
 	err := r.db.QueryRow(`
@@ -70,11 +223,19 @@ func (r *AchievementRepository) FindReferenceByID(id string) (*model.Achievement
 	return &ref, nil
 }
 
+// FindByStudentID prestasi mahasiswa, termasuk prestasi tim yang sudah ia
+// konfirmasi sebagai anggota
 func (r *AchievementRepository) FindByStudentID(studentID string) ([]model.AchievementReference, error) {
 	rows, err := r.db.Query(`
 		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at
-		FROM achievement_references WHERE student_id=$1
-	`, studentID)
+		FROM achievement_references WHERE student_id=$1 AND status <> $2
+		UNION
+		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at
+		FROM achievement_references
+		WHERE status <> $2 AND id IN (
+			SELECT achievement_ref_id FROM achievement_members WHERE student_id=$1 AND status=$3
+		)
+	`, studentID, model.StatusDeleted, model.MemberConfirmed)
 	if err != nil {
 		return nil, err
 	}
@@ -100,13 +261,42 @@ func (r *AchievementRepository) FindByStudentID(studentID string) ([]model.Achie
 	return list, nil
 }
 
+// FindByStudentIDs mengambil reference milik banyak mahasiswa dalam satu query
+func (r *AchievementRepository) FindByStudentIDs(studentIDs []string) ([]model.AchievementReference, error) {
+	list := []model.AchievementReference{}
+	if len(studentIDs) == 0 {
+		return list, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+referenceColumns+`
+		FROM achievement_references ar
+		WHERE ar.student_id = ANY($1) AND ar.status <> $2
+		ORDER BY ar.created_at DESC, ar.id
+	`, pq.Array(studentIDs), model.StatusDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		ref, err := scanReference(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *ref)
+	}
+	return list, rows.Err()
+}
+
 // For admin or lecturer: get all (simple version, add pagination/filter later)
 func (r *AchievementRepository) FindAll() ([]model.AchievementReference, error) {
 	rows, err := r.db.Query(`
 		SELECT id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at
 		FROM achievement_references
+		WHERE status <> $1
 		ORDER BY created_at DESC
-	`)
+	`, model.StatusDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -131,3 +321,166 @@ func (r *AchievementRepository) FindAll() ([]model.AchievementReference, error)
 	}
 	return list, nil
 }
+
+const referenceColumns = `ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at`
+
+func scanReference(row interface{ Scan(...interface{}) error }) (*model.AchievementReference, error) {
+	var ref model.AchievementReference
+	err := row.Scan(
+		&ref.ID,
+		&ref.StudentID,
+		&ref.MongoAchievementID,
+		&ref.Status,
+		&ref.SubmittedAt,
+		&ref.VerifiedAt,
+		&ref.VerifiedBy,
+		&ref.RejectionNote,
+		&ref.CreatedAt,
+		&ref.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// referenceColumn ekspresi SQL kolom filter / sorting reference
+func referenceColumn(name string) string {
+	if name == model.StageStartedColumn {
+		return stageStartedSQL
+	}
+	return "ar." + name
+}
+
+// buildReferenceWhere menyusun klausa FROM/WHERE dari filter beserta argumennya
+func buildReferenceWhere(f model.ReferenceFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	from := `FROM achievement_references ar`
+	if f.AdvisorID != "" || f.ProgramStudy != "" {
+		from += ` JOIN students s ON s.id = ar.student_id`
+	}
+
+	if f.StudentID != "" {
+		student := arg(f.StudentID)
+		if f.IncludeMembers {
+			conds = append(conds, "(ar.student_id = "+student+" OR EXISTS (SELECT 1 FROM achievement_members m WHERE m.achievement_ref_id = ar.id AND m.student_id = "+student+" AND m.status = '"+model.MemberConfirmed+"'))")
+		} else {
+			conds = append(conds, "ar.student_id = "+student)
+		}
+	}
+
+	// advisorOf: kolom advisor_id adalah AdvisorID atau dosen wali yang mendelegasikan kepadanya
+	var advisorOf func(column string) string
+	if f.AdvisorID != "" {
+		advisor := arg(f.AdvisorID)
+		delegators := ""
+		if len(f.DelegatorIDs) > 0 {
+			delegators = arg(pq.Array(f.DelegatorIDs))
+		}
+		advisorOf = func(column string) string {
+			if delegators == "" {
+				return column + " = " + advisor
+			}
+			return "(" + column + " = " + advisor + " OR " + column + " = ANY(" + delegators + "))"
+		}
+
+		if f.IncludeMembers {
+			conds = append(conds, "("+advisorOf("s.advisor_id")+` OR EXISTS (
+				SELECT 1 FROM achievement_members m JOIN students ms ON ms.id = m.student_id
+				WHERE m.achievement_ref_id = ar.id AND m.status = '`+model.MemberConfirmed+`' AND `+advisorOf("ms.advisor_id")+`
+			))`)
+		} else {
+			conds = append(conds, advisorOf("s.advisor_id"))
+		}
+	}
+	if f.ProgramStudy != "" {
+		conds = append(conds, "s.program_study = "+arg(f.ProgramStudy))
+	}
+	if f.Approver != nil {
+		match := fmt.Sprintf(approverMatchSQL, arg(f.Approver.Role), arg(f.Approver.UserID))
+		if f.AwaitingApproval {
+			conds = append(conds, "EXISTS (SELECT 1 FROM achievement_approvals ap WHERE "+currentStageSQL+" AND "+match+")")
+		} else {
+			conds = append(conds, "EXISTS (SELECT 1 FROM achievement_approvals ap WHERE ap.achievement_ref_id = ar.id AND "+match+")")
+		}
+	} else if f.AwaitingApproval && f.AdvisorID != "" {
+		// Tahap dosen wali pengaju atau pengajuan tanpa tahap (sebelum rantai
+		// persetujuan), atau tahap dosen wali anggota tim
+		conds = append(conds, `((`+advisorOf("s.advisor_id")+` AND (
+				EXISTS (SELECT 1 FROM achievement_approvals ap WHERE `+currentStageSQL+` AND ap.scope = '`+model.ScopeAdvisor+`')
+				OR NOT EXISTS (SELECT 1 FROM achievement_approvals ap WHERE ap.achievement_ref_id = ar.id AND ap.submitted_at = ar.submitted_at)))
+			OR EXISTS (
+				SELECT 1 FROM achievement_approvals ap JOIN students ms ON ms.id::text = ap.scope_value
+				WHERE `+currentStageSQL+` AND ap.scope = '`+model.ScopeMemberAdvisor+`' AND `+advisorOf("ms.advisor_id")+`
+			))`)
+	}
+	if len(f.Statuses) > 0 {
+		conds = append(conds, "ar.status = ANY("+arg(pq.Array(f.Statuses))+")")
+	} else if f.AwaitingApproval {
+		conds = append(conds, "ar.status = "+arg(model.StatusSubmitted))
+	} else if !f.IncludeDeleted {
+		conds = append(conds, "ar.status <> "+arg(model.StatusDeleted))
+	}
+	if f.DateColumn != "" && f.From != nil {
+		conds = append(conds, referenceColumn(f.DateColumn)+" >= "+arg(*f.From))
+	}
+	if f.DateColumn != "" && f.To != nil {
+		conds = append(conds, referenceColumn(f.DateColumn)+" < "+arg(*f.To))
+	}
+	if f.RestrictMongoIDs {
+		conds = append(conds, "ar.mongo_achievement_id = ANY("+arg(pq.Array(f.MongoIDs))+")")
+	}
+
+	if len(conds) > 0 {
+		from += " WHERE " + strings.Join(conds, " AND ")
+	}
+	return from, args
+}
+
+// Search mengambil reference sesuai filter beserta total baris (sebelum paging).
+// DateColumn dan SortColumn harus sudah divalidasi oleh pemanggil (whitelist kolom).
+func (r *AchievementRepository) Search(f model.ReferenceFilter) ([]model.AchievementReference, int, error) {
+	from, args := buildReferenceWhere(f)
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) `+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn := f.SortColumn
+	if sortColumn == "" {
+		sortColumn = "created_at"
+	}
+	order := "ASC"
+	if f.SortDesc {
+		order = "DESC"
+	}
+	query := `SELECT ` + referenceColumns + ` ` + from +
+		fmt.Sprintf(` ORDER BY %s %s NULLS LAST, ar.id`, referenceColumn(sortColumn), order)
+	if f.Limit > 0 {
+		args = append(args, f.Limit, f.Offset)
+		query += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementReference{}
+	for rows.Next() {
+		ref, err := scanReference(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, *ref)
+	}
+	return list, total, rows.Err()
+}
@@ -0,0 +1,212 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// Test TransitionStatus - Success
+func TestTransitionStatus_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_references`).
+		WithArgs(model.StatusSubmitted, sqlmock.AnyArg(), nil, nil, nil, sqlmock.AnyArg(), "ref-1", model.StatusDraft).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_status_history`).
+		WithArgs("ref-1", model.StatusDraft, model.StatusSubmitted, "user-1", "Mahasiswa", nil, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.TransitionStatus("ref-1", model.StatusChange{
+		From:      model.StatusDraft,
+		To:        model.StatusSubmitted,
+		ActorID:   "user-1",
+		ActorRole: "Mahasiswa",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test TransitionStatus - Status sudah diubah request lain
+func TestTransitionStatus_Conflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_references`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT status FROM achievement_references`).
+		WithArgs("ref-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusVerified))
+	mock.ExpectRollback()
+
+	err = repo.TransitionStatus("ref-1", model.StatusChange{
+		From: model.StatusSubmitted,
+		To:   model.StatusRejected,
+	})
+
+	var tErr *model.StatusTransitionError
+	assert.True(t, errors.As(err, &tErr))
+	assert.Equal(t, model.StatusVerified, tErr.Current)
+	assert.Equal(t, model.StatusRejected, tErr.Requested)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindStatusHistory - Success
+func TestFindStatusHistory_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "achievement_ref_id", "from_status", "to_status", "actor_id", "actor_role", "on_behalf_of", "note", "created_at",
+	}).
+		AddRow("h-1", "ref-1", "draft", "submitted", "user-1", "Mahasiswa", nil, nil, now).
+		AddRow("h-2", "ref-1", "submitted", "rejected", "user-2", "Dosen Wali", "advisor-1", "tanggal salah", now)
+
+	mock.ExpectQuery(`FROM achievement_status_history`).
+		WithArgs("ref-1").
+		WillReturnRows(rows)
+
+	history, err := repo.FindStatusHistory("ref-1")
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "rejected", history[1].ToStatus)
+	assert.Equal(t, "tanggal salah", *history[1].Note)
+	assert.Equal(t, "advisor-1", *history[1].OnBehalfOf)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test TransitionStatusBatch - satu item gagal tidak membatalkan item lain
+func TestTransitionStatusBatch_PartialFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectBegin()
+
+	// item 1: sukses
+	mock.ExpectExec(`SAVEPOINT bulk_item`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE achievement_references`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_status_history`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`RELEASE SAVEPOINT bulk_item`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// item 2: sudah diverifikasi dosen lain
+	mock.ExpectExec(`SAVEPOINT bulk_item`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE achievement_references`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT status FROM achievement_references`).
+		WithArgs("ref-2").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusVerified))
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT bulk_item`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectCommit()
+
+	change := model.StatusChange{From: model.StatusSubmitted, To: model.StatusVerified}
+	errs, err := repo.TransitionStatusBatch(
+		[]string{"ref-1", "ref-2"},
+		[]model.StatusChange{change, change},
+	)
+
+	assert.NoError(t, err)
+	assert.NoError(t, errs[0])
+	var tErr *model.StatusTransitionError
+	assert.True(t, errors.As(errs[1], &tErr))
+	assert.Equal(t, model.StatusVerified, tErr.Current)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Search - filter advisor + status dengan paging
+func TestSearch_AdvisorScopeWithPaging(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM achievement_references ar JOIN students s ON s.id = ar.student_id WHERE s.advisor_id = \$1 AND ar.status = ANY\(\$2\)`).
+		WithArgs("advisor-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+
+	now := time.Now()
+	mock.ExpectQuery(`ORDER BY ar.verified_at ASC NULLS LAST, ar.id LIMIT \$3 OFFSET \$4`).
+		WithArgs("advisor-1", sqlmock.AnyArg(), 10, 20).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "student_id", "mongo_achievement_id", "status", "submitted_at", "verified_at", "verified_by", "rejection_note", "created_at", "updated_at",
+		}).AddRow("ref-21", "student-1", "mongo-21", model.StatusVerified, now, now, "advisor-1", nil, now, now))
+
+	refs, total, err := repo.Search(model.ReferenceFilter{
+		AdvisorID:  "advisor-1",
+		Statuses:   []string{model.StatusVerified},
+		SortColumn: "verified_at",
+		Limit:      10,
+		Offset:     20,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 21, total)
+	assert.Len(t, refs, 1)
+	assert.Equal(t, "ref-21", refs[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindByStudentIDs - satu query untuk banyak mahasiswa
+func TestFindByStudentIDs_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(`WHERE ar.student_id = ANY\(\$1\) AND ar.status <> \$2`).
+		WithArgs(sqlmock.AnyArg(), model.StatusDeleted).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "student_id", "mongo_achievement_id", "status", "submitted_at", "verified_at", "verified_by", "rejection_note", "created_at", "updated_at",
+		}).
+			AddRow("ref-2", "student-2", "mongo-2", model.StatusDraft, nil, nil, nil, nil, now, now).
+			AddRow("ref-1", "student-1", "mongo-1", model.StatusSubmitted, now, nil, nil, nil, now, now))
+
+	refs, err := repo.FindByStudentIDs([]string{"student-1", "student-2"})
+
+	assert.NoError(t, err)
+	assert.Len(t, refs, 2)
+	assert.Equal(t, "ref-2", refs[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindByStudentIDs - tanpa mahasiswa tidak query ke database
+func TestFindByStudentIDs_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	refs, err := repo.FindByStudentIDs(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, refs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-fiber/app/model"
+)
+
+var ErrAchievementTypeNotFound = errors.New("achievement type not found")
+
+type AchievementTypeRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementTypeRepository(db *sql.DB) *AchievementTypeRepository {
+	return &AchievementTypeRepository{db: db}
+}
+
+func scanAchievementType(row interface{ Scan(...interface{}) error }) (*model.AchievementType, error) {
+	var t model.AchievementType
+	var description sql.NullString
+	var schema []byte
+	if err := row.Scan(&t.Code, &t.Name, &description, &schema, &t.IsActive, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	t.Description = description.String
+	if len(schema) > 0 {
+		if err := json.Unmarshal(schema, &t.Schema); err != nil {
+			return nil, fmt.Errorf("invalid schema for type %s: %v", t.Code, err)
+		}
+	}
+	return &t, nil
+}
+
+// FindAll mengambil registry jenis prestasi; includeInactive untuk tampilan admin
+func (r *AchievementTypeRepository) FindAll(includeInactive bool) ([]model.AchievementType, error) {
+	query := `
+		SELECT code, name, description, schema, is_active, created_at, updated_at
+		FROM achievement_types
+	`
+	if !includeInactive {
+		query += ` WHERE is_active = true`
+	}
+	query += ` ORDER BY name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementType{}
+	for rows.Next() {
+		t, err := scanAchievementType(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *t)
+	}
+	return list, rows.Err()
+}
+
+func (r *AchievementTypeRepository) FindByCode(code string) (*model.AchievementType, error) {
+	t, err := scanAchievementType(r.db.QueryRow(`
+		SELECT code, name, description, schema, is_active, created_at, updated_at
+		FROM achievement_types WHERE code = $1
+	`, code))
+	if err == sql.ErrNoRows {
+		return nil, ErrAchievementTypeNotFound
+	}
+	return t, err
+}
+
+func (r *AchievementTypeRepository) Create(req *model.AchievementTypeRequest) error {
+	schema, err := json.Marshal(req.Schema)
+	if err != nil {
+		return err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now()
+	_, err = r.db.Exec(`
+		INSERT INTO achievement_types (code, name, description, schema, is_active, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`, req.Code, req.Name, req.Description, schema, isActive, now, now)
+	return err
+}
+
+func (r *AchievementTypeRepository) Update(code string, req *model.AchievementTypeRequest) error {
+	schema, err := json.Marshal(req.Schema)
+	if err != nil {
+		return err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	res, err := r.db.Exec(`
+		UPDATE achievement_types
+		SET name=$1, description=$2, schema=$3, is_active=$4, updated_at=$5
+		WHERE code=$6
+	`, req.Name, req.Description, schema, isActive, time.Now(), code)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAchievementTypeNotFound
+	}
+	return nil
+}
+
+// Deactivate menonaktifkan jenis prestasi. Tidak dihapus permanen karena
+// dokumen prestasi lama masih memakai kode tersebut.
+func (r *AchievementTypeRepository) Deactivate(code string) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_types SET is_active=false, updated_at=$1 WHERE code=$2
+	`, time.Now(), code)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrAchievementTypeNotFound
+	}
+	return nil
+}
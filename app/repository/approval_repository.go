@@ -0,0 +1,496 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrApprovalDecided tahap persetujuan sudah diputuskan oleh request lain
+var ErrApprovalDecided = errors.New("approval stage has already been decided")
+
+type ApprovalRepository struct {
+	db *sql.DB
+}
+
+func NewApprovalRepository(db *sql.DB) *ApprovalRepository {
+	return &ApprovalRepository{db: db}
+}
+
+// approverMatchSQL: tahap ap bisa diputuskan oleh user dengan role tertentu.
+// Argumen pertama role, kedua user ID.
+const approverMatchSQL = `ap.role = %s AND (ap.scope = 'all' OR EXISTS (
+	SELECT 1 FROM approver_scopes x
+	WHERE x.user_id = %s AND x.scope = ap.scope AND x.value = ap.scope_value
+))`
+
+// currentStageSQL: ap adalah tahap aktif pengajuan terakhir ar (tahap menunggu
+// dengan urutan terkecil)
+const currentStageSQL = `ap.achievement_ref_id = ar.id AND ap.submitted_at = ar.submitted_at AND ap.decision IS NULL
+	AND ap.stage_order = (
+		SELECT MIN(pp.stage_order) FROM achievement_approvals pp
+		WHERE pp.achievement_ref_id = ap.achievement_ref_id AND pp.submitted_at = ap.submitted_at
+		  AND pp.decision IS NULL
+	)`
+
+// stageStartedSQL: waktu tahap aktif pengajuan terakhir ar mulai menunggu, yaitu
+// waktu tahap terakhir disetujui, atau waktu submit jika belum ada
+const stageStartedSQL = `COALESCE((
+		SELECT MAX(sp.decided_at) FROM achievement_approvals sp
+		WHERE sp.achievement_ref_id = ar.id AND sp.submitted_at = ar.submitted_at
+		  AND sp.decision = '` + model.ApprovalApproved + `'
+	), ar.submitted_at)`
+
+// ===== Rantai persetujuan =====
+
+const approvalChainColumns = `id, achievement_type, competition_level, stages, description, is_active, created_at, updated_at`
+
+func scanApprovalChain(row interface{ Scan(...interface{}) error }) (*model.ApprovalChain, error) {
+	var ch model.ApprovalChain
+	var description sql.NullString
+	var stages []byte
+	err := row.Scan(
+		&ch.ID,
+		&ch.AchievementType,
+		&ch.CompetitionLevel,
+		&stages,
+		&description,
+		&ch.IsActive,
+		&ch.CreatedAt,
+		&ch.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ch.Description = description.String
+	if err := json.Unmarshal(stages, &ch.Stages); err != nil {
+		return nil, fmt.Errorf("invalid stages for approval chain %s: %v", ch.ID, err)
+	}
+	return &ch, nil
+}
+
+func (r *ApprovalRepository) queryChains(query string, args ...interface{}) ([]model.ApprovalChain, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.ApprovalChain{}
+	for rows.Next() {
+		ch, err := scanApprovalChain(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *ch)
+	}
+	return list, rows.Err()
+}
+
+func (r *ApprovalRepository) FindAllChains() ([]model.ApprovalChain, error) {
+	return r.queryChains(`SELECT ` + approvalChainColumns + ` FROM approval_chains ORDER BY created_at`)
+}
+
+// FindActiveChains dipakai saat submit untuk memilih rantai
+func (r *ApprovalRepository) FindActiveChains() ([]model.ApprovalChain, error) {
+	return r.queryChains(`SELECT ` + approvalChainColumns + ` FROM approval_chains WHERE is_active = true ORDER BY created_at`)
+}
+
+func (r *ApprovalRepository) FindChainByID(id string) (*model.ApprovalChain, error) {
+	ch, err := scanApprovalChain(r.db.QueryRow(`SELECT `+approvalChainColumns+` FROM approval_chains WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("approval chain not found")
+	}
+	return ch, err
+}
+
+func (r *ApprovalRepository) CreateChain(req *model.ApprovalChainRequest) (*model.ApprovalChain, error) {
+	stages, err := json.Marshal(req.Stages)
+	if err != nil {
+		return nil, err
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now()
+	chain := &model.ApprovalChain{
+		ID:               uuid.New().String(),
+		AchievementType:  req.AchievementType,
+		CompetitionLevel: req.CompetitionLevel,
+		Stages:           req.Stages,
+		Description:      req.Description,
+		IsActive:         isActive,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO approval_chains
+		(`+approvalChainColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	`,
+		chain.ID,
+		chain.AchievementType,
+		chain.CompetitionLevel,
+		stages,
+		chain.Description,
+		chain.IsActive,
+		chain.CreatedAt,
+		chain.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+// UpdateChain hanya berlaku untuk pengajuan berikutnya; pengajuan yang sedang
+// berjalan memakai salinan tahap saat submit
+func (r *ApprovalRepository) UpdateChain(id string, req *model.ApprovalChainRequest) error {
+	stages, err := json.Marshal(req.Stages)
+	if err != nil {
+		return err
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	res, err := r.db.Exec(`
+		UPDATE approval_chains
+		SET achievement_type=$1, competition_level=$2, stages=$3, description=$4,
+		    is_active=$5, updated_at=$6
+		WHERE id=$7
+	`,
+		req.AchievementType,
+		req.CompetitionLevel,
+		stages,
+		req.Description,
+		isActive,
+		time.Now(),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("approval chain not found")
+	}
+	return nil
+}
+
+func (r *ApprovalRepository) DeleteChain(id string) error {
+	res, err := r.db.Exec(`DELETE FROM approval_chains WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("approval chain not found")
+	}
+	return nil
+}
+
+// ===== Cakupan approver =====
+
+// FindScopes penugasan program studi / departemen seorang approver
+func (r *ApprovalRepository) FindScopes(userID string) ([]model.ApproverScope, error) {
+	rows, err := r.db.Query(`
+		SELECT scope, value FROM approver_scopes WHERE user_id = $1 ORDER BY scope, value
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.ApproverScope{}
+	for rows.Next() {
+		var sc model.ApproverScope
+		if err := rows.Scan(&sc.Scope, &sc.Value); err != nil {
+			return nil, err
+		}
+		list = append(list, sc)
+	}
+	return list, rows.Err()
+}
+
+// ReplaceScopes mengganti seluruh penugasan seorang approver
+func (r *ApprovalRepository) ReplaceScopes(userID string, scopes []model.ApproverScope) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM approver_scopes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, sc := range scopes {
+		_, err := tx.Exec(`
+			INSERT INTO approver_scopes (user_id, scope, value) VALUES ($1,$2,$3)
+			ON CONFLICT DO NOTHING
+		`, userID, sc.Scope, sc.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ===== Tahap persetujuan pengajuan =====
+
+const approvalColumns = `id, achievement_ref_id, submitted_at, stage_order, name, role, scope, scope_value, decision, actor_id, on_behalf_of, note, decided_at`
+
+func scanApproval(row interface{ Scan(...interface{}) error }) (*model.AchievementApproval, error) {
+	var a model.AchievementApproval
+	err := row.Scan(
+		&a.ID,
+		&a.AchievementRefID,
+		&a.SubmittedAt,
+		&a.StageOrder,
+		&a.Name,
+		&a.Role,
+		&a.Scope,
+		&a.ScopeValue,
+		&a.Decision,
+		&a.ActorID,
+		&a.OnBehalfOf,
+		&a.Note,
+		&a.DecidedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// FindStages tahap persetujuan satu pengajuan (reference + waktu submit), urut tahap
+func (r *ApprovalRepository) FindStages(refID string, submittedAt time.Time) ([]model.AchievementApproval, error) {
+	rows, err := r.db.Query(`
+		SELECT `+approvalColumns+`
+		FROM achievement_approvals
+		WHERE achievement_ref_id = $1 AND submitted_at = $2
+		ORDER BY stage_order
+	`, refID, submittedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementApproval{}
+	for rows.Next() {
+		a, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *a)
+	}
+	return list, rows.Err()
+}
+
+// FindCurrentStages tahap aktif banyak reference berstatus submitted, di-key
+// dengan ID reference. Reference tanpa tahap (pengajuan sebelum rantai
+// persetujuan) tidak ada di map.
+func (r *ApprovalRepository) FindCurrentStages(refIDs []string) (map[string]model.AchievementApproval, error) {
+	result := map[string]model.AchievementApproval{}
+	if len(refIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+approvalColumns+`
+		FROM achievement_approvals ap
+		WHERE ap.achievement_ref_id = ANY($1) AND ap.decision IS NULL
+		  AND ap.submitted_at = (
+			SELECT ar.submitted_at FROM achievement_references ar
+			WHERE ar.id = ap.achievement_ref_id AND ar.status = $2
+		  )
+		  AND ap.stage_order = (
+			SELECT MIN(pp.stage_order) FROM achievement_approvals pp
+			WHERE pp.achievement_ref_id = ap.achievement_ref_id AND pp.submitted_at = ap.submitted_at
+			  AND pp.decision IS NULL
+		  )
+	`, pq.Array(refIDs), model.StatusSubmitted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		a, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		result[a.AchievementRefID] = *a
+	}
+	return result, rows.Err()
+}
+
+// FindStageStarts waktu tahap aktif mulai menunggu untuk banyak reference
+// berstatus submitted, di-key dengan ID reference
+func (r *ApprovalRepository) FindStageStarts(refIDs []string) (map[string]time.Time, error) {
+	result := map[string]time.Time{}
+	if len(refIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT ar.id, `+stageStartedSQL+`
+		FROM achievement_references ar
+		WHERE ar.id = ANY($1) AND ar.status = $2 AND ar.submitted_at IS NOT NULL
+	`, pq.Array(refIDs), model.StatusSubmitted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var startedAt time.Time
+		if err := rows.Scan(&id, &startedAt); err != nil {
+			return nil, err
+		}
+		result[id] = startedAt
+	}
+	return result, rows.Err()
+}
+
+// FindApproverIDs user aktif yang termasuk approver tahap (role dan cakupan).
+// Tahap dosen wali tidak tercakup karena ditentukan lewat data bimbingan.
+func (r *ApprovalRepository) FindApproverIDs(approvalID string) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT u.id
+		FROM users u
+		JOIN roles r ON r.id = u.role_id
+		JOIN achievement_approvals ap ON ap.id = $1
+		WHERE u.is_active = true AND `+fmt.Sprintf(approverMatchSQL, "r.name", "u.id")+`
+	`, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CanDecide memeriksa apakah user dengan role tertentu termasuk approver tahap
+func (r *ApprovalRepository) CanDecide(userID, role, approvalID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM achievement_approvals ap
+			WHERE ap.id = $3 AND `+fmt.Sprintf(approverMatchSQL, "$1", "$2")+`
+		)
+	`, role, userID, approvalID).Scan(&ok)
+	return ok, err
+}
+
+// IsApprover memeriksa apakah user termasuk approver salah satu tahap reference
+// (pengajuan mana pun); dipakai untuk akses baca
+func (r *ApprovalRepository) IsApprover(userID, role, refID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM achievement_approvals ap
+			WHERE ap.achievement_ref_id = $3 AND `+fmt.Sprintf(approverMatchSQL, "$1", "$2")+`
+		)
+	`, role, userID, refID).Scan(&ok)
+	return ok, err
+}
+
+// StudentDepartment departemen mahasiswa, diambil dari departemen dosen walinya.
+// Kosong jika mahasiswa belum punya dosen wali.
+func (r *ApprovalRepository) StudentDepartment(studentID string) (string, error) {
+	var department string
+	err := r.db.QueryRow(`
+		SELECT l.department
+		FROM students s
+		JOIN lecturers l ON l.user_id = s.advisor_id
+		WHERE s.id = $1
+	`, studentID).Scan(&department)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return department, err
+}
+
+// insertApprovalsTx menyimpan tahap pengajuan baru (dipanggil saat submit)
+func insertApprovalsTx(tx *sql.Tx, refID string, submittedAt time.Time, stages []model.AchievementApproval) error {
+	for _, a := range stages {
+		id := a.ID
+		if id == "" {
+			id = uuid.New().String()
+		}
+		_, err := tx.Exec(`
+			INSERT INTO achievement_approvals
+			(id, achievement_ref_id, submitted_at, stage_order, name, role, scope, scope_value)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		`,
+			id,
+			refID,
+			submittedAt,
+			a.StageOrder,
+			a.Name,
+			a.Role,
+			a.Scope,
+			a.ScopeValue,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decideApprovalTx mencatat keputusan tahap aktif. Gagal dengan
+// ErrApprovalDecided jika tahap sudah diputuskan atau bukan milik pengajuan
+// terakhir reference.
+func decideApprovalTx(tx *sql.Tx, refID string, change model.StatusChange, at time.Time) error {
+	decision := model.ApprovalApproved
+	switch change.To {
+	case model.StatusRejected:
+		decision = model.ApprovalRejected
+	case model.StatusRevisionRequested:
+		decision = model.ApprovalRevisionRequested
+	}
+
+	res, err := tx.Exec(`
+		UPDATE achievement_approvals
+		SET decision=$1, actor_id=$2, on_behalf_of=$3, note=$4, decided_at=$5
+		WHERE id=$6 AND achievement_ref_id=$7 AND decision IS NULL
+		  AND submitted_at = (SELECT submitted_at FROM achievement_references WHERE id = $7)
+	`,
+		decision,
+		nullString(change.ActorID),
+		nullString(change.OnBehalfOf),
+		change.Note,
+		at,
+		change.ApprovalID,
+		refID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrApprovalDecided
+	}
+	return nil
+}
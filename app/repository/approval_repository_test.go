@@ -0,0 +1,169 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// Test TransitionStatus - submit menyimpan tahap persetujuan dalam transaksi yang sama
+func TestTransitionStatus_SubmitWithApprovals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	now := time.Now()
+	programStudy := "Informatika"
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_references`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_approvals`).
+		WithArgs("stage-1", "ref-1", now, 1, "Dosen Wali", "Dosen Wali", model.ScopeAdvisor, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_approvals`).
+		WithArgs("stage-2", "ref-1", now, 2, "Kaprodi", "Kaprodi", model.ScopeProgramStudy, &programStudy).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_status_history`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.TransitionStatus("ref-1", model.StatusChange{
+		From:        model.StatusDraft,
+		To:          model.StatusSubmitted,
+		SubmittedAt: &now,
+		Approvals: []model.AchievementApproval{
+			{ID: "stage-1", StageOrder: 1, Name: "Dosen Wali", Role: "Dosen Wali", Scope: model.ScopeAdvisor},
+			{ID: "stage-2", StageOrder: 2, Name: "Kaprodi", Role: "Kaprodi", Scope: model.ScopeProgramStudy, ScopeValue: &programStudy},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test TransitionStatus - tahap disetujui tanpa mengubah status
+func TestTransitionStatus_StageApproval(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	note := "approved stage 1 (Dosen Wali)"
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_references`).
+		WithArgs(model.StatusSubmitted, nil, nil, nil, nil, sqlmock.AnyArg(), "ref-1", model.StatusSubmitted).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE achievement_approvals`).
+		WithArgs(model.ApprovalApproved, "advisor-2", "advisor-1", &note, sqlmock.AnyArg(), "stage-1", "ref-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_status_history`).
+		WithArgs("ref-1", model.StatusSubmitted, model.StatusSubmitted, "advisor-2", "Dosen Wali", "advisor-1", &note, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.TransitionStatus("ref-1", model.StatusChange{
+		From:       model.StatusSubmitted,
+		To:         model.StatusSubmitted,
+		ActorID:    "advisor-2",
+		ActorRole:  "Dosen Wali",
+		OnBehalfOf: "advisor-1",
+		Note:       &note,
+		ApprovalID: "stage-1",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test TransitionStatus - tahap sudah diputuskan request lain, status tidak berubah
+func TestTransitionStatus_StageAlreadyDecided(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_references`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE achievement_approvals`).
+		WithArgs(model.ApprovalRejected, "kaprodi-1", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), "stage-2", "ref-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	note := "bukti tidak valid"
+	err = repo.TransitionStatus("ref-1", model.StatusChange{
+		From:          model.StatusSubmitted,
+		To:            model.StatusRejected,
+		ActorID:       "kaprodi-1",
+		ActorRole:     "Kaprodi",
+		RejectionNote: &note,
+		Note:          &note,
+		ApprovalID:    "stage-2",
+	})
+
+	assert.ErrorIs(t, err, repository.ErrApprovalDecided)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Search - antrian approver hanya tahap aktif dengan role dan cakupannya
+func TestSearch_ApproverQueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM achievement_references ar WHERE EXISTS \(SELECT 1 FROM achievement_approvals ap WHERE .*ap.decision IS NULL.*ap.role = \$1 .*x.user_id = \$2.*\) AND ar.status = \$3$`).
+		WithArgs("Kaprodi", "kaprodi-1", model.StatusSubmitted).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`ORDER BY ar.submitted_at ASC NULLS LAST, ar.id LIMIT \$4 OFFSET \$5`).
+		WithArgs("Kaprodi", "kaprodi-1", model.StatusSubmitted, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, total, err := repo.Search(model.ReferenceFilter{
+		Approver:         &model.ApproverFilter{UserID: "kaprodi-1", Role: "Kaprodi"},
+		AwaitingApproval: true,
+		SortColumn:       "submitted_at",
+		Limit:            20,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Search - filter overdue memakai waktu tahap aktif mulai menunggu, bukan waktu submit
+func TestSearch_StageStartedColumn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	threshold := time.Now().Add(-7 * 24 * time.Hour)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM achievement_references ar WHERE ar.status = ANY\(\$1\) AND COALESCE\(\(\s+SELECT MAX\(sp.decided_at\) .*sp.decision = 'approved'\s+\), ar.submitted_at\) < \$2$`).
+		WithArgs(sqlmock.AnyArg(), threshold).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`ORDER BY COALESCE\(.*\), ar.submitted_at\) ASC NULLS LAST, ar.id$`).
+		WithArgs(sqlmock.AnyArg(), threshold).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, _, err = repo.Search(model.ReferenceFilter{
+		Statuses:   []string{model.StatusSubmitted},
+		DateColumn: model.StageStartedColumn,
+		To:         &threshold,
+		SortColumn: model.StageStartedColumn,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
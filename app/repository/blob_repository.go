@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"database/sql"
+
+	"go-fiber/app/model"
+)
+
+type BlobRepository struct {
+	db *sql.DB
+}
+
+func NewBlobRepository(db *sql.DB) *BlobRepository {
+	return &BlobRepository{db: db}
+}
+
+// Acquire mencatat ref baru ke blob, membuat baris blob jika hash belum pernah
+// disimpan. created = true berarti baris blob baru dibuat dan isinya belum
+// ada di storage. Mengembalikan key storage blob (milik baris yang sudah ada
+// jika hash sama). Kuota diperiksa di transaksi yang sama dengan baris
+// mahasiswa dikunci, sehingga upload paralel tidak bisa melewatinya bersama;
+// jika terlampaui dikembalikan *model.QuotaExceededError.
+func (r *BlobRepository) Acquire(blob model.AttachmentBlob, ref model.BlobRef, quota model.BlobQuota) (string, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", false, err
+	}
+	defer tx.Rollback()
+
+	if quota.Student > 0 || quota.Achievement > 0 {
+		if err := checkQuota(tx, ref, quota); err != nil {
+			return "", false, err
+		}
+	}
+
+	// xmax = 0 hanya untuk baris yang baru di-insert (bukan hasil DO UPDATE)
+	var key string
+	var created bool
+	err = tx.QueryRow(`
+		INSERT INTO attachment_blobs (sha256, storage_key, size, content_type)
+		VALUES ($1,$2,$3,$4)
+		ON CONFLICT (sha256) DO UPDATE SET updated_at = NOW()
+		RETURNING storage_key, (xmax = 0)
+	`, blob.SHA256, blob.StorageKey, blob.Size, blob.ContentType).Scan(&key, &created)
+	if err != nil {
+		return "", false, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO attachment_blob_refs (id, attachment_id, sha256, student_id, achievement_ref_id, size)
+		VALUES ($1,$2,$3,$4,$5,$6)
+	`, ref.ID, ref.AttachmentID, blob.SHA256, ref.StudentID, ref.AchievementRefID, ref.Size)
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", false, err
+	}
+	return key, created, nil
+}
+
+func checkQuota(tx *sql.Tx, ref model.BlobRef, quota model.BlobQuota) error {
+	if _, err := tx.Exec(`SELECT 1 FROM students WHERE id = $1 FOR UPDATE`, ref.StudentID); err != nil {
+		return err
+	}
+	var student, achievement int64
+	err := tx.QueryRow(usedBytesQuery, ref.StudentID, ref.AchievementRefID).Scan(&student, &achievement)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range []model.QuotaExceededError{
+		{Scope: "student", Quota: quota.Student, Used: student},
+		{Scope: "achievement", Quota: quota.Achievement, Used: achievement},
+	} {
+		if l.Quota > 0 && l.Used-quota.Freed+ref.Size > l.Quota {
+			l.Requested = ref.Size - quota.Freed
+			return &l
+		}
+	}
+	return nil
+}
+
+// Release menghapus ref. Jika itu ref terakhir, deleteBlob dipanggil dengan key
+// storage blob selagi baris blob dikunci (upload hash yang sama menunggu), lalu
+// baris blob dihapus. Jika deleteBlob gagal, ref tetap dihapus dan baris blob
+// dibiarkan; upload berikutnya dengan hash sama akan menulis ulang isinya.
+// found = false jika ref sudah tidak ada (mis. recovery mengulang langkah).
+func (r *BlobRepository) Release(refID string, deleteBlob func(key string) error) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var sum string
+	err = tx.QueryRow(`DELETE FROM attachment_blob_refs WHERE id = $1 RETURNING sha256`, refID).Scan(&sum)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var key string
+	err = tx.QueryRow(`SELECT storage_key FROM attachment_blobs WHERE sha256 = $1 FOR UPDATE`, sum).Scan(&key)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+
+	var refs int
+	if err == nil {
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM attachment_blob_refs WHERE sha256 = $1`, sum).Scan(&refs); err != nil {
+			return false, err
+		}
+		if refs == 0 {
+			if deleteErr := deleteBlob(key); deleteErr != nil {
+				if err := tx.Commit(); err != nil {
+					return false, err
+				}
+				return true, deleteErr
+			}
+			if _, err := tx.Exec(`DELETE FROM attachment_blobs WHERE sha256 = $1`, sum); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// InUse memeriksa apakah blob dengan hash tersebut masih punya ref
+func (r *BlobRepository) InUse(sum string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM attachment_blob_refs WHERE sha256 = $1)`, sum).Scan(&exists)
+	return exists, err
+}
+
+// UsedBytes total ukuran lampiran seorang mahasiswa dan yang berada di satu prestasi.
+// Dihitung per lampiran: file yang sama di dua prestasi dihitung dua kali.
+func (r *BlobRepository) UsedBytes(studentID, achievementRefID string) (int64, int64, error) {
+	var student, achievement int64
+	err := r.db.QueryRow(usedBytesQuery, studentID, achievementRefID).Scan(&student, &achievement)
+	return student, achievement, err
+}
+
+const usedBytesQuery = `
+	SELECT COALESCE(SUM(size), 0),
+	       COALESCE(SUM(size) FILTER (WHERE achievement_ref_id = $2), 0)
+	FROM attachment_blob_refs
+	WHERE student_id = $1
+`
+
+// UsageByAchievement pemakaian penyimpanan seorang mahasiswa per prestasi,
+// terbesar lebih dulu
+func (r *BlobRepository) UsageByAchievement(studentID string) ([]model.AchievementStorageUsage, error) {
+	rows, err := r.db.Query(`
+		SELECT achievement_ref_id, SUM(size), COUNT(*)
+		FROM attachment_blob_refs
+		WHERE student_id = $1
+		GROUP BY achievement_ref_id
+		ORDER BY SUM(size) DESC
+	`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementStorageUsage{}
+	for rows.Next() {
+		var u model.AchievementStorageUsage
+		if err := rows.Scan(&u.AchievementRefID, &u.UsedBytes, &u.Attachments); err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
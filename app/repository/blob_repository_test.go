@@ -0,0 +1,185 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// Test Acquire - hash sudah ada, key blob lama dipakai ulang
+func TestBlobAcquire_Existing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBlobRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO attachment_blobs`).
+		WithArgs("abc", "blobs/sha256/ab/abc", int64(10), "application/pdf").
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key", "created"}).AddRow("blobs/sha256/ab/abc", false))
+	mock.ExpectExec(`INSERT INTO attachment_blob_refs`).
+		WithArgs("ref-1", "att-1", "abc", "student-1", "ach-1", int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	key, created, err := repo.Acquire(
+		model.AttachmentBlob{SHA256: "abc", StorageKey: "blobs/sha256/ab/abc", Size: 10, ContentType: "application/pdf"},
+		model.BlobRef{ID: "ref-1", AttachmentID: "att-1", StudentID: "student-1", AchievementRefID: "ach-1", Size: 10},
+		model.BlobQuota{},
+	)
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "blobs/sha256/ab/abc", key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Acquire - baris mahasiswa dikunci dan kuota terlampaui, ref tidak dicatat
+func TestBlobAcquire_QuotaExceeded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBlobRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT 1 FROM students WHERE id = \$1 FOR UPDATE`).
+		WithArgs("student-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM attachment_blob_refs`).
+		WithArgs("student-1", "ach-1").
+		WillReturnRows(sqlmock.NewRows([]string{"student", "achievement"}).AddRow(int64(95), int64(40)))
+	mock.ExpectRollback()
+
+	_, _, err = repo.Acquire(
+		model.AttachmentBlob{SHA256: "abc", StorageKey: "blobs/sha256/ab/abc", Size: 10, ContentType: "application/pdf"},
+		model.BlobRef{ID: "ref-1", AttachmentID: "att-1", StudentID: "student-1", AchievementRefID: "ach-1", Size: 10},
+		model.BlobQuota{Student: 100, Achievement: 50},
+	)
+
+	var qErr *model.QuotaExceededError
+	assert.True(t, errors.As(err, &qErr))
+	assert.Equal(t, "student", qErr.Scope)
+	assert.Equal(t, int64(95), qErr.Used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Release - ref terakhir, isi file dan baris blob dihapus
+func TestBlobRelease_LastRef(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBlobRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM attachment_blob_refs WHERE id = \$1 RETURNING sha256`).
+		WithArgs("ref-1").
+		WillReturnRows(sqlmock.NewRows([]string{"sha256"}).AddRow("abc"))
+	mock.ExpectQuery(`SELECT storage_key FROM attachment_blobs WHERE sha256 = \$1 FOR UPDATE`).
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("blobs/sha256/ab/abc"))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM attachment_blob_refs`).
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`DELETE FROM attachment_blobs WHERE sha256 = \$1`).
+		WithArgs("abc").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	var deleted string
+	found, err := repo.Release("ref-1", func(key string) error {
+		deleted = key
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "blobs/sha256/ab/abc", deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Release - blob masih dipakai lampiran lain, isi file tidak dihapus
+func TestBlobRelease_StillReferenced(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBlobRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM attachment_blob_refs`).
+		WithArgs("ref-1").
+		WillReturnRows(sqlmock.NewRows([]string{"sha256"}).AddRow("abc"))
+	mock.ExpectQuery(`SELECT storage_key FROM attachment_blobs`).
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("blobs/sha256/ab/abc"))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM attachment_blob_refs`).
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectCommit()
+
+	found, err := repo.Release("ref-1", func(key string) error {
+		t.Fatalf("blob %s must not be deleted", key)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Release - hapus file gagal, ref tetap dilepas dan baris blob dibiarkan
+func TestBlobRelease_DeleteFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBlobRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM attachment_blob_refs`).
+		WithArgs("ref-1").
+		WillReturnRows(sqlmock.NewRows([]string{"sha256"}).AddRow("abc"))
+	mock.ExpectQuery(`SELECT storage_key FROM attachment_blobs`).
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("blobs/sha256/ab/abc"))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM attachment_blob_refs`).
+		WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectCommit()
+
+	storageErr := errors.New("storage unavailable")
+	found, err := repo.Release("ref-1", func(string) error { return storageErr })
+
+	assert.ErrorIs(t, err, storageErr)
+	assert.True(t, found)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Release - ref sudah dilepas sebelumnya (recovery mengulang langkah)
+func TestBlobRelease_AlreadyReleased(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBlobRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM attachment_blob_refs`).
+		WithArgs("ref-1").
+		WillReturnRows(sqlmock.NewRows([]string{"sha256"}))
+	mock.ExpectRollback()
+
+	found, err := repo.Release("ref-1", func(string) error { return nil })
+
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
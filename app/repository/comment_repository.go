@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+type CommentRepository struct {
+	db *sql.DB
+}
+
+func NewCommentRepository(db *sql.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) Create(comment *model.AchievementComment) error {
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO achievement_comments
+		(id, achievement_ref_id, author_id, author_role, body, field, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`,
+		comment.ID,
+		comment.AchievementRefID,
+		comment.AuthorID,
+		comment.AuthorRole,
+		comment.Body,
+		comment.Field,
+		comment.CreatedAt,
+	)
+	return err
+}
+
+// FindByReferenceID mengambil thread komentar sebuah prestasi, urut dari yang terlama
+func (r *CommentRepository) FindByReferenceID(refID string) ([]model.AchievementComment, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, c.achievement_ref_id, c.author_id, COALESCE(u.full_name, ''), c.author_role,
+		       c.body, c.field, c.created_at
+		FROM achievement_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.achievement_ref_id = $1
+		ORDER BY c.created_at ASC
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementComment{}
+	for rows.Next() {
+		var cm model.AchievementComment
+		if err := rows.Scan(
+			&cm.ID,
+			&cm.AchievementRefID,
+			&cm.AuthorID,
+			&cm.AuthorName,
+			&cm.AuthorRole,
+			&cm.Body,
+			&cm.Field,
+			&cm.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, cm)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+// ErrDelegationOverlap sudah ada delegasi yang belum dicabut untuk pasangan
+// dosen wali - delegasi yang sama pada rentang waktu yang beririsan
+var ErrDelegationOverlap = errors.New("delegation overlaps an existing delegation")
+
+type DelegationRepository struct {
+	db *sql.DB
+}
+
+func NewDelegationRepository(db *sql.DB) *DelegationRepository {
+	return &DelegationRepository{db: db}
+}
+
+const delegationColumns = `id, advisor_id, delegate_id, starts_at, ends_at, reason, created_by, created_at, revoked_at, revoked_by`
+
+func scanDelegation(row interface{ Scan(...interface{}) error }) (*model.AdvisorDelegation, error) {
+	var d model.AdvisorDelegation
+	err := row.Scan(
+		&d.ID,
+		&d.AdvisorID,
+		&d.DelegateID,
+		&d.StartsAt,
+		&d.EndsAt,
+		&d.Reason,
+		&d.CreatedBy,
+		&d.CreatedAt,
+		&d.RevokedAt,
+		&d.RevokedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Create menyimpan delegasi baru. Pemeriksaan irisan dan insert berjalan dalam
+// satu transaksi dengan advisory lock per dosen wali.
+func (r *DelegationRepository) Create(d *model.AdvisorDelegation) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	d.CreatedAt = time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, d.AdvisorID); err != nil {
+		return err
+	}
+	var overlap bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM advisor_delegations
+			WHERE advisor_id = $1 AND delegate_id = $2 AND revoked_at IS NULL
+			  AND starts_at < $4 AND ends_at > $3
+		)
+	`, d.AdvisorID, d.DelegateID, d.StartsAt, d.EndsAt).Scan(&overlap)
+	if err != nil {
+		return err
+	}
+	if overlap {
+		return ErrDelegationOverlap
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO advisor_delegations
+		(id, advisor_id, delegate_id, starts_at, ends_at, reason, created_by, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	`,
+		d.ID,
+		d.AdvisorID,
+		d.DelegateID,
+		d.StartsAt,
+		d.EndsAt,
+		d.Reason,
+		d.CreatedBy,
+		d.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *DelegationRepository) FindByID(id string) (*model.AdvisorDelegation, error) {
+	return scanDelegation(r.db.QueryRow(`SELECT `+delegationColumns+` FROM advisor_delegations WHERE id = $1`, id))
+}
+
+// Find mengambil delegasi yang melibatkan userID (sebagai dosen wali atau
+// delegasi; kosong = semua), terbaru lebih dulu. status kosong = semua status.
+func (r *DelegationRepository) Find(userID, status string, now time.Time, limit, offset int) ([]model.AdvisorDelegation, int, error) {
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if userID != "" {
+		p := arg(userID)
+		conds = append(conds, "(advisor_id = "+p+" OR delegate_id = "+p+")")
+	}
+	switch status {
+	case model.DelegationRevoked:
+		conds = append(conds, "revoked_at IS NOT NULL")
+	case model.DelegationScheduled:
+		conds = append(conds, "revoked_at IS NULL", "starts_at > "+arg(now))
+	case model.DelegationActive:
+		p := arg(now)
+		conds = append(conds, "revoked_at IS NULL", "starts_at <= "+p, "ends_at > "+p)
+	case model.DelegationExpired:
+		conds = append(conds, "revoked_at IS NULL", "ends_at <= "+arg(now))
+	}
+
+	from := `FROM advisor_delegations`
+	if len(conds) > 0 {
+		from += " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) `+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + delegationColumns + ` ` + from +
+		` ORDER BY created_at DESC, id LIMIT ` + arg(limit) + ` OFFSET ` + arg(offset)
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []model.AdvisorDelegation{}
+	for rows.Next() {
+		d, err := scanDelegation(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, *d)
+	}
+	return list, total, rows.Err()
+}
+
+// ActiveAdvisorIDs dosen wali yang sedang mendelegasikan verifikasi ke delegateID
+func (r *DelegationRepository) ActiveAdvisorIDs(delegateID string, now time.Time) ([]string, error) {
+	return r.activeIDs(`
+		SELECT DISTINCT advisor_id FROM advisor_delegations
+		WHERE delegate_id = $1 AND revoked_at IS NULL AND starts_at <= $2 AND ends_at > $2
+	`, delegateID, now)
+}
+
+// ActiveDelegateIDs dosen yang sedang menerima delegasi dari advisorID
+func (r *DelegationRepository) ActiveDelegateIDs(advisorID string, now time.Time) ([]string, error) {
+	return r.activeIDs(`
+		SELECT DISTINCT delegate_id FROM advisor_delegations
+		WHERE advisor_id = $1 AND revoked_at IS NULL AND starts_at <= $2 AND ends_at > $2
+	`, advisorID, now)
+}
+
+func (r *DelegationRepository) activeIDs(query, userID string, now time.Time) ([]string, error) {
+	rows, err := r.db.Query(query, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// IsActive memeriksa apakah advisorID sedang mendelegasikan verifikasi ke delegateID
+func (r *DelegationRepository) IsActive(advisorID, delegateID string, now time.Time) (bool, error) {
+	var active bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM advisor_delegations
+			WHERE advisor_id = $1 AND delegate_id = $2 AND revoked_at IS NULL
+			  AND starts_at <= $3 AND ends_at > $3
+		)
+	`, advisorID, delegateID, now).Scan(&active)
+	return active, err
+}
+
+// Revoke mencabut delegasi yang belum berakhir. Mengembalikan false jika
+// delegasi sudah dicabut atau sudah berakhir.
+func (r *DelegationRepository) Revoke(id, revokedBy string, now time.Time) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE advisor_delegations
+		SET revoked_at = $3, revoked_by = $2
+		WHERE id = $1 AND revoked_at IS NULL AND ends_at > $3
+	`, id, revokedBy, now)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
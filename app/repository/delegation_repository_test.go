@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// Test Create - sudah ada delegasi yang beririsan untuk pasangan yang sama
+func TestDelegationCreate_Overlap(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewDelegationRepository(db)
+
+	starts := time.Now()
+	ends := starts.Add(30 * 24 * time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+		WithArgs("advisor-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs("advisor-1", "delegate-1", starts, ends).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	err = repo.Create(&model.AdvisorDelegation{
+		AdvisorID:  "advisor-1",
+		DelegateID: "delegate-1",
+		StartsAt:   starts,
+		EndsAt:     ends,
+		CreatedBy:  "advisor-1",
+	})
+
+	assert.ErrorIs(t, err, repository.ErrDelegationOverlap)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Find - delegasi aktif yang melibatkan seorang dosen
+func TestDelegationFind_Active(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewDelegationRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM advisor_delegations WHERE \(advisor_id = \$1 OR delegate_id = \$1\) AND revoked_at IS NULL AND starts_at <= \$2 AND ends_at > \$2`).
+		WithArgs("lecturer-1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`FROM advisor_delegations .* LIMIT \$3 OFFSET \$4`).
+		WithArgs("lecturer-1", now, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "advisor_id", "delegate_id", "starts_at", "ends_at", "reason", "created_by", "created_at", "revoked_at", "revoked_by",
+		}).AddRow("d-1", "lecturer-1", "lecturer-2", now.Add(-time.Hour), now.Add(time.Hour), "cuti", "lecturer-1", now, nil, nil))
+
+	list, total, err := repo.Find("lecturer-1", model.DelegationActive, now, 20, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, list, 1)
+	assert.Equal(t, model.DelegationActive, list[0].StatusAt(now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
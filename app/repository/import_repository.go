@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+var ErrImportNotFound = errors.New("import not found")
+
+// ImportRepository riwayat import prestasi massal (achievement_imports)
+type ImportRepository struct {
+	db *sql.DB
+}
+
+func NewImportRepository(db *sql.DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// Create menyimpan ringkasan import beserta error per barisnya
+func (r *ImportRepository) Create(imp *model.AchievementImport) error {
+	if imp.ID == "" {
+		imp.ID = uuid.New().String()
+	}
+	imp.CreatedAt = time.Now()
+
+	errs, err := json.Marshal(imp.Errors)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(`
+		INSERT INTO achievement_imports
+		(id, file_name, initial_status, dry_run, total_rows, imported, failed, errors, created_by, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+	`,
+		imp.ID,
+		imp.FileName,
+		imp.InitialStatus,
+		imp.DryRun,
+		imp.TotalRows,
+		imp.Imported,
+		imp.Failed,
+		errs,
+		imp.CreatedBy,
+		imp.CreatedAt,
+	)
+	return err
+}
+
+// FindByID ringkasan import beserta error per barisnya
+func (r *ImportRepository) FindByID(id string) (*model.AchievementImport, error) {
+	var imp model.AchievementImport
+	var errs []byte
+	err := r.db.QueryRow(`
+		SELECT id, file_name, initial_status, dry_run, total_rows, imported, failed, errors, created_by, created_at
+		FROM achievement_imports WHERE id = $1
+	`, id).Scan(
+		&imp.ID,
+		&imp.FileName,
+		&imp.InitialStatus,
+		&imp.DryRun,
+		&imp.TotalRows,
+		&imp.Imported,
+		&imp.Failed,
+		&errs,
+		&imp.CreatedBy,
+		&imp.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrImportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(errs, &imp.Errors); err != nil {
+		return nil, fmt.Errorf("invalid errors for import %s: %v", imp.ID, err)
+	}
+	return &imp, nil
+}
+
+// RecordHistory mencatat status awal prestasi hasil import (selain draft) di
+// achievement_status_history, seolah-olah berpindah dari draft
+func (r *ImportRepository) RecordHistory(refID, status, actorID, actorRole, note string, at time.Time) error {
+	_, err := r.db.Exec(`
+		INSERT INTO achievement_status_history
+		(achievement_ref_id, from_status, to_status, actor_id, actor_role, note, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`, refID, model.StatusDraft, status, nullString(actorID), nullString(actorRole), note, at)
+	return err
+}
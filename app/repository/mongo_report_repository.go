@@ -20,20 +20,26 @@ func NewMongoReportRepository(coll *mongo.Collection) *MongoReportRepository {
 	return &MongoReportRepository{collection: coll}
 }
 
+// matchActive: stage $match awal semua laporan. Dokumen di trash (deletedAt) tidak dihitung.
+// Prestasi tim cocok jika pemilik atau salah satu anggotanya ada di studentIDs.
+func matchActive(studentIDs []string) mongo.Pipeline {
+	match := bson.D{{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}}}
+	if len(studentIDs) > 0 {
+		in := bson.D{{Key: "$in", Value: studentIDs}}
+		match = append(match, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "studentId", Value: in}},
+			bson.D{{Key: "team.studentId", Value: in}},
+		}})
+	}
+	return mongo.Pipeline{bson.D{{Key: "$match", Value: match}}}
+}
+
 func (r *MongoReportRepository) GetTotalByType(
 	ctx context.Context,
 	studentIDs []string,
 ) (map[string]int, error) {
 
-	pipeline := mongo.Pipeline{}
-
-	if len(studentIDs) > 0 {
-		pipeline = append(pipeline,
-			bson.D{{Key: "$match", Value: bson.D{
-				{Key: "studentId", Value: bson.D{{Key: "$in", Value: studentIDs}}},
-			}}},
-		)
-	}
+	pipeline := matchActive(studentIDs)
 
 	pipeline = append(pipeline,
 		bson.D{{Key: "$group", Value: bson.D{
@@ -65,15 +71,7 @@ func (r *MongoReportRepository) GetCompetitionLevelDistribution(
 	studentIDs []string,
 ) (map[string]int, error) {
 
-	pipeline := mongo.Pipeline{}
-
-	if len(studentIDs) > 0 {
-		pipeline = append(pipeline,
-			bson.D{{Key: "$match", Value: bson.D{
-				{Key: "studentId", Value: bson.D{{Key: "$in", Value: studentIDs}}},
-			}}},
-		)
-	}
+	pipeline := matchActive(studentIDs)
 
 	pipeline = append(pipeline,
 		bson.D{{Key: "$group", Value: bson.D{
@@ -111,20 +109,32 @@ func (r *MongoReportRepository) GetTopStudentsByPoints(
 	studentIDs []string,
 ) ([]model.StudentPoints, error) {
 
-	pipeline := mongo.Pipeline{}
+	pipeline := matchActive(studentIDs)
 
+	// Poin prestasi tim dihitung per anggota (team.points), prestasi
+	// perorangan untuk pemiliknya
+	pipeline = append(pipeline,
+		bson.D{{Key: "$project", Value: bson.D{
+			{Key: "credits", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$gt", Value: bson.A{
+					bson.D{{Key: "$size", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$team", bson.A{}}}}}},
+					0,
+				}}},
+				"$team",
+				bson.A{bson.D{{Key: "studentId", Value: "$studentId"}, {Key: "points", Value: "$points"}}},
+			}}}},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$credits"}},
+	)
 	if len(studentIDs) > 0 {
-		pipeline = append(pipeline,
-			bson.D{{Key: "$match", Value: bson.D{
-				{Key: "studentId", Value: bson.D{{Key: "$in", Value: studentIDs}}},
-			}}},
-		)
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{
+			{Key: "credits.studentId", Value: bson.D{{Key: "$in", Value: studentIDs}}},
+		}}})
 	}
-
 	pipeline = append(pipeline,
 		bson.D{{Key: "$group", Value: bson.D{
-			{Key: "_id", Value: "$studentId"},
-			{Key: "points", Value: bson.D{{Key: "$sum", Value: "$points"}}},
+			{Key: "_id", Value: "$credits.studentId"},
+			{Key: "points", Value: bson.D{{Key: "$sum", Value: "$credits.points"}}},
 		}}},
 		bson.D{{Key: "$sort", Value: bson.D{{Key: "points", Value: -1}}}},
 		bson.D{{Key: "$limit", Value: limit}},
@@ -158,15 +168,7 @@ func (r *MongoReportRepository) GetMonthlyCounts(
 		return nil, errors.New("months must be > 0")
 	}
 
-	pipeline := mongo.Pipeline{}
-
-	if len(studentIDs) > 0 {
-		pipeline = append(pipeline,
-			bson.D{{Key: "$match", Value: bson.D{
-				{Key: "studentId", Value: bson.D{{Key: "$in", Value: studentIDs}}},
-			}}},
-		)
-	}
+	pipeline := matchActive(studentIDs)
 
 	pipeline = append(pipeline,
 		bson.D{{Key: "$project", Value: bson.D{
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(n *model.Notification) error {
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO notifications
+		(id, user_id, type, title, message, achievement_ref_id, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`,
+		n.ID,
+		n.UserID,
+		n.Type,
+		n.Title,
+		n.Message,
+		n.AchievementRefID,
+		n.CreatedAt,
+	)
+	return err
+}
+
+// FindByUserID mengambil notifikasi seorang user (terbaru lebih dulu) beserta totalnya
+func (r *NotificationRepository) FindByUserID(userID string, unreadOnly bool, limit, offset int) ([]model.Notification, int, error) {
+	where := `WHERE user_id = $1`
+	if unreadOnly {
+		where += ` AND read_at IS NULL`
+	}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM notifications `+where, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, user_id, type, title, message, achievement_ref_id, read_at, created_at
+		FROM notifications `+where+`
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []model.Notification{}
+	for rows.Next() {
+		var n model.Notification
+		if err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Type,
+			&n.Title,
+			&n.Message,
+			&n.AchievementRefID,
+			&n.ReadAt,
+			&n.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		list = append(list, n)
+	}
+	return list, total, rows.Err()
+}
+
+// MarkRead menandai notifikasi milik user sebagai sudah dibaca.
+// Mengembalikan false jika notifikasi tidak ditemukan.
+func (r *NotificationRepository) MarkRead(id, userID string) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/google/uuid"
+)
+
+type PointRuleRepository struct {
+	db *sql.DB
+}
+
+func NewPointRuleRepository(db *sql.DB) *PointRuleRepository {
+	return &PointRuleRepository{db: db}
+}
+
+const pointRuleColumns = `id, achievement_type, competition_level, rank, is_team, points, team_policy, description, is_active, created_at, updated_at`
+
+func scanPointRule(row interface{ Scan(...interface{}) error }) (*model.PointRule, error) {
+	var p model.PointRule
+	var description sql.NullString
+	err := row.Scan(
+		&p.ID,
+		&p.AchievementType,
+		&p.CompetitionLevel,
+		&p.Rank,
+		&p.IsTeam,
+		&p.Points,
+		&p.TeamPolicy,
+		&description,
+		&p.IsActive,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Description = description.String
+	return &p, nil
+}
+
+func (r *PointRuleRepository) query(query string, args ...interface{}) ([]model.PointRule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.PointRule{}
+	for rows.Next() {
+		p, err := scanPointRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *p)
+	}
+	return list, rows.Err()
+}
+
+func (r *PointRuleRepository) FindAll() ([]model.PointRule, error) {
+	return r.query(`SELECT ` + pointRuleColumns + ` FROM point_rules ORDER BY created_at`)
+}
+
+// FindActive dipakai scoring engine
+func (r *PointRuleRepository) FindActive() ([]model.PointRule, error) {
+	return r.query(`SELECT ` + pointRuleColumns + ` FROM point_rules WHERE is_active = true ORDER BY created_at`)
+}
+
+func (r *PointRuleRepository) FindByID(id string) (*model.PointRule, error) {
+	p, err := scanPointRule(r.db.QueryRow(`SELECT `+pointRuleColumns+` FROM point_rules WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("point rule not found")
+	}
+	return p, err
+}
+
+func (r *PointRuleRepository) Create(req *model.PointRuleRequest) (*model.PointRule, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now()
+	rule := &model.PointRule{
+		ID:               uuid.New().String(),
+		AchievementType:  req.AchievementType,
+		CompetitionLevel: req.CompetitionLevel,
+		Rank:             req.Rank,
+		IsTeam:           req.IsTeam,
+		Points:           req.Points,
+		TeamPolicy:       req.TeamPolicy,
+		Description:      req.Description,
+		IsActive:         isActive,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO point_rules
+		(`+pointRuleColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+	`,
+		rule.ID,
+		rule.AchievementType,
+		rule.CompetitionLevel,
+		rule.Rank,
+		rule.IsTeam,
+		rule.Points,
+		rule.TeamPolicy,
+		rule.Description,
+		rule.IsActive,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *PointRuleRepository) Update(id string, req *model.PointRuleRequest) error {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	res, err := r.db.Exec(`
+		UPDATE point_rules
+		SET achievement_type=$1, competition_level=$2, rank=$3, is_team=$4,
+		    points=$5, team_policy=$6, description=$7, is_active=$8, updated_at=$9
+		WHERE id=$10
+	`,
+		req.AchievementType,
+		req.CompetitionLevel,
+		req.Rank,
+		req.IsTeam,
+		req.Points,
+		req.TeamPolicy,
+		req.Description,
+		isActive,
+		time.Now(),
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("point rule not found")
+	}
+	return nil
+}
+
+func (r *PointRuleRepository) Delete(id string) error {
+	res, err := r.db.Exec(`DELETE FROM point_rules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("point rule not found")
+	}
+	return nil
+}
@@ -16,14 +16,29 @@ func NewReportRepository(db *sql.DB) *ReportRepository {
 	return &ReportRepository{db: db}
 }
 
+// participantsSQL: pasangan (student_id, reference) untuk pemilik prestasi dan
+// anggota tim yang sudah konfirmasi, sehingga prestasi tim ikut terhitung
+// untuk setiap anggotanya
+const participantsSQL = `(
+	SELECT student_id, id AS ref_id, status, created_at FROM achievement_references
+	UNION
+	SELECT m.student_id, ar.id, ar.status, ar.created_at
+	FROM achievement_members m
+	JOIN achievement_references ar ON ar.id = m.achievement_ref_id
+	WHERE m.status = 'confirmed'
+) p`
+
 // CountByStatus returns map[status]count, optionally filtered by student IDs.
-// If studentIDs is empty or nil, it counts across all students.
+// If studentIDs is empty or nil, it counts across all students. Team
+// achievements count once even when several members are in studentIDs.
+// Achievements in the trash (status deleted) are not counted.
 func (r *ReportRepository) CountByStatus(studentIDs []string) (map[string]int, error) {
 	result := map[string]int{
-		"draft":     0,
-		"submitted": 0,
-		"verified":  0,
-		"rejected":  0,
+		"draft":              0,
+		"submitted":          0,
+		"revision_requested": 0,
+		"verified":           0,
+		"rejected":           0,
 	}
 
 	var rows *sql.Rows
@@ -33,6 +48,7 @@ func (r *ReportRepository) CountByStatus(studentIDs []string) (map[string]int, e
 		rows, err = r.db.Query(`
 			SELECT status, COUNT(*) as cnt
 			FROM achievement_references
+			WHERE status <> 'deleted'
 			GROUP BY status
 		`)
 	} else {
@@ -44,11 +60,11 @@ func (r *ReportRepository) CountByStatus(studentIDs []string) (map[string]int, e
 			placeholders[i] = fmt.Sprintf("$%d", i+1)
 		}
 		query := fmt.Sprintf(`
-			SELECT status, COUNT(*) as cnt
-			FROM achievement_references
-			WHERE student_id IN (%s)
+			SELECT status, COUNT(DISTINCT ref_id) as cnt
+			FROM %s
+			WHERE student_id IN (%s) AND status <> 'deleted'
 			GROUP BY status
-		`, strings.Join(placeholders, ","))
+		`, participantsSQL, strings.Join(placeholders, ","))
 		rows, err = r.db.Query(query, args...)
 	}
 	if err != nil {
@@ -68,7 +84,8 @@ func (r *ReportRepository) CountByStatus(studentIDs []string) (map[string]int, e
 	return result, nil
 }
 
-// CountTotalPerStudent returns map[studentId]count for given studentIDs.
+// CountTotalPerStudent returns map[studentId]count for given studentIDs,
+// including team achievements the student confirmed as a member.
 // If studentIDs empty -> returns for all students (may be large).
 func (r *ReportRepository) CountTotalPerStudent(studentIDs []string, limit int) (map[string]int, error) {
 	result := map[string]int{}
@@ -80,7 +97,8 @@ func (r *ReportRepository) CountTotalPerStudent(studentIDs []string, limit int)
 		// get top by count
 		query := `
 			SELECT student_id, COUNT(*) as cnt
-			FROM achievement_references
+			FROM ` + participantsSQL + `
+			WHERE status <> 'deleted'
 			GROUP BY student_id
 			ORDER BY cnt DESC
 			LIMIT $1
@@ -97,12 +115,12 @@ func (r *ReportRepository) CountTotalPerStudent(studentIDs []string, limit int)
 
 		query := fmt.Sprintf(`
 			SELECT student_id, COUNT(*) as cnt
-			FROM achievement_references
-			WHERE student_id IN (%s)
+			FROM %s
+			WHERE student_id IN (%s) AND status <> 'deleted'
 			GROUP BY student_id
 			ORDER BY cnt DESC
 			LIMIT $%d
-		`, strings.Join(placeholders, ","), len(studentIDs)+1)
+		`, participantsSQL, strings.Join(placeholders, ","), len(studentIDs)+1)
 
 		rows, err = r.db.Query(query, args...)
 	}
@@ -138,7 +156,7 @@ func (r *ReportRepository) CountByPeriod(studentIDs []string, monthsBack int) (m
 		rows, err = r.db.Query(`
 			SELECT to_char(created_at, 'YYYY-MM') as month, COUNT(*) as cnt
 			FROM achievement_references
-			WHERE created_at >= $1
+			WHERE created_at >= $1 AND status <> 'deleted'
 			GROUP BY month
 			ORDER BY month
 		`, from)
@@ -152,12 +170,12 @@ func (r *ReportRepository) CountByPeriod(studentIDs []string, monthsBack int) (m
 		args[len(studentIDs)] = from
 
 		query := fmt.Sprintf(`
-			SELECT to_char(created_at, 'YYYY-MM') as month, COUNT(*) as cnt
-			FROM achievement_references
-			WHERE student_id IN (%s) AND created_at >= $%d
+			SELECT to_char(created_at, 'YYYY-MM') as month, COUNT(DISTINCT ref_id) as cnt
+			FROM %s
+			WHERE student_id IN (%s) AND created_at >= $%d AND status <> 'deleted'
 			GROUP BY month
 			ORDER BY month
-		`, strings.Join(placeholders, ","), len(studentIDs)+1)
+		`, participantsSQL, strings.Join(placeholders, ","), len(studentIDs)+1)
 
 		rows, err = r.db.Query(query, args...)
 	}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-fiber/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// MongoRevisionRepository menyimpan snapshot isi prestasi di collection achievement_revisions.
+// Revisi tidak pernah diubah setelah ditulis.
+type MongoRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRevisionRepository(coll *mongo.Collection) *MongoRevisionRepository {
+	return &MongoRevisionRepository{collection: coll}
+}
+
+// EnsureIndexes membuat unique index (achievementId, revision) agar dua update
+// bersamaan tidak menghasilkan nomor revisi yang sama
+func (r *MongoRevisionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "achievementId", Value: 1}, {Key: "revision", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// Create menulis revisi baru dengan nomor = revisi terakhir + 1
+func (r *MongoRevisionRepository) Create(ctx context.Context, rev *model.AchievementRevision) error {
+	latest, err := r.Latest(ctx, rev.AchievementID)
+	if err != nil && !errors.Is(err, ErrRevisionNotFound) {
+		return err
+	}
+	rev.Revision = 1
+	if latest != nil {
+		rev.Revision = latest.Revision + 1
+	}
+	rev.ID = primitive.NewObjectID()
+	rev.CreatedAt = time.Now()
+
+	_, err = r.collection.InsertOne(ctx, rev)
+	return err
+}
+
+// Delete menghapus revisi yang tidak jadi dipakai (update dokumen gagal)
+func (r *MongoRevisionRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// DeleteByAchievementID menghapus semua revisi sebuah prestasi (purge permanen)
+func (r *MongoRevisionRepository) DeleteByAchievementID(ctx context.Context, achievementID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"achievementId": achievementID})
+	return err
+}
+
+func (r *MongoRevisionRepository) Latest(ctx context.Context, achievementID primitive.ObjectID) (*model.AchievementRevision, error) {
+	var rev model.AchievementRevision
+	opts := options.FindOne().SetSort(bson.D{{Key: "revision", Value: -1}})
+	err := r.collection.FindOne(ctx, bson.M{"achievementId": achievementID}, opts).Decode(&rev)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func (r *MongoRevisionRepository) FindOne(ctx context.Context, achievementID primitive.ObjectID, revision int) (*model.AchievementRevision, error) {
+	var rev model.AchievementRevision
+	err := r.collection.FindOne(ctx, bson.M{"achievementId": achievementID, "revision": revision}).Decode(&rev)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// FindByAchievementID mengambil semua revisi, urut dari yang terlama
+func (r *MongoRevisionRepository) FindByAchievementID(ctx context.Context, achievementID primitive.ObjectID) ([]model.AchievementRevision, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "revision", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"achievementId": achievementID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	list := []model.AchievementRevision{}
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
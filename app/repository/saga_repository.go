@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"go-fiber/app/model"
+)
+
+type SagaRepository struct {
+	db *sql.DB
+}
+
+func NewSagaRepository(db *sql.DB) *SagaRepository {
+	return &SagaRepository{db: db}
+}
+
+const sagaColumns = `id, operation, achievement_ref_id, mongo_achievement_id, student_id, state, attempts, last_error, created_at, updated_at`
+
+// execer dipenuhi *sql.DB dan *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertSaga(exec execer, saga *model.AchievementSaga) error {
+	now := time.Now()
+	saga.State = model.SagaPending
+	saga.CreatedAt = now
+	saga.UpdatedAt = now
+
+	_, err := exec.Exec(`
+		INSERT INTO achievement_sagas
+		(`+sagaColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+	`,
+		saga.ID,
+		saga.Operation,
+		saga.AchievementRefID,
+		saga.MongoAchievementID,
+		saga.StudentID,
+		saga.State,
+		saga.Attempts,
+		saga.LastError,
+		saga.CreatedAt,
+		saga.UpdatedAt,
+	)
+	return err
+}
+
+// Start mencatat saga baru (state pending) sebelum langkah pertama dijalankan
+func (r *SagaRepository) Start(saga *model.AchievementSaga) error {
+	return insertSaga(r.db, saga)
+}
+
+// CompleteCreate menulis reference (beserta tahap persetujuan jika reference
+// langsung diajukan) dan menandai saga create selesai dalam satu transaksi,
+// sehingga reference ada jika dan hanya jika saga completed
+func (r *SagaRepository) CompleteCreate(sagaID string, ref *model.AchievementReference, stages []model.AchievementApproval) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO achievement_references
+		(id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+	`,
+		ref.ID,
+		ref.StudentID,
+		ref.MongoAchievementID,
+		ref.Status,
+		ref.SubmittedAt,
+		ref.VerifiedAt,
+		ref.VerifiedBy,
+		ref.RejectionNote,
+		ref.CreatedAt,
+		ref.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if ref.SubmittedAt != nil && len(stages) > 0 {
+		if err := insertApprovalsTx(tx, ref.ID, *ref.SubmittedAt, stages); err != nil {
+			return err
+		}
+	}
+
+	if err := finishSaga(tx, sagaID, model.SagaCompleted); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// StartTransition mengubah status reference dan mencatat saga (delete/restore)
+// dalam satu transaksi (outbox). Perubahan dokumen Mongo dijalankan setelahnya.
+func (r *SagaRepository) StartTransition(change model.StatusChange, saga *model.AchievementSaga) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := transitionStatusTx(tx, saga.AchievementRefID, change); err != nil {
+		return err
+	}
+	if err := insertSaga(tx, saga); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// StartPurge menghapus permanen reference di trash beserta riwayat status,
+// komentar, anggota tim, catatan SLA, tahap persetujuan, upload resumable dan
+// notifikasinya, lalu mencatat saga purge untuk
+// penghapusan dokumen Mongo
+func (r *SagaRepository) StartPurge(saga *model.AchievementSaga) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		DELETE FROM achievement_references WHERE id=$1 AND status=$2
+	`, saga.AchievementRefID, model.StatusDeleted)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	for _, query := range []string{
+		`DELETE FROM achievement_status_history WHERE achievement_ref_id=$1`,
+		`DELETE FROM achievement_comments WHERE achievement_ref_id=$1`,
+		`DELETE FROM achievement_members WHERE achievement_ref_id=$1`,
+		`DELETE FROM achievement_sla WHERE achievement_ref_id=$1`,
+		`DELETE FROM achievement_approvals WHERE achievement_ref_id=$1`,
+		// Data chunk upload dibersihkan ExpireUploads setelah barisnya tidak ada
+		`DELETE FROM achievement_uploads WHERE achievement_ref_id=$1`,
+		`DELETE FROM notifications WHERE achievement_ref_id=$1`,
+	} {
+		if _, err := tx.Exec(query, saga.AchievementRefID); err != nil {
+			return err
+		}
+	}
+
+	if err := insertSaga(tx, saga); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func finishSaga(exec execer, id, state string) error {
+	_, err := exec.Exec(`
+		UPDATE achievement_sagas SET state=$1, updated_at=$2 WHERE id=$3
+	`, state, time.Now(), id)
+	return err
+}
+
+// Finish menandai saga completed atau compensated
+func (r *SagaRepository) Finish(id, state string) error {
+	return finishSaga(r.db, id, state)
+}
+
+// RecordFailure mencatat percobaan yang gagal; saga tetap pending untuk dicoba lagi
+func (r *SagaRepository) RecordFailure(id string, cause error) error {
+	_, err := r.db.Exec(`
+		UPDATE achievement_sagas
+		SET attempts = attempts + 1, last_error=$1, updated_at=$2
+		WHERE id=$3
+	`, cause.Error(), time.Now(), id)
+	return err
+}
+
+// FindPending mengambil saga pending yang dibuat sebelum `before`.
+// Saga yang lebih baru dilewati karena request-nya mungkin masih berjalan.
+func (r *SagaRepository) FindPending(before time.Time, limit int) ([]model.AchievementSaga, error) {
+	rows, err := r.db.Query(`
+		SELECT `+sagaColumns+`
+		FROM achievement_sagas
+		WHERE state = $1 AND created_at < $2
+		ORDER BY created_at
+		LIMIT $3
+	`, model.SagaPending, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementSaga{}
+	for rows.Next() {
+		var s model.AchievementSaga
+		if err := rows.Scan(
+			&s.ID,
+			&s.Operation,
+			&s.AchievementRefID,
+			&s.MongoAchievementID,
+			&s.StudentID,
+			&s.State,
+			&s.Attempts,
+			&s.LastError,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
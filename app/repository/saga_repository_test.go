@@ -0,0 +1,199 @@
+package repository_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// Test CompleteCreate - reference dan penanda saga ditulis dalam satu transaksi
+func TestCompleteCreate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO achievement_references`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE achievement_sagas SET state`).
+		WithArgs(model.SagaCompleted, sqlmock.AnyArg(), "saga-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.CompleteCreate("saga-1", &model.AchievementReference{
+		ID:                 "ref-1",
+		StudentID:          "student-1",
+		MongoAchievementID: "mongo-1",
+		Status:             model.StatusDraft,
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test CompleteCreate - reference yang langsung diajukan ditulis bersama tahap persetujuannya
+func TestCompleteCreate_WithStages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+	submittedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO achievement_references`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_approvals`).
+		WithArgs("approval-1", "ref-1", submittedAt, 1, "Dosen Wali", "Dosen Wali", model.ScopeAdvisor, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE achievement_sagas SET state`).
+		WithArgs(model.SagaCompleted, sqlmock.AnyArg(), "saga-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.CompleteCreate("saga-1", &model.AchievementReference{
+		ID:          "ref-1",
+		Status:      model.StatusSubmitted,
+		SubmittedAt: &submittedAt,
+	}, []model.AchievementApproval{
+		{ID: "approval-1", StageOrder: 1, Name: "Dosen Wali", Role: "Dosen Wali", Scope: model.ScopeAdvisor},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test CompleteCreate - insert reference gagal, saga tidak ditandai selesai
+func TestCompleteCreate_InsertFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO achievement_references`).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	err = repo.CompleteCreate("saga-1", &model.AchievementReference{ID: "ref-1"}, nil)
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test StartTransition - transisi status ditolak, saga tidak dicatat
+func TestStartTransition_Conflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_references`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT status FROM achievement_references`).
+		WithArgs("ref-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(model.StatusSubmitted))
+	mock.ExpectRollback()
+
+	err = repo.StartTransition(
+		model.StatusChange{From: model.StatusDraft, To: model.StatusDeleted},
+		&model.AchievementSaga{ID: "saga-1", Operation: model.SagaDelete, AchievementRefID: "ref-1"},
+	)
+
+	var tErr *model.StatusTransitionError
+	assert.True(t, errors.As(err, &tErr))
+	assert.Equal(t, model.StatusSubmitted, tErr.Current)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindPending - hanya saga pending yang melewati grace period
+func TestFindPending_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+
+	now := time.Now()
+	before := now.Add(-5 * time.Minute)
+	mock.ExpectQuery(`FROM achievement_sagas`).
+		WithArgs(model.SagaPending, before, 100).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "operation", "achievement_ref_id", "mongo_achievement_id", "student_id", "state", "attempts", "last_error", "created_at", "updated_at",
+		}).AddRow("saga-1", model.SagaDelete, "ref-1", "mongo-1", "student-1", model.SagaPending, 2, "timeout", now, now))
+
+	sagas, err := repo.FindPending(before, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, sagas, 1)
+	assert.Equal(t, 2, sagas[0].Attempts)
+	assert.Equal(t, "timeout", *sagas[0].LastError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test StartPurge - reference sudah tidak di trash, tidak ada yang dihapus
+func TestStartPurge_NotInTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM achievement_references`).
+		WithArgs("ref-1", model.StatusDeleted).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = repo.StartPurge(&model.AchievementSaga{ID: "saga-1", Operation: model.SagaPurge, AchievementRefID: "ref-1"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test StartPurge - data turunan reference ikut dihapus sebelum saga dicatat
+func TestStartPurge_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSagaRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM achievement_references`).
+		WithArgs("ref-1", model.StatusDeleted).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range []string{
+		"achievement_status_history",
+		"achievement_comments",
+		"achievement_members",
+		"achievement_sla",
+		"achievement_approvals",
+		"achievement_uploads",
+		"notifications",
+	} {
+		mock.ExpectExec(`DELETE FROM ` + table + ` WHERE achievement_ref_id`).
+			WithArgs("ref-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(`INSERT INTO achievement_sagas`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.StartPurge(&model.AchievementSaga{ID: "saga-1", Operation: model.SagaPurge, AchievementRefID: "ref-1"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/lib/pq"
+)
+
+type SLARepository struct {
+	db *sql.DB
+}
+
+func NewSLARepository(db *sql.DB) *SLARepository {
+	return &SLARepository{db: db}
+}
+
+// FindByRefIDs mengambil penanda SLA banyak reference, di-key dengan ID reference
+func (r *SLARepository) FindByRefIDs(ids []string) (map[string]model.AchievementSLA, error) {
+	result := map[string]model.AchievementSLA{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT achievement_ref_id, started_at, overdue_at, escalated_at
+		FROM achievement_sla
+		WHERE achievement_ref_id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sla model.AchievementSLA
+		if err := rows.Scan(&sla.AchievementRefID, &sla.StartedAt, &sla.OverdueAt, &sla.EscalatedAt); err != nil {
+			return nil, err
+		}
+		result[sla.AchievementRefID] = sla
+	}
+	return result, rows.Err()
+}
+
+// MarkOverdue menandai tahap aktif pengajuan (reference + waktu tahap mulai
+// menunggu) sebagai overdue. Penanda tahap / pengajuan sebelumnya diganti.
+// Mengembalikan false jika tahap ini sudah ditandai, sehingga notifikasi hanya
+// dikirim sekali.
+func (r *SLARepository) MarkOverdue(refID string, startedAt, at time.Time) (bool, error) {
+	res, err := r.db.Exec(`
+		INSERT INTO achievement_sla (achievement_ref_id, started_at, overdue_at)
+		VALUES ($1,$2,$3)
+		ON CONFLICT (achievement_ref_id) DO UPDATE
+		SET started_at = EXCLUDED.started_at, overdue_at = EXCLUDED.overdue_at, escalated_at = NULL
+		WHERE achievement_sla.started_at <> EXCLUDED.started_at OR achievement_sla.overdue_at IS NULL
+	`, refID, startedAt, at)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// MarkEscalated menandai tahap yang sudah overdue sebagai dieskalasi.
+// Mengembalikan false jika sudah dieskalasi sebelumnya.
+func (r *SLARepository) MarkEscalated(refID string, startedAt, at time.Time) (bool, error) {
+	res, err := r.db.Exec(`
+		UPDATE achievement_sla
+		SET escalated_at = $3
+		WHERE achievement_ref_id = $1 AND started_at = $2 AND escalated_at IS NULL
+	`, refID, startedAt, at)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
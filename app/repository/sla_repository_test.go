@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/repository"
+)
+
+// Test MarkOverdue - pengajuan sudah ditandai sebelumnya, notifikasi tidak diulang
+func TestSLAMarkOverdue_AlreadyMarked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSLARepository(db)
+
+	submitted := time.Now().Add(-8 * 24 * time.Hour)
+	now := time.Now()
+	mock.ExpectExec(`INSERT INTO achievement_sla`).
+		WithArgs("ref-1", submitted, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	marked, err := repo.MarkOverdue("ref-1", submitted, now)
+
+	assert.NoError(t, err)
+	assert.False(t, marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test MarkEscalated - baru dieskalasi
+func TestSLAMarkEscalated_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewSLARepository(db)
+
+	submitted := time.Now().Add(-15 * 24 * time.Hour)
+	now := time.Now()
+	mock.ExpectExec(`UPDATE achievement_sla`).
+		WithArgs("ref-1", submitted, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	marked, err := repo.MarkEscalated("ref-1", submitted, now)
+
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"database/sql"
 	"go-fiber/app/model"
+
+	"github.com/lib/pq"
 )
 
 type StudentRepository struct {
@@ -129,3 +131,47 @@ func (r *StudentRepository) FindByAdvisorID(advisorID string) ([]model.Student,
     }
     return list, nil
 }
+
+// FindByStudentIDs mengambil mahasiswa berdasarkan NIM (students.student_id)
+func (r *StudentRepository) FindByStudentIDs(nims []string) ([]model.Student, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, student_id, program_study, academic_year, advisor_id, created_at
+		FROM students WHERE student_id = ANY($1)
+	`, pq.Array(nims))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.Student{}
+	for rows.Next() {
+		var s model.Student
+		if err := rows.Scan(&s.ID, &s.UserID, &s.StudentID, &s.ProgramStudy, &s.AcademicYear, &s.AdvisorID, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+// FindByIDs mengambil banyak mahasiswa sekaligus berdasarkan id
+func (r *StudentRepository) FindByIDs(ids []string) ([]model.Student, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, student_id, program_study, academic_year, advisor_id, created_at
+		FROM students WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.Student{}
+	for rows.Next() {
+		var s model.Student
+		if err := rows.Scan(&s.ID, &s.UserID, &s.StudentID, &s.ProgramStudy, &s.AcademicYear, &s.AdvisorID, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
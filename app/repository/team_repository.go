@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/lib/pq"
+)
+
+// TeamRepository anggota tim prestasi (achievement_members)
+type TeamRepository struct {
+	db *sql.DB
+}
+
+func NewTeamRepository(db *sql.DB) *TeamRepository {
+	return &TeamRepository{db: db}
+}
+
+const teamMemberColumns = `achievement_ref_id, student_id, role, status, invited_by, responded_at, created_at`
+
+func scanTeamMember(row interface{ Scan(...interface{}) error }) (*model.TeamMember, error) {
+	var m model.TeamMember
+	err := row.Scan(
+		&m.AchievementRefID,
+		&m.StudentID,
+		&m.Role,
+		&m.Status,
+		&m.InvitedBy,
+		&m.RespondedAt,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// FindMembers anggota tim sebuah prestasi, ketua lebih dulu. Kosong untuk
+// prestasi perorangan.
+func (r *TeamRepository) FindMembers(refID string) ([]model.TeamMember, error) {
+	rows, err := r.db.Query(`
+		SELECT `+teamMemberColumns+`
+		FROM achievement_members
+		WHERE achievement_ref_id = $1
+		ORDER BY role = $2 DESC, created_at, student_id
+	`, refID, model.TeamRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.TeamMember{}
+	for rows.Next() {
+		m, err := scanTeamMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *m)
+	}
+	return list, rows.Err()
+}
+
+// FindMember keanggotaan satu mahasiswa; nil jika bukan anggota tim
+func (r *TeamRepository) FindMember(refID, studentID string) (*model.TeamMember, error) {
+	m, err := scanTeamMember(r.db.QueryRow(`
+		SELECT `+teamMemberColumns+`
+		FROM achievement_members
+		WHERE achievement_ref_id = $1 AND student_id = $2
+	`, refID, studentID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return m, err
+}
+
+// ReplaceMembers mengganti daftar anggota tim. Anggota yang tetap ada
+// mempertahankan status konfirmasinya (hanya perannya yang diperbarui);
+// anggota baru disimpan dengan status dari members.
+func (r *TeamRepository) ReplaceMembers(refID string, members []model.TeamMember) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	studentIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		studentIDs = append(studentIDs, m.StudentID)
+	}
+	_, err = tx.Exec(`
+		DELETE FROM achievement_members
+		WHERE achievement_ref_id = $1 AND NOT (student_id = ANY($2))
+	`, refID, pq.Array(studentIDs))
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		_, err := tx.Exec(`
+			INSERT INTO achievement_members
+			(achievement_ref_id, student_id, role, status, invited_by, responded_at, created_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7)
+			ON CONFLICT (achievement_ref_id, student_id) DO UPDATE SET role = EXCLUDED.role
+		`,
+			refID,
+			m.StudentID,
+			m.Role,
+			m.Status,
+			m.InvitedBy,
+			m.RespondedAt,
+			m.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Respond mencatat konfirmasi / penolakan anggota tim
+func (r *TeamRepository) Respond(refID, studentID, status string, at time.Time) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_members
+		SET status = $3, responded_at = $4
+		WHERE achievement_ref_id = $1 AND student_id = $2
+	`, refID, studentID, status, at)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("team member not found")
+	}
+	return nil
+}
+
+// FindInvitations reference prestasi tim yang menunggu konfirmasi mahasiswa,
+// yang terbaru lebih dulu. Prestasi di trash tidak ikut.
+func (r *TeamRepository) FindInvitations(studentID string) ([]model.AchievementReference, error) {
+	rows, err := r.db.Query(`
+		SELECT `+referenceColumns+`
+		FROM achievement_references ar
+		JOIN achievement_members m ON m.achievement_ref_id = ar.id
+		WHERE m.student_id = $1 AND m.status = $2 AND ar.status <> $3
+		ORDER BY m.created_at DESC, ar.id
+	`, studentID, model.MemberPending, model.StatusDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementReference{}
+	for rows.Next() {
+		ref, err := scanReference(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *ref)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// Test ReplaceMembers - anggota yang dihapus dibuang, sisanya di-upsert dalam satu transaksi
+func TestReplaceMembers_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewTeamRepository(db)
+
+	now := time.Now()
+	invitedBy := "user-1"
+	members := []model.TeamMember{
+		{StudentID: "student-1", Role: model.TeamRoleLeader, Status: model.MemberConfirmed, RespondedAt: &now, CreatedAt: now},
+		{StudentID: "student-2", Role: model.TeamRoleMember, Status: model.MemberPending, InvitedBy: &invitedBy, CreatedAt: now},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM achievement_members`).
+		WithArgs("ref-1", pq.Array([]string{"student-1", "student-2"})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_members .* ON CONFLICT \(achievement_ref_id, student_id\) DO UPDATE SET role = EXCLUDED.role`).
+		WithArgs("ref-1", "student-1", model.TeamRoleLeader, model.MemberConfirmed, nil, &now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO achievement_members`).
+		WithArgs("ref-1", "student-2", model.TeamRoleMember, model.MemberPending, &invitedBy, nil, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.ReplaceMembers("ref-1", members)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Respond - mahasiswa yang bukan anggota tim
+func TestRespond_NotMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewTeamRepository(db)
+
+	now := time.Now()
+	mock.ExpectExec(`UPDATE achievement_members`).
+		WithArgs("ref-1", "student-9", model.MemberConfirmed, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.Respond("ref-1", "student-9", model.MemberConfirmed, now)
+
+	assert.EqualError(t, err, "team member not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Search - scope mahasiswa mencakup prestasi tim yang sudah dikonfirmasi
+func TestSearch_StudentScopeIncludesTeams(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM achievement_references ar WHERE \(ar.student_id = \$1 OR EXISTS \(SELECT 1 FROM achievement_members m WHERE m.achievement_ref_id = ar.id AND m.student_id = \$1 AND m.status = 'confirmed'\)\) AND ar.status <> \$2$`).
+		WithArgs("student-1", model.StatusDeleted).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`ORDER BY ar.created_at DESC NULLS LAST, ar.id`).
+		WithArgs("student-1", model.StatusDeleted).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, total, err := repo.Search(model.ReferenceFilter{
+		StudentID:      "student-1",
+		IncludeMembers: true,
+		SortDesc:       true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Search - antrian dosen wali mencakup tahap dosen wali anggota tim
+func TestSearch_AdvisorQueueIncludesMemberStages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM achievement_references ar JOIN students s .*ms.advisor_id = \$1.*ap.scope = 'advisor'.*ap.scope = 'member_advisor' AND ms.advisor_id = \$1.* AND ar.status = \$2$`).
+		WithArgs("lecturer-1", model.StatusSubmitted).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`ORDER BY ar.submitted_at ASC NULLS LAST, ar.id`).
+		WithArgs("lecturer-1", model.StatusSubmitted).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, total, err := repo.Search(model.ReferenceFilter{
+		AdvisorID:        "lecturer-1",
+		IncludeMembers:   true,
+		AwaitingApproval: true,
+		SortColumn:       "submitted_at",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"go-fiber/app/model"
+)
+
+var (
+	// ErrUploadOffsetConflict offset upload sudah berubah (chunk lain masuk lebih dulu)
+	ErrUploadOffsetConflict = errors.New("upload offset conflict")
+	// ErrUploadFinalizing upload belum lengkap atau sedang di-finalize request lain
+	ErrUploadFinalizing = errors.New("upload is not ready to finalize")
+)
+
+type UploadRepository struct {
+	db *sql.DB
+}
+
+func NewUploadRepository(db *sql.DB) *UploadRepository {
+	return &UploadRepository{db: db}
+}
+
+const uploadColumns = `id, achievement_ref_id, user_id, file_name, category, caption, upload_length, upload_offset, expires_at, created_at, updated_at`
+
+func (r *UploadRepository) Create(u *model.ResumableUpload) error {
+	now := time.Now()
+	u.CreatedAt = now
+	u.UpdatedAt = now
+
+	_, err := r.db.Exec(`
+		INSERT INTO achievement_uploads
+		(`+uploadColumns+`)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+	`,
+		u.ID,
+		u.AchievementRefID,
+		u.UserID,
+		u.FileName,
+		u.Category,
+		u.Caption,
+		u.Length,
+		u.Offset,
+		u.ExpiresAt,
+		u.CreatedAt,
+		u.UpdatedAt,
+	)
+	return err
+}
+
+func (r *UploadRepository) FindByID(id string) (*model.ResumableUpload, error) {
+	var u model.ResumableUpload
+	err := r.db.QueryRow(`SELECT `+uploadColumns+` FROM achievement_uploads WHERE id = $1`, id).Scan(
+		&u.ID,
+		&u.AchievementRefID,
+		&u.UserID,
+		&u.FileName,
+		&u.Category,
+		&u.Caption,
+		&u.Length,
+		&u.Offset,
+		&u.ExpiresAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// AddChunk mencatat chunk (belum committed) sebelum datanya ditulis ke
+// storage, sehingga data yang tertinggal karena request gagal tetap bisa dibersihkan
+func (r *UploadRepository) AddChunk(chunk model.UploadChunk) error {
+	_, err := r.db.Exec(`
+		INSERT INTO achievement_upload_chunks (storage_key, upload_id, chunk_offset, size)
+		VALUES ($1,$2,$3,$4)
+	`, chunk.StorageKey, chunk.UploadID, chunk.Offset, chunk.Size)
+	return err
+}
+
+// Advance memajukan offset dari from ke to, memperpanjang masa berlaku dan
+// menandai chunk sebagai bagian upload dalam satu transaksi. Jika offset di
+// database bukan from, dikembalikan ErrUploadOffsetConflict.
+func (r *UploadRepository) Advance(id string, from, to int64, chunkKey string, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`
+		UPDATE achievement_uploads
+		SET upload_offset = $3, expires_at = $4, updated_at = NOW()
+		WHERE id = $1 AND upload_offset = $2 AND finalizing_at IS NULL
+	`, id, from, to, expiresAt)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUploadOffsetConflict
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE achievement_upload_chunks SET committed = TRUE WHERE storage_key = $1
+	`, chunkKey); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindChunks chunk yang sudah committed, urut offset
+func (r *UploadRepository) FindChunks(uploadID string) ([]model.UploadChunk, error) {
+	rows, err := r.db.Query(`
+		SELECT storage_key, upload_id, chunk_offset, size
+		FROM achievement_upload_chunks
+		WHERE upload_id = $1 AND committed
+		ORDER BY chunk_offset
+	`, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chunks := []model.UploadChunk{}
+	for rows.Next() {
+		var c model.UploadChunk
+		if err := rows.Scan(&c.StorageKey, &c.UploadID, &c.Offset, &c.Size); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, c)
+	}
+	return chunks, rows.Err()
+}
+
+// DeleteChunk menghapus catatan chunk yang tidak jadi dipakai
+func (r *UploadRepository) DeleteChunk(key string) error {
+	_, err := r.db.Exec(`DELETE FROM achievement_upload_chunks WHERE storage_key = $1`, key)
+	return err
+}
+
+// ClaimFinalize menandai upload lengkap sedang di-finalize. Klaim yang lebih
+// lama dari staleBefore (request sebelumnya terputus) boleh diambil alih.
+// Gagal dengan ErrUploadFinalizing jika upload belum lengkap atau sudah diklaim.
+func (r *UploadRepository) ClaimFinalize(id string, staleBefore time.Time) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_uploads
+		SET finalizing_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND upload_offset = upload_length
+		  AND (finalizing_at IS NULL OR finalizing_at < $2)
+	`, id, staleBefore)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUploadFinalizing
+	}
+	return nil
+}
+
+// ReleaseFinalize melepas klaim finalize yang gagal agar bisa dicoba lagi
+func (r *UploadRepository) ReleaseFinalize(id string) error {
+	_, err := r.db.Exec(`UPDATE achievement_uploads SET finalizing_at = NULL WHERE id = $1`, id)
+	return err
+}
+
+// Delete menghapus upload beserta catatan chunk-nya dan mengembalikan key
+// storage chunk yang harus dihapus pemanggil
+func (r *UploadRepository) Delete(id string) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM achievement_uploads WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+	keys, err := queryStrings(tx, `DELETE FROM achievement_upload_chunks WHERE upload_id = $1 RETURNING storage_key`, id)
+	if err != nil {
+		return nil, err
+	}
+	return keys, tx.Commit()
+}
+
+// DeleteExpired menghapus upload yang melewati expires_at dan mengembalikan ID-nya
+func (r *UploadRepository) DeleteExpired(now time.Time) ([]string, error) {
+	return queryStrings(r.db, `DELETE FROM achievement_uploads WHERE expires_at < $1 RETURNING id`, now)
+}
+
+// DeleteOrphanChunks menghapus catatan chunk yang uploadnya sudah tidak ada
+// (kedaluwarsa, di-purge bersama prestasinya) dan mengembalikan key storage-nya
+func (r *UploadRepository) DeleteOrphanChunks() ([]string, error) {
+	return queryStrings(r.db, `
+		DELETE FROM achievement_upload_chunks c
+		WHERE NOT EXISTS (SELECT 1 FROM achievement_uploads u WHERE u.id = c.upload_id)
+		RETURNING storage_key
+	`)
+}
+
+// querier dipenuhi *sql.DB dan *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryStrings menjalankan query yang mengembalikan satu kolom teks
+func queryStrings(q querier, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
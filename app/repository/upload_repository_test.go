@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/repository"
+)
+
+// Test Advance - offset sudah dimajukan chunk lain, chunk tidak di-commit
+func TestUploadAdvance_Conflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUploadRepository(db)
+
+	expires := time.Now().Add(time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_uploads`).
+		WithArgs("upload-1", int64(0), int64(1024), expires).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = repo.Advance("upload-1", 0, 1024, "resumable/upload-1/a", expires)
+
+	assert.ErrorIs(t, err, repository.ErrUploadOffsetConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Advance - offset maju dan chunk di-commit dalam satu transaksi
+func TestUploadAdvance_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUploadRepository(db)
+
+	expires := time.Now().Add(time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE achievement_uploads`).
+		WithArgs("upload-1", int64(0), int64(1024), expires).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE achievement_upload_chunks SET committed = TRUE`).
+		WithArgs("resumable/upload-1/a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.Advance("upload-1", 0, 1024, "resumable/upload-1/a", expires)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ClaimFinalize - upload sudah diklaim request lain
+func TestUploadClaimFinalize_Claimed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUploadRepository(db)
+
+	stale := time.Now().Add(-30 * time.Minute)
+	mock.ExpectExec(`UPDATE achievement_uploads\s+SET finalizing_at = NOW\(\)`).
+		WithArgs("upload-1", stale).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.ClaimFinalize("upload-1", stale)
+
+	assert.ErrorIs(t, err, repository.ErrUploadFinalizing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test DeleteExpired - mengembalikan ID upload yang dihapus
+func TestUploadDeleteExpired_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUploadRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(`DELETE FROM achievement_uploads WHERE expires_at < \$1 RETURNING id`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("upload-1").AddRow("upload-2"))
+
+	ids, err := repo.DeleteExpired(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"upload-1", "upload-2"}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test DeleteOrphanChunks - key chunk tanpa upload dikembalikan untuk dihapus dari storage
+func TestUploadDeleteOrphanChunks_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUploadRepository(db)
+
+	mock.ExpectQuery(`DELETE FROM achievement_upload_chunks c\s+WHERE NOT EXISTS`).
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("resumable/upload-1/a"))
+
+	keys, err := repo.DeleteOrphanChunks()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"resumable/upload-1/a"}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
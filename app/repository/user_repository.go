@@ -346,3 +346,48 @@ func (r *UserRepository) AssignRole(userID string, req *model.AssignRoleRequest)
 	return err
 }
 
+
+// FindIDsByRole mengambil ID user aktif dengan role tertentu (mis. "Admin")
+func (r *UserRepository) FindIDsByRole(roleName string) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT u.id
+		FROM users u
+		JOIN roles r ON r.id = u.role_id
+		WHERE r.name = $1 AND u.is_active = true
+	`, roleName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// HasRole memeriksa apakah user aktif memiliki role tertentu
+func (r *UserRepository) HasRole(userID, roleName string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM users u
+			JOIN roles r ON r.id = u.role_id
+			WHERE u.id = $1 AND r.name = $2 AND u.is_active = true
+		)
+	`, userID, roleName).Scan(&exists)
+	return exists, err
+}
+
+// RoleExists memeriksa apakah role dengan nama tertentu terdaftar
+func (r *UserRepository) RoleExists(roleName string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM roles WHERE name = $1)`, roleName).Scan(&exists)
+	return exists, err
+}
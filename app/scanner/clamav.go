@@ -0,0 +1,124 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const clamavChunkSize = 64 * 1024
+
+// Perkiraan kecepatan scan clamd (byte per detik). Batas waktu menunggu hasil
+// ditambah sesuai ukuran file agar file besar (upload resumable) tidak timeout.
+const clamavScanRate = 5 << 20
+
+// ErrStreamTooLarge file melebihi StreamMaxLength clamd (default 25 MB).
+// StreamMaxLength di clamd.conf harus setidaknya sebesar upload maksimum.
+var ErrStreamTooLarge = errors.New("clamd: file exceeds StreamMaxLength")
+
+// ClamAVScanner memakai protokol clamd (perintah INSTREAM) lewat TCP atau unix
+// socket. timeout berlaku per chunk yang dikirim (bukan untuk seluruh file) dan
+// untuk menunggu hasil scan, ditambah waktu sesuai ukuran file.
+type ClamAVScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner menerima "tcp://host:3310", "unix:///path/clamd.sock",
+// path socket ("/var/run/clamd.sock") atau "host:port"
+func NewClamAVScanner(addr string, timeout time.Duration) *ClamAVScanner {
+	network, address := "tcp", addr
+	switch {
+	case strings.HasPrefix(addr, "tcp://"):
+		address = strings.TrimPrefix(addr, "tcp://")
+	case strings.HasPrefix(addr, "unix://"):
+		network, address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "/"):
+		network = "unix"
+	}
+	return &ClamAVScanner{network: network, address: address, timeout: timeout}
+}
+
+func (s *ClamAVScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+
+	// Isi dikirim per chunk: panjang 4 byte (big endian) lalu data; chunk kosong = selesai
+	buf := make([]byte, clamavChunkSize)
+	size := make([]byte, 4)
+	var sent int64
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			conn.SetDeadline(time.Now().Add(s.timeout))
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return s.writeFailed(conn, err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return s.writeFailed(conn, err)
+			}
+			sent += int64(n)
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return Result{}, readErr
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return s.writeFailed(conn, err)
+	}
+
+	conn.SetDeadline(time.Now().Add(s.timeout + time.Duration(sent/clamavScanRate)*time.Second))
+	return readClamAVReply(conn)
+}
+
+// writeFailed: clamd menutup koneksi di tengah stream (mis. StreamMaxLength
+// terlampaui); balasan yang sudah dikirim clamd dibaca agar alasannya jelas
+func (s *ClamAVScanner) writeFailed(conn net.Conn, err error) (Result, error) {
+	conn.SetReadDeadline(time.Now().Add(s.timeout))
+	if res, replyErr := readClamAVReply(conn); errors.Is(replyErr, ErrStreamTooLarge) {
+		return res, replyErr
+	}
+	return Result{}, fmt.Errorf("clamd: %w", err)
+}
+
+func readClamAVReply(conn net.Conn) (Result, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	return parseClamAVReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamAVReply: "stream: OK", "stream: <signature> FOUND" atau "... ERROR"
+func parseClamAVReply(reply string) (Result, error) {
+	status := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case status == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	case strings.Contains(status, "size limit exceeded"):
+		return Result{}, ErrStreamTooLarge
+	}
+	return Result{}, fmt.Errorf("clamd: unexpected reply %q", reply)
+}
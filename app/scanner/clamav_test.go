@@ -0,0 +1,108 @@
+package scanner_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/scanner"
+)
+
+// fakeClamd menerima satu koneksi INSTREAM dan membalas "FOUND" jika isi
+// mengandung signature EICAR
+func fakeClamd(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		if cmd, _ := r.ReadString(0); cmd != "zINSTREAM\x00" {
+			return
+		}
+		var body bytes.Buffer
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(r, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			io.CopyN(&body, r, int64(n))
+		}
+		if strings.Contains(body.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+			conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+			return
+		}
+		conn.Write([]byte("stream: OK\x00"))
+	}()
+	return ln.Addr().String()
+}
+
+// Test ClamAVScanner - file bersih
+func TestClamAVScanner_Clean(t *testing.T) {
+	s := scanner.NewClamAVScanner("tcp://"+fakeClamd(t), 5*time.Second)
+
+	res, err := s.Scan(context.Background(), strings.NewReader("%PDF-1.4 sertifikat"))
+
+	assert.NoError(t, err)
+	assert.False(t, res.Infected)
+}
+
+// Test ClamAVScanner - file terinfeksi mengembalikan nama signature
+func TestClamAVScanner_Infected(t *testing.T) {
+	s := scanner.NewClamAVScanner(fakeClamd(t), 5*time.Second)
+
+	res, err := s.Scan(context.Background(), strings.NewReader(`X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`))
+
+	assert.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", res.Signature)
+}
+
+// Test ClamAVScanner - clamd tidak bisa dihubungi
+func TestClamAVScanner_Unavailable(t *testing.T) {
+	s := scanner.NewClamAVScanner("127.0.0.1:1", time.Second)
+
+	_, err := s.Scan(context.Background(), strings.NewReader("x"))
+
+	assert.Error(t, err)
+}
+
+// Test ClamAVScanner - file melebihi StreamMaxLength clamd
+func TestClamAVScanner_StreamTooLarge(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	// Seperti clamd: setelah 1 KB balasan error dikirim dan koneksi ditutup
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.CopyN(io.Discard, conn, 1024)
+		conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+	}()
+
+	s := scanner.NewClamAVScanner(ln.Addr().String(), 5*time.Second)
+	_, err = s.Scan(context.Background(), bytes.NewReader(make([]byte, 8<<20)))
+
+	assert.ErrorIs(t, err, scanner.ErrStreamTooLarge)
+}
@@ -0,0 +1,26 @@
+// Package scanner memeriksa file upload terhadap malware sebelum disimpan.
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// Result hasil pemindaian satu file
+type Result struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner memindai isi file. Error berarti pemindaian tidak bisa dilakukan
+// (bukan berarti file bersih).
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// NoopScanner menganggap semua file bersih (default jika tidak ada scanner dikonfigurasi)
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{}, nil
+}
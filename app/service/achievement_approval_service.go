@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// approvalStep tahap persetujuan yang diputuskan oleh request
+type approvalStep struct {
+	stage      *model.AchievementApproval // nil = pengajuan tanpa tahap (sebelum rantai persetujuan)
+	next       *model.AchievementApproval // tahap berikutnya; nil jika stage adalah tahap terakhir
+	onBehalfOf string
+}
+
+// approvalAccess menentukan tahap aktif pengajuan dan memeriksa apakah user
+// boleh memutuskannya: Admin untuk tahap mana pun, dosen wali (atau delegasinya)
+// untuk tahap advisor, dosen wali anggota tim untuk tahap member_advisor,
+// selain itu user dengan role dan cakupan tahap.
+// Error yang dikembalikan berupa *fiber.Error.
+func (s *AchievementService) approvalAccess(c *fiber.Ctx, ref *model.AchievementReference) (approvalStep, error) {
+	var step approvalStep
+	if ref.Status == model.StatusSubmitted && ref.SubmittedAt != nil {
+		stages, err := s.approvals.Stages(ref.ID, *ref.SubmittedAt)
+		if err != nil {
+			return step, fiber.NewError(fiber.StatusInternalServerError, "failed to fetch approval stages")
+		}
+		for i := range stages {
+			if stages[i].Decision == nil {
+				step.stage = &stages[i]
+				if i+1 < len(stages) {
+					step.next = &stages[i+1]
+				}
+				break
+			}
+		}
+		if len(stages) > 0 && step.stage == nil {
+			return step, fiber.NewError(fiber.StatusConflict, "all approval stages have already been decided")
+		}
+	}
+
+	claims := c.Locals("user").(*model.JWTClaims)
+	if claims.Role == "Admin" {
+		return step, nil
+	}
+
+	// Tanpa tahap (atau status bukan submitted, transisi akan ditolak): akses
+	// dosen wali seperti sebelum ada rantai persetujuan
+	if step.stage == nil || step.stage.Scope == model.ScopeAdvisor {
+		if claims.Role != "Dosen Wali" {
+			return step, fiber.NewError(fiber.StatusForbidden, "forbidden: achievement is waiting for advisor approval")
+		}
+		onBehalfOf, err := s.advisorAccess(c, ref.StudentID)
+		step.onBehalfOf = onBehalfOf
+		return step, err
+	}
+
+	// Tahap dosen wali anggota tim (prestasi tim, verifikasi each_member)
+	if step.stage.Scope == model.ScopeMemberAdvisor {
+		if claims.Role != "Dosen Wali" || step.stage.ScopeValue == nil {
+			return step, fiber.NewError(fiber.StatusForbidden, fmt.Sprintf("forbidden: achievement is waiting for %s approval", step.stage.Name))
+		}
+		onBehalfOf, err := s.advisorAccess(c, *step.stage.ScopeValue)
+		step.onBehalfOf = onBehalfOf
+		return step, err
+	}
+
+	ok, err := s.approvals.CanDecide(claims, *step.stage)
+	if err != nil {
+		return step, fiber.NewError(fiber.StatusInternalServerError, "failed to check approver")
+	}
+	if !ok {
+		return step, fiber.NewError(fiber.StatusForbidden, fmt.Sprintf("forbidden: achievement is waiting for %s approval", step.stage.Name))
+	}
+	return step, nil
+}
+
+// apply mengisi tahap yang diputuskan ke perubahan status
+func (step approvalStep) apply(change *model.StatusChange) {
+	change.OnBehalfOf = step.onBehalfOf
+	if step.stage != nil {
+		change.ApprovalID = step.stage.ID
+	}
+}
+
+// approveStage menyetujui tahap yang bukan tahap terakhir; status tetap submitted
+func (s *AchievementService) approveStage(c *fiber.Ctx, ref *model.AchievementReference, step approvalStep) error {
+	note := fmt.Sprintf("approved stage %d (%s)", step.stage.StageOrder, step.stage.Name)
+	change := model.StatusChange{
+		From: ref.Status,
+		To:   ref.Status,
+		Note: &note,
+	}
+	step.apply(&change)
+	if err := s.transition(c, ref, change); err != nil {
+		return statusErrorResponse(c, err, "failed to approve stage")
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message":   "approval stage approved",
+		"status":    ref.Status,
+		"stage":     step.stage.Name,
+		"nextStage": step.next.Name,
+	}))
+}
+
+// ApprovalQueue godoc
+// @Summary List my approval queue
+// @Description Prestasi submitted yang tahap persetujuan aktifnya menunggu keputusan user, yang terlama lebih dulu.
+// @Description Dosen Wali: tahap dosen wali mahasiswa bimbingannya (termasuk delegasi); approver lain: tahap dengan role dan cakupannya; Admin: semua.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Success 200 {object} model.APIResponse{data=model.PaginatedResponse}
+// @Failure 401 {object} model.APIResponse
+// @Router /achievements/approval-queue [get]
+func (s *AchievementService) ApprovalQueue(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	page, limit := pageQuery(c)
+
+	filter := model.ReferenceFilter{
+		AwaitingApproval: true,
+		SortColumn:       "submitted_at",
+		Limit:            limit,
+		Offset:           (page - 1) * limit,
+	}
+	if err := s.applyRoleScope(claims, &filter); err != nil {
+		return err
+	}
+
+	refs, total, err := s.postgresRepo.Search(filter)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+	}
+	achievements, err := s.findAchievements(context.Background(), refs)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+	}
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.ID)
+	}
+	stages, err := s.approvals.CurrentStages(ids)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch approval stages", err.Error()))
+	}
+
+	items := make([]fiber.Map, 0, len(refs))
+	for _, ref := range refs {
+		var stage *model.AchievementApproval
+		if v, ok := stages[ref.ID]; ok {
+			stage = &v
+		}
+		items = append(items, fiber.Map{
+			"reference":   ref,
+			"achievement": achievements[ref.MongoAchievementID],
+			"stage":       stage,
+		})
+	}
+
+	return c.JSON(model.SuccessResponse(model.PaginatedResponse{
+		Items:      items,
+		Pagination: model.NewPagination(page, limit, total),
+	}))
+}
@@ -0,0 +1,888 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/scanner"
+	"go-fiber/app/storage"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// Batas masa berlaku signed URL lampiran
+	attachmentURLMaxTTL = time.Hour
+	// Panjang maksimum caption lampiran
+	maxCaptionLength = 500
+)
+
+// AttachmentPolicy batasan file lampiran yang boleh diupload
+type AttachmentPolicy struct {
+	MaxSize      int64
+	MaxFiles     int // jumlah file per request upload
+	AllowedTypes []string
+	Scanner      scanner.Scanner
+
+	// Upload resumable: ukuran maksimum, tipe file tambahan (mis. video bukti
+	// yang terlalu besar untuk upload biasa), ukuran maksimum satu PATCH dan
+	// masa berlaku upload yang tidak dilanjutkan. Data sementara disimpan di
+	// storage lampiran.
+	MaxResumableSize int64
+	ResumableTypes   []string
+	MaxChunkSize     int64
+	UploadTTL        time.Duration
+
+	// Kuota total ukuran lampiran per mahasiswa dan per prestasi (byte), 0 = tidak dibatasi
+	StudentQuota     int64
+	AchievementQuota int64
+}
+
+// uploadFile sumber file yang akan divalidasi dan disimpan: file multipart
+// atau hasil upload resumable di storage
+type uploadFile struct {
+	name string
+	size int64
+	open func() (io.ReadCloser, error)
+}
+
+func multipartFile(fh *multipart.FileHeader) uploadFile {
+	return uploadFile{
+		name: fh.Filename,
+		size: fh.Size,
+		open: func() (io.ReadCloser, error) { return fh.Open() },
+	}
+}
+
+// chunkedFile upload resumable yang datanya tersebar di beberapa chunk
+// storage; dibaca berurutan tanpa disalin ke disk
+func chunkedFile(store storage.Storage, name string, size int64, chunks []model.UploadChunk) uploadFile {
+	return uploadFile{
+		name: name,
+		size: size,
+		open: func() (io.ReadCloser, error) {
+			return &chunkReader{ctx: context.Background(), storage: store, chunks: chunks}, nil
+		},
+	}
+}
+
+// chunkReader membaca chunk satu per satu sebagai satu stream
+type chunkReader struct {
+	ctx     context.Context
+	storage storage.Storage
+	chunks  []model.UploadChunk
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+			rc, _, err := r.storage.Get(r.ctx, r.chunks[0].StorageKey)
+			if err != nil {
+				return 0, err
+			}
+			r.current, r.chunks = rc, r.chunks[1:]
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Close()
+}
+
+// read membuka file, menjalankan fn lalu menutupnya kembali
+func (f uploadFile) read(fn func(io.Reader) error) error {
+	r, err := f.open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return fn(r)
+}
+
+// limits ukuran maksimum dan tipe file yang diterima untuk upload biasa atau resumable
+func (p AttachmentPolicy) limits(resumable bool) (int64, []string) {
+	if !resumable {
+		return p.MaxSize, p.AllowedTypes
+	}
+	types := append(append([]string{}, p.AllowedTypes...), p.ResumableTypes...)
+	return p.MaxResumableSize, types
+}
+
+func allowsType(types []string, contentType string) bool {
+	for _, t := range types {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// validateUpload memeriksa ukuran, tipe asli file (dari isinya) dan malware.
+// File terinfeksi disalin ke quarantine/ lalu ditolak. Mengembalikan content
+// type hasil sniffing; jika return kedua true, response sudah ditulis.
+func (s *AchievementService) validateUpload(c *fiber.Ctx, achievementID string, file uploadFile, resumable bool) (string, bool, error) {
+	policy := s.uploadPolicy
+	maxSize, allowed := policy.limits(resumable)
+	if file.size > maxSize {
+		return "", true, c.Status(fiber.StatusRequestEntityTooLarge).JSON(model.ErrorResponse("file too large", fiber.Map{
+			"maxSize": maxSize,
+		}))
+	}
+
+	var contentType string
+	err := file.read(func(r io.Reader) (err error) {
+		contentType, err = helper.SniffContentType(r)
+		return err
+	})
+	if err != nil {
+		return "", true, c.Status(500).JSON(model.ErrorResponse("failed to read file", err.Error()))
+	}
+	if !allowsType(allowed, contentType) {
+		return "", true, c.Status(fiber.StatusUnsupportedMediaType).JSON(model.ErrorResponse("unsupported file type", fiber.Map{
+			"detected": contentType,
+			"allowed":  allowed,
+		}))
+	}
+
+	ctx := context.Background()
+	var result scanner.Result
+	var scanErr error
+	if err := file.read(func(r io.Reader) error {
+		result, scanErr = policy.Scanner.Scan(ctx, r)
+		return nil
+	}); err != nil {
+		return "", true, c.Status(500).JSON(model.ErrorResponse("failed to read file", err.Error()))
+	}
+	if errors.Is(scanErr, scanner.ErrStreamTooLarge) {
+		log.Printf("virus scan rejected %d byte upload: clamd StreamMaxLength is below the upload limit", file.size)
+		return "", true, c.Status(fiber.StatusRequestEntityTooLarge).JSON(model.ErrorResponse("file too large for virus scan", scanErr.Error()))
+	}
+	if scanErr != nil {
+		// Fail closed: file tidak diterima jika scanner tidak bisa dihubungi
+		return "", true, c.Status(fiber.StatusServiceUnavailable).JSON(model.ErrorResponse("virus scan unavailable", scanErr.Error()))
+	}
+	if result.Infected {
+		s.quarantine(ctx, achievementID, file, contentType, result.Signature)
+		return "", true, c.Status(fiber.StatusUnprocessableEntity).JSON(model.ErrorResponse("file rejected: malware detected", fiber.Map{
+			"signature": result.Signature,
+		}))
+	}
+	return contentType, false, nil
+}
+
+// quarantine menyimpan file terinfeksi di bawah quarantine/ untuk diperiksa admin.
+// Kegagalan hanya dicatat di log; upload tetap ditolak.
+func (s *AchievementService) quarantine(ctx context.Context, achievementID string, file uploadFile, contentType, signature string) {
+	key := "quarantine/" + helper.AttachmentKey(achievementID, file.name)
+	log.Printf("malware detected in upload for achievement %s (%s), quarantined as %s", achievementID, signature, key)
+
+	err := file.read(func(r io.Reader) error {
+		return s.storage.Put(ctx, key, r, file.size, contentType)
+	})
+	if err != nil {
+		log.Printf("failed to quarantine %s: %v", key, err)
+	}
+}
+
+// findAttachment mencari lampiran berdasarkan ID. Lampiran lama yang belum
+// punya ID dialamatkan dengan nomor urutnya (0, 1, ...).
+func findAttachment(ach *model.Achievement, attachmentID string) (*model.Attachment, bool) {
+	for i := range ach.Attachments {
+		if ach.Attachments[i].ID != "" && ach.Attachments[i].ID == attachmentID {
+			return &ach.Attachments[i], true
+		}
+	}
+	if i, err := strconv.Atoi(attachmentID); err == nil && i >= 0 && i < len(ach.Attachments) && ach.Attachments[i].ID == "" {
+		return &ach.Attachments[i], true
+	}
+	return nil, false
+}
+
+// attachmentTarget mencari lampiran dari params :id dan :attachmentId.
+// authorize = aturan akses sama dengan Detail. Jika return kedua true, response sudah ditulis.
+func (s *AchievementService) attachmentTarget(c *fiber.Ctx, authorize bool) (*model.Attachment, bool, error) {
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return nil, true, c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if authorize {
+		if err := s.checkAccess(c, ref); err != nil {
+			return nil, true, err
+		}
+	}
+	objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return nil, true, c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+	}
+	ach, err := s.mongoRepo.FindByID(context.Background(), objID)
+	if err != nil {
+		return nil, true, c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+	}
+	att, ok := findAttachment(ach, c.Params("attachmentId"))
+	if !ok {
+		return nil, true, c.Status(404).JSON(model.ErrorResponse("attachment not found", nil))
+	}
+	return att, false, nil
+}
+
+// streamAttachment mengirim isi file dari storage. Default file asli sebagai
+// unduhan; ?inline=true untuk ditampilkan langsung, ?variant=thumbnail|preview
+// untuk gambar turunannya (selalu inline).
+func (s *AchievementService) streamAttachment(c *fiber.Ctx, att *model.Attachment) error {
+	key, contentType, filename := att.FileURL, att.FileType, att.FileName
+	disposition := "attachment"
+	if c.QueryBool("inline") {
+		disposition = "inline"
+	}
+
+	base := strings.TrimSuffix(att.FileName, path.Ext(att.FileName))
+	switch c.Query("variant") {
+	case "":
+	case "thumbnail":
+		key, contentType, filename, disposition = att.ThumbnailURL, "image/jpeg", base+"-thumbnail.jpg", "inline"
+	case "preview":
+		// Gambar tidak punya preview terpisah; file aslinya sudah bisa ditampilkan
+		if att.PreviewURL != "" || !strings.HasPrefix(att.FileType, "image/") {
+			key, contentType, filename = att.PreviewURL, "image/png", base+"-preview.png"
+		}
+		disposition = "inline"
+	default:
+		return c.Status(400).JSON(model.ErrorResponse("invalid variant", "use thumbnail or preview"))
+	}
+	if key == "" {
+		return c.Status(404).JSON(model.ErrorResponse("preview not available", fiber.Map{"previewStatus": att.PreviewStatus}))
+	}
+
+	body, info, err := s.storage.Get(context.Background(), key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return c.Status(404).JSON(model.ErrorResponse("file not found", nil))
+	}
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to read file", err.Error()))
+	}
+
+	if contentType == "" {
+		contentType = info.ContentType
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": filename}))
+	// File dari mahasiswa tidak boleh dijalankan sebagai halaman di origin API
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderContentSecurityPolicy, "sandbox")
+	return c.SendStream(body, int(info.Size))
+}
+
+// DownloadAttachment godoc
+// @Summary Download attachment
+// @Description Mengunduh file lampiran dengan aturan akses yang sama dengan detail prestasi
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce octet-stream
+// @Param id path string true "Achievement Reference ID"
+// @Param attachmentId path string true "Attachment ID (atau nomor urut untuk lampiran lama)"
+// @Param inline query bool false "Tampilkan inline, bukan sebagai unduhan"
+// @Param variant query string false "thumbnail | preview"
+// @Success 200 {file} file
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/attachments/{attachmentId} [get]
+func (s *AchievementService) DownloadAttachment(c *fiber.Ctx) error {
+	att, written, err := s.attachmentTarget(c, true)
+	if written {
+		return err
+	}
+	return s.streamAttachment(c, att)
+}
+
+// AttachmentSignedURL godoc
+// @Summary Create signed attachment URL
+// @Description URL lampiran berumur pendek (HMAC) yang bisa dibuka tanpa bearer token, mis. untuk preview
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param attachmentId path string true "Attachment ID (atau nomor urut untuk lampiran lama)"
+// @Param expiresIn query int false "Masa berlaku dalam detik (default ATTACHMENT_URL_TTL, maks 3600)"
+// @Success 200 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/attachments/{attachmentId}/signed-url [post]
+func (s *AchievementService) AttachmentSignedURL(c *fiber.Ctx) error {
+	if _, written, err := s.attachmentTarget(c, true); written {
+		return err
+	}
+
+	ttl := helper.ParseDuration("ATTACHMENT_URL_TTL", 5*time.Minute)
+	if seconds := c.QueryInt("expiresIn"); seconds > 0 {
+		ttl = time.Duration(seconds) * time.Second
+	}
+	if ttl > attachmentURLMaxTTL {
+		ttl = attachmentURLMaxTTL
+	}
+	expires := time.Now().Add(ttl)
+
+	refID, attachmentID := c.Params("id"), c.Params("attachmentId")
+	path, err := c.GetRouteURL("attachment.signed", fiber.Map{"id": refID, "attachmentId": attachmentID})
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to build url", err.Error()))
+	}
+	signature, err := helper.SignAttachment(refID, attachmentID, expires)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to sign url", err.Error()))
+	}
+	url := c.BaseURL() + path +
+		"?expires=" + strconv.FormatInt(expires.Unix(), 10) +
+		"&signature=" + signature
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"url":       url,
+		"expiresAt": expires.Unix(),
+	}))
+}
+
+// SignedAttachment godoc
+// @Summary Download attachment via signed URL
+// @Description Tanpa bearer token; hanya berlaku dengan signature valid yang belum kedaluwarsa
+// @Tags Achievements
+// @Produce octet-stream
+// @Param id path string true "Achievement Reference ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Param expires query int true "Unix time kedaluwarsa"
+// @Param signature query string true "HMAC signature"
+// @Param inline query bool false "Tampilkan inline, bukan sebagai unduhan"
+// @Param variant query string false "thumbnail | preview"
+// @Success 200 {file} file
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /files/achievements/{id}/attachments/{attachmentId} [get]
+func (s *AchievementService) SignedAttachment(c *fiber.Ctx) error {
+	if !helper.VerifyAttachmentSignature(c.Params("id"), c.Params("attachmentId"), c.Query("expires"), c.Query("signature")) {
+		return c.Status(403).JSON(model.ErrorResponse("invalid or expired signature", nil))
+	}
+	att, written, err := s.attachmentTarget(c, false)
+	if written {
+		return err
+	}
+	return s.streamAttachment(c, att)
+}
+
+// editableAchievement memuat reference dan dokumen untuk perubahan lampiran:
+// hanya pemilik, hanya saat draft / revision_requested. Lampiran lama tanpa ID
+// diberi ID lebih dulu. Jika return ketiga true, response sudah ditulis.
+func (s *AchievementService) editableAchievement(c *fiber.Ctx, refID string) (*model.AchievementReference, *model.Achievement, bool, error) {
+	ref, err := s.postgresRepo.FindReferenceByID(refID)
+	if err != nil {
+		return nil, nil, true, c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if err := s.checkOwnership(c, ref.StudentID); err != nil {
+		return nil, nil, true, err
+	}
+	if ref.Status != model.StatusDraft && ref.Status != model.StatusRevisionRequested {
+		return nil, nil, true, c.Status(fiber.StatusConflict).JSON(model.ErrorResponse(
+			"attachments can only be changed while the achievement is draft or revision_requested",
+			fiber.Map{"currentStatus": ref.Status},
+		))
+	}
+
+	objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return nil, nil, true, c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+	}
+	ctx := context.Background()
+	ach, err := s.mongoRepo.FindByID(ctx, objID)
+	if err != nil {
+		return nil, nil, true, c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+	}
+	if err := s.assignAttachmentIDs(ctx, ach); err != nil {
+		return nil, nil, true, attachmentWriteError(c, err)
+	}
+	return ref, ach, false, nil
+}
+
+// assignAttachmentIDs memberi ID pada lampiran lama yang belum punya
+func (s *AchievementService) assignAttachmentIDs(ctx context.Context, ach *model.Achievement) error {
+	list := make([]model.Attachment, len(ach.Attachments))
+	copy(list, ach.Attachments)
+
+	missing := false
+	for i := range list {
+		if list[i].ID == "" {
+			list[i].ID = primitive.NewObjectID().Hex()
+			missing = true
+		}
+	}
+	if !missing {
+		return nil
+	}
+	if err := s.mongoRepo.SetAttachments(ctx, ach.ID, ach.Attachments, list); err != nil {
+		return err
+	}
+	ach.Attachments = list
+	return nil
+}
+
+// attachmentWriteError: ErrNoDocuments berarti lampiran diubah request lain di antara baca dan tulis
+func attachmentWriteError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("attachments were changed by another request, reload and retry", nil))
+	}
+	return c.Status(500).JSON(model.ErrorResponse("failed to update mongo", err.Error()))
+}
+
+// storeAttachment memvalidasi file lalu menyimpannya ke storage sebagai blob
+// (content-addressed: isi yang sama disimpan sekali). attachmentID kosong =
+// lampiran baru; freed ukuran file lama yang diganti untuk perhitungan kuota.
+// Category dan caption diisi pemanggil. Jika return kedua true, response sudah
+// ditulis.
+func (s *AchievementService) storeAttachment(c *fiber.Ctx, ref *model.AchievementReference, attachmentID string, file uploadFile, freed int64, resumable bool) (model.Attachment, bool, error) {
+	// Content-Type dari client tidak dipercaya; tipe diambil dari isi file
+	contentType, written, err := s.validateUpload(c, ref.MongoAchievementID, file, resumable)
+	if written {
+		return model.Attachment{}, true, err
+	}
+	// Hash isi file menjadi key blob dan dipakai untuk deteksi prestasi duplikat
+	var sum string
+	err = file.read(func(r io.Reader) (err error) {
+		sum, err = helper.HashSHA256(r)
+		return err
+	})
+	if err != nil {
+		return model.Attachment{}, true, c.Status(500).JSON(model.ErrorResponse("failed to read file", err.Error()))
+	}
+
+	if attachmentID == "" {
+		attachmentID = primitive.NewObjectID().Hex()
+	}
+	blobRef := model.BlobRef{
+		ID:               primitive.NewObjectID().Hex(),
+		AttachmentID:     attachmentID,
+		StudentID:        ref.StudentID,
+		AchievementRefID: ref.ID,
+		Size:             file.size,
+	}
+	// FileURL menyimpan key storage (bukan path), lokasi fisik ditentukan backend
+	// Kuota diperiksa ulang secara atomik saat ref dicatat; checkQuota di awal
+	// hanya menolak lebih cepat sebelum file dibaca
+	key, err := s.blobs.Store(context.Background(), file, sum, contentType, blobRef, model.BlobQuota{
+		Student:     s.uploadPolicy.StudentQuota,
+		Achievement: s.uploadPolicy.AchievementQuota,
+		Freed:       freed,
+	})
+	var qErr *model.QuotaExceededError
+	if errors.As(err, &qErr) {
+		return model.Attachment{}, true, quotaExceeded(c, qErr)
+	}
+	if err != nil {
+		return model.Attachment{}, true, c.Status(500).JSON(model.ErrorResponse("failed to save file", err.Error()))
+	}
+
+	return model.Attachment{
+		ID:         attachmentID,
+		FileName:   helper.SanitizeFilename(file.name),
+		FileURL:    key,
+		FileType:   contentType,
+		Size:       file.size,
+		UploadedAt: time.Now(),
+		SHA256:     sum,
+		BlobRef:    blobRef.ID,
+		// Thumbnail/preview dibuat di background setelah lampiran tersimpan
+		PreviewStatus: model.PreviewPending,
+	}, false, nil
+}
+
+// removeBlobs melepas file lampiran; isinya dihapus jika tidak dipakai lampiran
+// lain. Kegagalan hanya dicatat: file yatim tidak merusak data, sedangkan
+// lampiran tanpa file akan 404.
+func (s *AchievementService) removeBlobs(ctx context.Context, atts []model.Attachment) {
+	for _, att := range atts {
+		if err := s.blobs.Release(ctx, att); err != nil {
+			log.Printf("failed to remove attachment file %s: %v", att.FileURL, err)
+		}
+	}
+}
+
+// checkQuota memastikan tambahan incoming byte (dikurangi freed dari file yang
+// diganti) masih muat dalam kuota mahasiswa dan kuota prestasi. Dipanggil
+// sebelum file dibaca; batas yang mengikat diperiksa lagi saat file disimpan.
+// Jika return pertama true, response sudah ditulis.
+func (s *AchievementService) checkQuota(c *fiber.Ctx, ref *model.AchievementReference, incoming, freed int64) (bool, error) {
+	policy := s.uploadPolicy
+	if policy.StudentQuota <= 0 && policy.AchievementQuota <= 0 {
+		return false, nil
+	}
+	studentUsed, achievementUsed, err := s.blobs.UsedBytes(ref.StudentID, ref.ID)
+	if err != nil {
+		return true, c.Status(500).JSON(model.ErrorResponse("failed to check storage quota", err.Error()))
+	}
+
+	for _, l := range []model.QuotaExceededError{
+		{Scope: "student", Used: studentUsed, Quota: policy.StudentQuota},
+		{Scope: "achievement", Used: achievementUsed, Quota: policy.AchievementQuota},
+	} {
+		if l.Quota > 0 && l.Used-freed+incoming > l.Quota {
+			l.Requested = incoming - freed
+			return true, quotaExceeded(c, &l)
+		}
+	}
+	return false, nil
+}
+
+func quotaExceeded(c *fiber.Ctx, err *model.QuotaExceededError) error {
+	return c.Status(fiber.StatusRequestEntityTooLarge).JSON(model.ErrorResponse("storage quota exceeded", err))
+}
+
+// attachmentMetadata mengambil category dan caption ke-i dari form (urutan
+// sama dengan file). Category default "other".
+func attachmentMetadata(form *multipart.Form, i int) (string, string) {
+	value := func(key string) string {
+		if v := form.Value[key]; i < len(v) {
+			return strings.TrimSpace(v[i])
+		}
+		return ""
+	}
+	category := value("category")
+	if category == "" {
+		category = model.AttachmentOther
+	}
+	return category, value("caption")
+}
+
+func validateAttachmentMetadata(field, category, caption string) []model.ValidationError {
+	var errs []model.ValidationError
+	if !model.ValidAttachmentCategory(category) {
+		errs = append(errs, model.ValidationError{
+			Field:   field + "category",
+			Message: "must be one of " + strings.Join(model.AttachmentCategories, ", "),
+		})
+	}
+	if len(caption) > maxCaptionLength {
+		errs = append(errs, model.ValidationError{
+			Field:   field + "caption",
+			Message: "must be at most " + strconv.Itoa(maxCaptionLength) + " characters",
+		})
+	}
+	return errs
+}
+
+// UploadAchievementAttachment godoc
+// @Summary Upload achievement attachments
+// @Description Upload satu atau beberapa file pendukung prestasi (draft / revision_requested).
+// @Description category[i] dan caption[i] berlaku untuk file ke-i.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param files formData file true "Attachment files (PDF, JPEG, PNG); field lama 'file' tetap diterima"
+// @Param category formData string false "certificate | photo | assignment_letter | other (default other)"
+// @Param caption formData string false "Keterangan lampiran"
+// @Success 200 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Failure 413 {object} model.APIResponse
+// @Failure 415 {object} model.APIResponse
+// @Failure 422 {object} model.APIResponse
+// @Failure 503 {object} model.APIResponse
+// @Router /achievements/{id}/attachments [post]
+func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
+	ref, ach, written, err := s.editableAchievement(c, c.Params("id"))
+	if written {
+		return err
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("file is required", err.Error()))
+	}
+	files := form.File["files"]
+	if len(files) == 0 {
+		files = form.File["file"]
+	}
+	if len(files) == 0 {
+		return c.Status(400).JSON(model.ErrorResponse("file is required", nil))
+	}
+	if len(files) > s.uploadPolicy.MaxFiles {
+		return c.Status(400).JSON(model.ErrorResponse("too many files", fiber.Map{"maxFiles": s.uploadPolicy.MaxFiles}))
+	}
+
+	var errs []model.ValidationError
+	for i := range files {
+		category, caption := attachmentMetadata(form, i)
+		errs = append(errs, validateAttachmentMetadata("["+strconv.Itoa(i)+"].", category, caption)...)
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.Size
+	}
+	if written, err := s.checkQuota(c, ref, total, 0); written {
+		return err
+	}
+
+	// Semua file atau tidak sama sekali: file yang sudah tersimpan dihapus jika ada yang gagal
+	ctx := context.Background()
+	attachments := make([]model.Attachment, 0, len(files))
+	for i, file := range files {
+		att, written, err := s.storeAttachment(c, ref, "", multipartFile(file), 0, false)
+		if written {
+			s.removeBlobs(ctx, attachments)
+			return err
+		}
+		att.Category, att.Caption = attachmentMetadata(form, i)
+		attachments = append(attachments, att)
+	}
+
+	if err := s.mongoRepo.AddAttachments(ctx, ach.ID, attachments); err != nil {
+		s.removeBlobs(ctx, attachments)
+		return c.Status(500).JSON(model.ErrorResponse("failed to update mongo", err.Error()))
+	}
+	for _, att := range attachments {
+		s.previews.Enqueue(ach.ID, att)
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message": "file uploaded successfully",
+		// "file" dipertahankan untuk client lama yang upload satu file
+		"file":  attachments[0],
+		"files": attachments,
+	}))
+}
+
+// ReplaceAchievementAttachment godoc
+// @Summary Replace attachment
+// @Description Mengganti file dan/atau category/caption sebuah lampiran (draft / revision_requested)
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Param file formData file false "File pengganti (PDF, JPEG, PNG)"
+// @Param category formData string false "certificate | photo | assignment_letter | other"
+// @Param caption formData string false "Keterangan lampiran"
+// @Success 200 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Failure 413 {object} model.APIResponse
+// @Router /achievements/{id}/attachments/{attachmentId} [put]
+func (s *AchievementService) ReplaceAttachment(c *fiber.Ctx) error {
+	ref, ach, written, err := s.editableAchievement(c, c.Params("id"))
+	if written {
+		return err
+	}
+	old, ok := findAttachment(ach, c.Params("attachmentId"))
+	if !ok {
+		return c.Status(404).JSON(model.ErrorResponse("attachment not found", nil))
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid multipart form", err.Error()))
+	}
+	updated := *old
+	if v := form.Value["category"]; len(v) > 0 {
+		updated.Category = strings.TrimSpace(v[0])
+	}
+	if v := form.Value["caption"]; len(v) > 0 {
+		updated.Caption = strings.TrimSpace(v[0])
+	}
+	files := form.File["file"]
+	if len(files) == 0 && updated.Category == old.Category && updated.Caption == old.Caption {
+		return c.Status(400).JSON(model.ErrorResponse("nothing to update", nil))
+	}
+	if updated.Category == "" {
+		updated.Category = model.AttachmentOther
+	}
+	if errs := validateAttachmentMetadata("", updated.Category, updated.Caption); len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	ctx := context.Background()
+	var stored []model.Attachment
+	if len(files) > 0 {
+		// Ukuran file lama hanya diketahui untuk lampiran yang tercatat di kuota
+		var freed int64
+		if old.BlobRef != "" {
+			freed = old.Size
+		}
+		if written, err := s.checkQuota(c, ref, files[0].Size, freed); written {
+			return err
+		}
+		att, written, err := s.storeAttachment(c, ref, old.ID, multipartFile(files[0]), freed, false)
+		if written {
+			return err
+		}
+		att.Category, att.Caption = updated.Category, updated.Caption
+		updated = att
+		stored = append(stored, att)
+	}
+
+	if err := s.mongoRepo.ReplaceAttachment(ctx, ach.ID, updated); err != nil {
+		s.removeBlobs(ctx, stored)
+		return attachmentWriteError(c, err)
+	}
+	// File lama baru dihapus setelah dokumen menunjuk ke file pengganti
+	if len(stored) > 0 {
+		s.removeBlobs(ctx, []model.Attachment{*old})
+		s.previews.Enqueue(ach.ID, updated)
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message": "attachment updated",
+		"file":    updated,
+	}))
+}
+
+// DeleteAchievementAttachment godoc
+// @Summary Delete attachment
+// @Description Menghapus lampiran beserta filenya (draft / revision_requested)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/attachments/{attachmentId} [delete]
+func (s *AchievementService) DeleteAttachment(c *fiber.Ctx) error {
+	_, ach, written, err := s.editableAchievement(c, c.Params("id"))
+	if written {
+		return err
+	}
+	att, ok := findAttachment(ach, c.Params("attachmentId"))
+	if !ok {
+		return c.Status(404).JSON(model.ErrorResponse("attachment not found", nil))
+	}
+
+	ctx := context.Background()
+	if err := s.mongoRepo.RemoveAttachment(ctx, ach.ID, att.ID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return c.Status(404).JSON(model.ErrorResponse("attachment not found", nil))
+		}
+		return c.Status(500).JSON(model.ErrorResponse("failed to update mongo", err.Error()))
+	}
+	s.removeBlobs(ctx, []model.Attachment{*att})
+
+	return c.JSON(model.SuccessResponse(fiber.Map{"message": "attachment deleted"}))
+}
+
+// ReorderAchievementAttachments godoc
+// @Summary Reorder attachments
+// @Description Mengubah urutan lampiran; attachmentIds harus berisi semua ID lampiran tepat satu kali
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param body body model.ReorderAttachmentsRequest true "Urutan baru"
+// @Success 200 {object} model.APIResponse{data=[]model.Attachment}
+// @Failure 400 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/attachments/order [put]
+func (s *AchievementService) ReorderAttachments(c *fiber.Ctx) error {
+	var req model.ReorderAttachmentsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+
+	_, ach, written, err := s.editableAchievement(c, c.Params("id"))
+	if written {
+		return err
+	}
+
+	byID := make(map[string]model.Attachment, len(ach.Attachments))
+	for _, att := range ach.Attachments {
+		byID[att.ID] = att
+	}
+	list := make([]model.Attachment, 0, len(req.AttachmentIDs))
+	for _, id := range req.AttachmentIDs {
+		att, ok := byID[id]
+		if !ok {
+			break
+		}
+		delete(byID, id)
+		list = append(list, att)
+	}
+	if len(list) != len(ach.Attachments) || len(req.AttachmentIDs) != len(ach.Attachments) {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", []model.ValidationError{
+			{Field: "attachmentIds", Message: "must contain every attachment id exactly once"},
+		}))
+	}
+
+	if err := s.mongoRepo.SetAttachments(context.Background(), ach.ID, ach.Attachments, list); err != nil {
+		return attachmentWriteError(c, err)
+	}
+	return c.JSON(model.SuccessResponse(list))
+}
+
+// MyStorage godoc
+// @Summary My attachment storage usage
+// @Description Pemakaian penyimpanan lampiran mahasiswa yang login, total dan per prestasi, beserta kuotanya (0 = tidak dibatasi)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.APIResponse{data=model.StorageUsage}
+// @Failure 404 {object} model.APIResponse
+// @Router /me/storage [get]
+func (s *AchievementService) MyStorage(c *fiber.Ctx) error {
+	claims, ok := c.Locals("user").(*model.JWTClaims)
+	if !ok {
+		return c.Status(401).JSON(model.ErrorResponse("unauthorized", nil))
+	}
+	student, err := s.studentRepo.FindByUserID(claims.UserID)
+	if err != nil || student == nil {
+		return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+	}
+
+	achievements, err := s.blobs.Usage(student.ID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to get storage usage", err.Error()))
+	}
+	usage := model.StorageUsage{
+		QuotaBytes:   s.uploadPolicy.StudentQuota,
+		Achievements: achievements,
+	}
+	for i := range achievements {
+		achievements[i].QuotaBytes = s.uploadPolicy.AchievementQuota
+		usage.UsedBytes += achievements[i].UsedBytes
+		usage.Attachments += achievements[i].Attachments
+	}
+	return c.JSON(model.SuccessResponse(usage))
+}
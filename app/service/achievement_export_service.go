@@ -0,0 +1,261 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"log"
+	"mime"
+	"strings"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Jumlah prestasi yang diambil dan ditulis per halaman saat ekspor
+const exportPageSize = 500
+
+// PDF dibangun di memori, jadi jumlah barisnya dibatasi; gunakan CSV / XLSX
+// untuk ekspor yang lebih besar
+const exportPDFMaxRows = 2000
+
+var achievementExportHeaders = []string{
+	"Reference ID", "NIM", "Title", "Type", "Status", "Points", "Tags", "Created At", "Submitted At", "Verified At",
+}
+
+// Lebar relatif kolom di PDF
+var achievementExportWidths = []float64{2.4, 1.2, 4, 1.4, 1.3, 0.8, 2, 1.6, 1.6, 1.6}
+
+// exportFormat membaca query format (default csv)
+func exportFormat(c *fiber.Ctx) (string, []model.ValidationError) {
+	format := strings.ToLower(c.Query("format", helper.ExportCSV))
+	if _, ok := helper.ExportContentType(format); !ok {
+		return "", []model.ValidationError{{Field: "format", Message: "must be csv, xlsx or pdf"}}
+	}
+	return format, nil
+}
+
+// streamExport mengirim file ekspor sebagai body stream. write dijalankan
+// setelah handler selesai, jadi tidak boleh memakai c; kegagalan di tengah
+// jalan hanya bisa dicatat karena status dan header sudah terkirim.
+func streamExport(c *fiber.Ctx, name, format string, write func(w *bufio.Writer) error) error {
+	contentType, _ := helper.ExportContentType(format)
+	filename := name + "-" + time.Now().Format("20060102") + "." + format
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(w); err != nil {
+			log.Printf("export %s: %v", filename, err)
+			return
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// studentNIMs NIM mahasiswa per student id
+func (s *AchievementService) studentNIMs(refs []model.AchievementReference) (map[string]string, error) {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, ref := range refs {
+		if !seen[ref.StudentID] {
+			seen[ref.StudentID] = true
+			ids = append(ids, ref.StudentID)
+		}
+	}
+	students, err := s.studentRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	nims := make(map[string]string, len(students))
+	for _, st := range students {
+		nims[st.ID] = st.StudentID
+	}
+	return nims, nil
+}
+
+// ExportAchievements godoc
+// @Summary Export achievements
+// @Description Ekspor prestasi sesuai role user (scope dan filter sama dengan GET /achievements, tanpa paging) ke CSV, XLSX atau PDF.
+// @Description CSV dan XLSX dikirim bertahap per halaman; PDF dibatasi 2000 baris.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Param format query string false "csv | xlsx | pdf (default csv)"
+// @Param status query string false "Filter status, pisahkan dengan koma"
+// @Param achievementType query string false "Filter jenis prestasi"
+// @Param studentId query string false "Filter mahasiswa"
+// @Param programStudy query string false "Filter program studi"
+// @Param tags query string false "Filter tags (semua harus ada), pisahkan dengan koma"
+// @Param q query string false "Cari di judul dan deskripsi"
+// @Param dateField query string false "Kolom tanggal untuk from/to: createdAt, submittedAt, verifiedAt"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD)"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Param sort query string false "createdAt, submittedAt, verifiedAt, points (points: maks. 5000 prestasi yang lolos filter)"
+// @Param order query string false "asc / desc (default desc)"
+// @Success 200 {file} file
+// @Failure 400 {object} model.APIResponse
+// @Failure 401 {object} model.APIResponse
+// @Router /achievements/export [get]
+func (s *AchievementService) Export(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	format, errs := exportFormat(c)
+	query := new(model.FilterAchievementRequest)
+	if err := c.QueryParser(query); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid query param", err.Error()))
+	}
+	refFilter, mongoFilter, sortByPoints, filterErrs := parseListFilter(query)
+	errs = append(errs, filterErrs...)
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	if err := s.applyRoleScope(claims, &refFilter); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if !mongoFilter.IsEmpty() {
+		ids, err := s.mongoRepo.FindIDs(ctx, mongoFilter)
+		if err != nil {
+			return c.Status(500).JSON(model.ErrorResponse("failed to filter achievements", err.Error()))
+		}
+		refFilter.MongoIDs = ids
+		refFilter.RestrictMongoIDs = true
+	}
+
+	if format == helper.ExportPDF {
+		refFilter.Limit, refFilter.Offset = 1, 0
+		_, total, err := s.postgresRepo.Search(refFilter)
+		if err != nil {
+			return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+		}
+		if total > exportPDFMaxRows {
+			return c.Status(400).JSON(model.ErrorResponse("too many achievements for pdf export, use csv or xlsx", fiber.Map{
+				"total":   total,
+				"maxRows": exportPDFMaxRows,
+			}))
+		}
+	}
+
+	if sortByPoints {
+		if written, err := s.checkPointsSort(c, refFilter); written {
+			return err
+		}
+	}
+
+	return streamExport(c, "achievements", format, func(w *bufio.Writer) error {
+		return s.writeAchievements(ctx, w, format, refFilter, sortByPoints)
+	})
+}
+
+// writeAchievements menulis prestasi yang lolos filter per halaman
+// exportPageSize; baris CSV dikirim ke client setiap halaman selesai
+func (s *AchievementService) writeAchievements(ctx context.Context, w *bufio.Writer, format string, f model.ReferenceFilter, sortByPoints bool) error {
+	table, err := helper.NewTableWriter(format, w, "Achievements")
+	if err != nil {
+		return err
+	}
+	if err := table.Section("Achievements", achievementExportHeaders, achievementExportWidths...); err != nil {
+		return err
+	}
+
+	page := s.referencePages(f)
+	if sortByPoints {
+		page, err = s.pointsPages(ctx, f)
+		if err != nil {
+			return err
+		}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		refs, achievements, err := page(ctx, offset)
+		if err != nil {
+			return err
+		}
+		nims, err := s.studentNIMs(refs)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if err := table.Row(achievementExportRow(ref, achievements[ref.MongoAchievementID], nims[ref.StudentID])...); err != nil {
+				return err
+			}
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err // client memutus koneksi
+		}
+		if len(refs) < exportPageSize {
+			break
+		}
+	}
+	return table.Close()
+}
+
+// exportPage satu halaman reference beserta dokumennya (per mongo id)
+type exportPage func(ctx context.Context, offset int) ([]model.AchievementReference, map[string]*model.Achievement, error)
+
+// referencePages halaman reference sesuai urutan filter Postgres
+func (s *AchievementService) referencePages(f model.ReferenceFilter) exportPage {
+	return func(ctx context.Context, offset int) ([]model.AchievementReference, map[string]*model.Achievement, error) {
+		f.Limit, f.Offset = exportPageSize, offset
+		refs, _, err := s.postgresRepo.Search(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		achievements, err := s.findAchievements(ctx, refs)
+		return refs, achievements, err
+	}
+}
+
+// pointsPages halaman dokumen yang diurutkan berdasarkan poin. Seperti List,
+// reference yang lolos filter (sudah dibatasi checkPointsSort) diambil semua
+// lebih dulu (hanya kolom Postgres), dokumen Mongo diambil per halaman.
+func (s *AchievementService) pointsPages(ctx context.Context, f model.ReferenceFilter) (exportPage, error) {
+	f.Limit, f.Offset = 0, 0
+	refs, _, err := s.postgresRepo.Search(f)
+	if err != nil {
+		return nil, err
+	}
+	byMongoID := map[string]model.AchievementReference{}
+	objIDs := make([]primitive.ObjectID, 0, len(refs))
+	for _, ref := range refs {
+		if objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID); err == nil {
+			objIDs = append(objIDs, objID)
+			byMongoID[ref.MongoAchievementID] = ref
+		}
+	}
+
+	return func(ctx context.Context, offset int) ([]model.AchievementReference, map[string]*model.Achievement, error) {
+		docs, _, err := s.mongoRepo.FindPageByPoints(ctx, objIDs, model.MongoAchievementFilter{}, f.SortDesc, int64(offset), exportPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		page := make([]model.AchievementReference, 0, len(docs))
+		achievements := make(map[string]*model.Achievement, len(docs))
+		for i := range docs {
+			page = append(page, byMongoID[docs[i].ID.Hex()])
+			achievements[docs[i].ID.Hex()] = &docs[i]
+		}
+		return page, achievements, nil
+	}, nil
+}
+
+// achievementExportRow satu baris ekspor; ach nil jika dokumen Mongo tidak ditemukan
+func achievementExportRow(ref model.AchievementReference, ach *model.Achievement, nim string) []interface{} {
+	row := []interface{}{ref.ID, nim, "", "", ref.Status, 0, "", ref.CreatedAt, ref.SubmittedAt, ref.VerifiedAt}
+	if ach != nil {
+		row[2], row[3], row[5], row[6] = ach.Title, ach.AchievementType, ach.Points, strings.Join(ach.Tags, ", ")
+	}
+	return row
+}
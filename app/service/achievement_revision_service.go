@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newRevision membuat snapshot isi achievement. c boleh nil (revisi dasar
+// dokumen lama yang tidak diketahui editornya).
+func newRevision(c *fiber.Ctx, ach *model.Achievement) *model.AchievementRevision {
+	rev := &model.AchievementRevision{
+		AchievementID:   ach.ID,
+		AchievementType: ach.AchievementType,
+		Title:           ach.Title,
+		Description:     ach.Description,
+		Details:         ach.Details,
+		Tags:            ach.Tags,
+	}
+	if c != nil {
+		if claims, ok := c.Locals("user").(*model.JWTClaims); ok {
+			rev.EditedBy = claims.UserID
+			rev.EditedByRole = claims.Role
+		}
+	}
+	return rev
+}
+
+// revisionTarget mencari reference, memeriksa akses, dan mengembalikan ObjectID
+// dokumennya. Jika return kedua true, response sudah ditulis.
+func (s *AchievementService) revisionTarget(c *fiber.Ctx) (primitive.ObjectID, bool, error) {
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return primitive.NilObjectID, true, c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if err := s.checkAccess(c, ref); err != nil {
+		return primitive.NilObjectID, true, err
+	}
+	objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return primitive.NilObjectID, true, c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+	}
+	return objID, false, nil
+}
+
+// ListAchievementRevisions godoc
+// @Summary List achievement revisions
+// @Description Semua revisi isi prestasi, urut dari yang terlama
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse{data=[]model.AchievementRevision}
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/revisions [get]
+func (s *AchievementService) ListRevisions(c *fiber.Ctx) error {
+	objID, written, err := s.revisionTarget(c)
+	if written {
+		return err
+	}
+
+	revisions, err := s.revisionRepo.FindByAchievementID(context.Background(), objID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch revisions", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(revisions))
+}
+
+// GetAchievementRevision godoc
+// @Summary Get achievement revision
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param revision path int true "Nomor revisi"
+// @Success 200 {object} model.APIResponse{data=model.AchievementRevision}
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/revisions/{revision} [get]
+func (s *AchievementService) GetRevision(c *fiber.Ctx) error {
+	number, err := strconv.Atoi(c.Params("revision"))
+	if err != nil || number < 1 {
+		return c.Status(400).JSON(model.ErrorResponse("invalid revision number", nil))
+	}
+
+	objID, written, err := s.revisionTarget(c)
+	if written {
+		return err
+	}
+
+	rev, err := s.revisionRepo.FindOne(context.Background(), objID, number)
+	if errors.Is(err, repository.ErrRevisionNotFound) {
+		return c.Status(404).JSON(model.ErrorResponse("revision not found", nil))
+	}
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch revision", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(rev))
+}
+
+// DiffAchievementRevisions godoc
+// @Summary Diff two achievement revisions
+// @Description Perbandingan field per field antara dua revisi. Default: revisi terakhir dibanding sebelumnya.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param from query int false "Revisi awal"
+// @Param to query int false "Revisi akhir"
+// @Success 200 {object} model.APIResponse{data=model.RevisionDiff}
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/revisions/diff [get]
+func (s *AchievementService) DiffRevisions(c *fiber.Ctx) error {
+	objID, written, err := s.revisionTarget(c)
+	if written {
+		return err
+	}
+	ctx := context.Background()
+
+	to := c.QueryInt("to")
+	if to == 0 {
+		latest, err := s.revisionRepo.Latest(ctx, objID)
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			return c.Status(404).JSON(model.ErrorResponse("revision not found", nil))
+		}
+		if err != nil {
+			return c.Status(500).JSON(model.ErrorResponse("failed to fetch revision", err.Error()))
+		}
+		to = latest.Revision
+	}
+	from := c.QueryInt("from", to-1)
+	if from < 1 || to < 1 {
+		return c.Status(400).JSON(model.ErrorResponse("from and to must be >= 1", nil))
+	}
+
+	revs := make([]*model.AchievementRevision, 2)
+	for i, number := range []int{from, to} {
+		revs[i], err = s.revisionRepo.FindOne(ctx, objID, number)
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			return c.Status(404).JSON(model.ErrorResponse("revision "+strconv.Itoa(number)+" not found", nil))
+		}
+		if err != nil {
+			return c.Status(500).JSON(model.ErrorResponse("failed to fetch revision", err.Error()))
+		}
+	}
+
+	return c.JSON(model.SuccessResponse(model.RevisionDiff{
+		From:    from,
+		To:      to,
+		Changes: helper.DiffRevisions(revs[0], revs[1]),
+	}))
+}
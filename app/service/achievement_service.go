@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"go-fiber/app/model"
 	"go-fiber/app/repository"
+	"go-fiber/app/storage"
 
 	"github.com/gofiber/fiber/v2"
 
@@ -20,17 +24,59 @@ type AchievementService struct {
 	postgresRepo *repository.AchievementRepository
 	mongoRepo    *repository.MongoAchievementRepository
 	studentRepo  *repository.StudentRepository
+	commentRepo  *repository.CommentRepository
+	pointService *PointService
+	typeService  *AchievementTypeService
+	consistency  *ConsistencyService
+	revisionRepo *repository.MongoRevisionRepository
+	uploadRepo   *repository.UploadRepository
+	storage      storage.Storage
+	uploadPolicy AttachmentPolicy
+	previews     *PreviewService
+	blobs        *BlobService
+	sla          *SLAService
+	delegations  *DelegationService
+	approvals    *ApprovalService
+	teams        *TeamService
 }
 
 func NewAchievementService(
 	postgresRepo *repository.AchievementRepository,
 	mongoRepo *repository.MongoAchievementRepository,
 	studentRepo *repository.StudentRepository,
+	commentRepo *repository.CommentRepository,
+	pointService *PointService,
+	typeService *AchievementTypeService,
+	consistency *ConsistencyService,
+	revisionRepo *repository.MongoRevisionRepository,
+	uploadRepo *repository.UploadRepository,
+	fileStorage storage.Storage,
+	uploadPolicy AttachmentPolicy,
+	previews *PreviewService,
+	blobs *BlobService,
+	sla *SLAService,
+	delegations *DelegationService,
+	approvals *ApprovalService,
+	teams *TeamService,
 ) *AchievementService {
 	return &AchievementService{
 		postgresRepo: postgresRepo,
 		mongoRepo:    mongoRepo,
 		studentRepo:  studentRepo,
+		commentRepo:  commentRepo,
+		pointService: pointService,
+		typeService:  typeService,
+		consistency:  consistency,
+		revisionRepo: revisionRepo,
+		uploadRepo:   uploadRepo,
+		storage:      fileStorage,
+		uploadPolicy: uploadPolicy,
+		previews:     previews,
+		blobs:        blobs,
+		sla:          sla,
+		delegations:  delegations,
+		approvals:    approvals,
+		teams:        teams,
 	}
 }
 
@@ -65,6 +111,15 @@ func (s *AchievementService) Create(c *fiber.Ctx) error {
 		return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
 	}
 
+	if req.Title == "" {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", []model.ValidationError{
+			{Field: "title", Message: "field is required"},
+		}))
+	}
+	if resp, err := s.validateDetails(c, req.AchievementType, req.Details); resp || err != nil {
+		return err
+	}
+
 	// 2. Mapping dari Request DTO ke Entity Model (untuk Database)
 	achievementData := model.Achievement{
 		StudentID:       student.ID,
@@ -75,34 +130,36 @@ func (s *AchievementService) Create(c *fiber.Ctx) error {
 		Tags:            req.Tags,
 		Points:          0, // Default 0
 		Attachments:     []model.Attachment{}, // Inisialisasi slice kosong
+		CurrentRevision: 1,
 	}
 
-	// Insert Mongo
-	ctx := context.Background()
-	// Repository tetap menerima model.Achievement
-	objID, err := s.mongoRepo.CreateAchievement(ctx, &achievementData)
-	if err != nil {
-		return c.Status(500).JSON(model.ErrorResponse("failed create achievement (mongo)", err.Error()))
+	duplicates, written, err := s.checkDuplicates(c, student.ID, &achievementData, "")
+	if written {
+		return err
 	}
 
-	// Insert Postgre Reference
 	ref := &model.AchievementReference{
-		ID:                 uuid.New().String(),
-		StudentID:          student.ID,
-		MongoAchievementID: objID.Hex(),
-		Status:             "draft",
-		CreatedAt:          time.Now(),
-		UpdatedAt:          time.Now(),
+		ID:        uuid.New().String(),
+		StudentID: student.ID,
+		Status:    model.StatusDraft,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
 
-	if err := s.postgresRepo.CreateReference(ref); err != nil {
-		_ = s.mongoRepo.DeleteAchievement(ctx, objID)
-		return c.Status(500).JSON(model.ErrorResponse("failed create achievement reference", err.Error()))
+	// Insert Mongo + Postgres reference lewat saga agar bisa dipulihkan jika terputus
+	if err := s.consistency.CreateAchievement(context.Background(), &achievementData, ref, nil); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed create achievement", err.Error()))
+	}
+
+	// Revisi pertama; kegagalan di sini tidak membatalkan prestasi yang sudah tersimpan
+	if err := s.revisionRepo.Create(context.Background(), newRevision(c, &achievementData)); err != nil {
+		log.Printf("achievement %s: failed to store revision 1: %v", ref.MongoAchievementID, err)
 	}
 
 	return c.JSON(model.SuccessResponse(fiber.Map{
 		"reference":     ref,
-		"achievementId": objID.Hex(),
+		"achievementId": ref.MongoAchievementID,
+		"duplicates":    duplicates,
 	}))
 }
 
@@ -120,7 +177,7 @@ func (s *AchievementService) GetAll(c *fiber.Ctx) error {
     var err error
 
     if filter.StudentID != "" {
-        refs, err = s.postgresRepo.FindByStudentID(filter.StudentID)
+        refs, err = s.postgresRepo.FindByStudentIDs(splitList(filter.StudentID))
     } else {
         refs, err = s.postgresRepo.FindAll()
     }
@@ -130,17 +187,16 @@ func (s *AchievementService) GetAll(c *fiber.Ctx) error {
         return c.JSON(model.ErrorResponse("failed fetch references", err.Error()))
     }
 
-    ctx := context.Background()
-    results := []fiber.Map{}
+    achievements, err := s.findAchievements(context.Background(), refs)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed fetch achievements", err.Error()))
+    }
+
+    results := make([]fiber.Map, 0, len(refs))
     for _, r := range refs {
-        var ach *model.Achievement
-        objID, convErr := primitive.ObjectIDFromHex(r.MongoAchievementID)
-        if convErr == nil {
-            ach, _ = s.mongoRepo.FindByID(ctx, objID) 
-        }
         results = append(results, fiber.Map{
             "reference":   r,
-            "achievement": ach,
+            "achievement": achievements[r.MongoAchievementID],
         })
     }
 
@@ -182,7 +238,8 @@ func (s *AchievementService) GetByID(c *fiber.Ctx) error {
 
 // UpdateAchievement godoc
 // @Summary Update achievement
-// @Description Update data prestasi (draft only)
+// @Description Update data prestasi (draft / revision_requested; prestasi rejected dibuka kembali sebagai draft).
+// @Description Details divalidasi terhadap skema jenis yang tersimpan walaupun jenis sudah nonaktif; jenis lama yang tidak terdaftar tidak divalidasi. achievementType boleh diganti ke jenis aktif.
 // @Tags Achievements
 // @Security BearerAuth
 // @Accept json
@@ -191,6 +248,7 @@ func (s *AchievementService) GetByID(c *fiber.Ctx) error {
 // @Param body body model.UpdateAchievementRequest true "Update request"
 // @Success 200 {object} model.APIResponse
 // @Failure 403 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
 // @Router /achievements/{id} [put]
 func (s *AchievementService) Update(c *fiber.Ctx) error {
 	id := c.Params("id")
@@ -215,19 +273,103 @@ func (s *AchievementService) Update(c *fiber.Ctx) error {
 		return c.Status(500).JSON(model.ErrorResponse("invalid mongo id", err.Error()))
 	}
 
+	current, err := s.mongoRepo.FindByID(context.Background(), objID)
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+	}
+	if req.Title == "" {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", []model.ValidationError{
+			{Field: "title", Message: "field is required"},
+		}))
+	}
+	// Jenis baru harus aktif di registry; jenis yang sudah tersimpan tetap bisa
+	// diedit walaupun nonaktif atau berasal dari sebelum registry ada
+	achievementType := current.AchievementType
+	if req.AchievementType != "" && req.AchievementType != current.AchievementType {
+		achievementType = req.AchievementType
+		if resp, err := s.validateDetails(c, achievementType, req.Details); resp || err != nil {
+			return err
+		}
+	} else if resp, err := s.validateStoredDetails(c, achievementType, req.Details); resp || err != nil {
+		return err
+	}
+
 	update := map[string]interface{}{
-		"title":       req.Title,
-		"description": req.Description,
-		"details":     req.Details,
-		"tags":        req.Tags,
+		"achievementType": achievementType,
+		"title":           req.Title,
+		"description":     req.Description,
+		"details":         req.Details,
+		"tags":            req.Tags,
 		// "points": req.Points, // Poin biasanya tidak diupdate manual user
 	}
 
-	if err := s.mongoRepo.UpdateAchievement(context.Background(), objID, update); err != nil {
+	// Prestasi yang ditolak dibuka kembali sebagai draft (setelah edit
+	// tersimpan) agar bisa disubmit ulang
+	reopen := ref.Status == model.StatusRejected
+	if !reopen && ref.Status != model.StatusDraft && ref.Status != model.StatusRevisionRequested {
+		return statusErrorResponse(c, &model.StatusTransitionError{Current: ref.Status, Requested: model.StatusDraft}, "")
+	}
+
+	ctx := context.Background()
+
+	// Dokumen lama (sebelum ada versioning) disimpan dulu sebagai revisi dasar
+	if current.CurrentRevision == 0 {
+		base := newRevision(nil, current)
+		if err := s.revisionRepo.Create(ctx, base); err != nil {
+			return c.Status(500).JSON(model.ErrorResponse("failed to store revision", err.Error()))
+		}
+	}
+
+	edited := *current
+	edited.AchievementType = achievementType
+	edited.Title = req.Title
+	edited.Description = req.Description
+	edited.Details = req.Details
+	edited.Tags = req.Tags
+	rev := newRevision(c, &edited)
+	if err := s.revisionRepo.Create(ctx, rev); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to store revision", err.Error()))
+	}
+	update["currentRevision"] = rev.Revision
+
+	if err := s.mongoRepo.UpdateAchievement(ctx, objID, update); err != nil {
+		_ = s.revisionRepo.Delete(ctx, rev.ID)
 		return c.Status(500).JSON(model.ErrorResponse("failed update achievement", err.Error()))
 	}
 
-	return c.JSON(model.SuccessResponse("achievement updated"))
+	// Status baru dibuka setelah edit tersimpan; jika gagal, edit dikembalikan
+	// agar prestasi yang tetap rejected tidak berubah isinya
+	if reopen {
+		if err := s.transition(c, ref, model.StatusChange{To: model.StatusDraft}); err != nil {
+			s.revertUpdate(ctx, objID, current, rev.ID)
+			return statusErrorResponse(c, err, "failed to reopen achievement")
+		}
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message":  "achievement updated",
+		"revision": rev.Revision,
+	}))
+}
+
+// revertUpdate mengembalikan dokumen ke isi sebelum Update dan menghapus
+// revisi yang sudah dicatat; kegagalan hanya dicatat di log
+func (s *AchievementService) revertUpdate(ctx context.Context, objID primitive.ObjectID, prev *model.Achievement, revisionID primitive.ObjectID) {
+	err := s.mongoRepo.UpdateAchievement(ctx, objID, map[string]interface{}{
+		"achievementType": prev.AchievementType,
+		"title":           prev.Title,
+		"description":     prev.Description,
+		"details":         prev.Details,
+		"tags":            prev.Tags,
+		"currentRevision": prev.CurrentRevision,
+	})
+	if err != nil {
+		log.Printf("failed to revert update of achievement %s: %v", objID.Hex(), err)
+		return
+	}
+	if err := s.revisionRepo.Delete(ctx, revisionID); err != nil {
+		log.Printf("failed to delete revision of achievement %s: %v", objID.Hex(), err)
+	}
 }
 
 // POST /achievements/:id/reject
@@ -242,6 +384,7 @@ func (s *AchievementService) Update(c *fiber.Ctx) error {
 // @Param id path string true "Achievement Reference ID"
 // @Param body body model.RejectAchievementRequest true "Reject request"
 // @Success 200 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
 // @Router /achievements/{id}/reject [post]
 func (s *AchievementService) Reject(c *fiber.Ctx) error {
     id := c.Params("id")
@@ -257,15 +400,20 @@ func (s *AchievementService) Reject(c *fiber.Ctx) error {
         return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
     }
 
-    if err := s.checkAdvisor(c, ref.StudentID); err != nil {
+    step, err := s.approvalAccess(c, ref)
+    if err != nil {
         return err
     }
 
     // 2. Gunakan data dari request struct (req.Note)
-    if err := s.postgresRepo.UpdateReferenceStatus(
-        id, "rejected", nil, nil, nil, &req.Note,
-    ); err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to reject", err.Error()))
+    change := model.StatusChange{
+        To:            model.StatusRejected,
+        RejectionNote: &req.Note,
+        Note:          &req.Note,
+    }
+    step.apply(&change)
+    if err := s.transition(c, ref, change); err != nil {
+        return statusErrorResponse(c, err, "failed to reject")
     }
 
     c.Status(fiber.StatusOK)
@@ -276,13 +424,14 @@ func (s *AchievementService) Reject(c *fiber.Ctx) error {
 
 // DeleteAchievement godoc
 // @Summary Delete achievement
-// @Description Hapus prestasi (draft only)
+// @Description Pindahkan prestasi ke trash (draft only); bisa dipulihkan sebelum masa simpan habis
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Achievement Reference ID"
 // @Success 200 {object} model.APIResponse
 // @Failure 403 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
 // @Router /achievements/{id} [delete]
 func (s *AchievementService) Delete(c *fiber.Ctx) error {
     id := c.Params("id")
@@ -299,26 +448,22 @@ func (s *AchievementService) Delete(c *fiber.Ctx) error {
             JSON(model.ErrorResponse("you are not the owner of this achievement", nil))
     }
 
-    if ref.Status != "draft" {
-        return c.Status(fiber.StatusForbidden).
-            JSON(model.ErrorResponse("cannot delete: achievement already submitted", nil))
+    note := "soft deleted by student"
+    change := model.StatusChange{
+        From:          ref.Status,
+        To:            model.StatusDeleted,
+        RejectionNote: &note,
+        Note:          &note,
     }
-
-    if objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID); err == nil {
-        _ = s.mongoRepo.DeleteAchievement(context.Background(), objID)
+    withActor(c, &change)
+    if !model.CanTransition(change.From, change.To) {
+        return statusErrorResponse(c, &model.StatusTransitionError{Current: ref.Status, Requested: change.To}, "")
     }
 
-    note := "soft deleted by student"
-    if err := s.postgresRepo.UpdateReferenceStatus(
-        id,
-        "deleted",
-        nil,
-        nil,
-        nil,
-        &note,
-    ); err != nil {
-        return c.Status(fiber.StatusInternalServerError).
-            JSON(model.ErrorResponse("failed to delete achievement", err.Error()))
+    // Status + saga ditulis dalam satu transaksi; dokumen Mongo dihapus setelahnya
+    // (dicoba ulang oleh recovery jika gagal)
+    if err := s.consistency.DeleteAchievement(context.Background(), ref, change); err != nil {
+        return statusErrorResponse(c, err, "failed to delete achievement")
     }
 
     return c.Status(fiber.StatusOK).
@@ -335,6 +480,7 @@ func (s *AchievementService) Delete(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "Achievement Reference ID"
 // @Success 200 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
 // @Router /achievements/{id}/submit [post]
 func (s *AchievementService) Submit(c *fiber.Ctx) error {
     id := c.Params("id")
@@ -345,25 +491,71 @@ func (s *AchievementService) Submit(c *fiber.Ctx) error {
     if err := s.checkOwnership(c, ref.StudentID); err != nil {
         return err
     }
+
+    // Prestasi tim baru bisa diajukan setelah semua anggota merespons undangan
+    members, err := s.teams.Members(ref.ID)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch team members", err.Error()))
+    }
+    for _, m := range members {
+        if m.Status == model.MemberPending {
+            return c.Status(409).JSON(model.ErrorResponse("all team members must confirm participation before submitting", members))
+        }
+    }
+
+    objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+    if err != nil {
+        return c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+    }
+    ach, err := s.mongoRepo.FindByID(context.Background(), objID)
+    if err != nil {
+        return c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+    }
+    duplicates, written, err := s.checkDuplicates(c, ref.StudentID, ach, ref.ID)
+    if written {
+        return err
+    }
+
+    // Tahap persetujuan disalin dari rantai yang berlaku saat ini
+    student, err := s.studentRepo.FindByID(ref.StudentID)
+    if err != nil {
+        return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+    }
+    stages, err := s.approvals.BuildStages(ach, student)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to resolve approval chain", err.Error()))
+    }
+    if stages, err = s.teams.ApprovalStages(stages, student, members); err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to resolve approval chain", err.Error()))
+    }
+
     now := time.Now()
-    if err := s.postgresRepo.UpdateReferenceStatus(
-        id, "submitted", &now, nil, nil, nil,
-    ); err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to submit", err.Error()))
+    if err := s.transition(c, ref, model.StatusChange{
+        To:          model.StatusSubmitted,
+        SubmittedAt: &now,
+        Approvals:   stages,
+    }); err != nil {
+        return statusErrorResponse(c, err, "failed to submit")
     }
-    return c.JSON(model.SuccessResponse("achievement submitted"))
+    return c.JSON(model.SuccessResponse(fiber.Map{
+        "message":    "achievement submitted",
+        "duplicates": duplicates,
+        "approvals":  stages,
+    }))
 }
 
 // POST /achievements/:id/verify
 
 // VerifyAchievement godoc
 // @Summary Verify achievement
-// @Description Verifikasi prestasi (Dosen/Admin)
+// @Description Setujui tahap persetujuan aktif (Dosen Wali, approver tahap, atau Admin).
+// @Description Prestasi baru verified dan diberi poin setelah tahap terakhir disetujui.
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
 // @Param id path string true "Achievement Reference ID"
 // @Success 200 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
 // @Router /achievements/{id}/verify [post]
 func (s *AchievementService) Verify(c *fiber.Ctx) error {
     id := c.Params("id")
@@ -373,58 +565,391 @@ func (s *AchievementService) Verify(c *fiber.Ctx) error {
     if err != nil {
         return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
     }
-    if err := s.checkAdvisor(c, ref.StudentID); err != nil {
+    step, err := s.approvalAccess(c, ref)
+    if err != nil {
         return err
     }
+    if step.next != nil {
+        return s.approveStage(c, ref, step)
+    }
+
+    // Poin dihitung dan ditulis ke Mongo sebelum status diubah agar kegagalan
+    // rule engine atau Mongo tidak meninggalkan prestasi verified tanpa poin
+    ctx := context.Background()
+    objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+    if err != nil {
+        return c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+    }
+    ach, err := s.mongoRepo.FindByID(ctx, objID)
+    if err != nil {
+        return c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+    }
+    points, rule, err := s.pointService.Calculate(ach)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to calculate points", err.Error()))
+    }
+    team, err := s.teamCredits(ref.ID, points, rule)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch team members", err.Error()))
+    }
+
     now := time.Now()
-    if err := s.postgresRepo.UpdateReferenceStatus(
-        id, "verified", nil, &now, &userID, nil,
-    ); err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to verify", err.Error()))
+    change := model.StatusChange{
+        To:         model.StatusVerified,
+        VerifiedAt: &now,
+        VerifiedBy: &userID,
+    }
+    step.apply(&change)
+
+    // Isi tidak bisa diubah selama submitted, jadi revisi saat ini adalah yang disetujui
+    if err := s.assignPoints(ctx, objID, points, ach.CurrentRevision, team); err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to assign points", err.Error()))
     }
+    if err := s.transition(c, ref, change); err != nil {
+        s.revertPoints(ctx, ref.ID, ach)
+        return statusErrorResponse(c, err, "failed to verify")
+    }
+
     c.Status(fiber.StatusOK)
-    return c.JSON(model.SuccessResponse("achievement verified"))
+    return c.JSON(model.SuccessResponse(fiber.Map{
+        "message":          "achievement verified",
+        "points":           points,
+        "matchedRule":      rule,
+        "verifiedRevision": ach.CurrentRevision,
+        "team":             team,
+    }))
+}
+
+// assignPoints menulis poin, revisi yang disetujui dan kredit tim ke dokumen
+// Mongo. Dipanggil sebelum status verified ditulis ke Postgres; jika transisi
+// gagal, nilai lama dikembalikan dengan revertPoints.
+func (s *AchievementService) assignPoints(ctx context.Context, objID primitive.ObjectID, points, revision int, team []model.TeamCredit) error {
+    update := map[string]interface{}{
+        "points":           points,
+        "verifiedRevision": revision,
+    }
+    if team != nil {
+        update["team"] = team
+    }
+    return s.mongoRepo.UpdateAchievement(ctx, objID, update)
+}
+
+// revertPoints mengembalikan poin, revisi yang disetujui dan tim ke nilai
+// sebelum assignPoints (prev). Tidak dilakukan jika prestasi ternyata sudah
+// verified, mis. diverifikasi request lain yang menulis nilai yang sama.
+func (s *AchievementService) revertPoints(ctx context.Context, refID string, prev *model.Achievement) {
+    if ref, err := s.postgresRepo.FindReferenceByID(refID); err == nil && ref.Status == model.StatusVerified {
+        return
+    }
+    update := map[string]interface{}{
+        "points":           prev.Points,
+        "verifiedRevision": prev.VerifiedRevision,
+        "team":             prev.Team,
+    }
+    if err := s.mongoRepo.UpdateAchievement(ctx, prev.ID, update); err != nil {
+        log.Printf("failed to revert points of achievement %s: %v", prev.ID.Hex(), err)
+    }
+}
+
+// Paging default GET /achievements
+const (
+    listDefaultLimit = 20
+    listMaxLimit     = 100
+)
+
+// Poin hanya ada di Mongo, sehingga sort=points membaca semua reference yang
+// lolos filter dan mengirim ID-nya ke Mongo. Jumlahnya dibatasi; di atas batas
+// ini client harus mempersempit filter.
+const pointsSortMaxRows = 5000
+
+// checkPointsSort menghitung reference yang lolos filter sebelum sort=points.
+// Jika melebihi pointsSortMaxRows, response 400 ditulis dan return pertama true.
+func (s *AchievementService) checkPointsSort(c *fiber.Ctx, f model.ReferenceFilter) (bool, error) {
+    f.Limit, f.Offset = 1, 0
+    _, total, err := s.postgresRepo.Search(f)
+    if err != nil {
+        return true, c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+    }
+    if total > pointsSortMaxRows {
+        return true, c.Status(400).JSON(model.ErrorResponse("too many achievements to sort by points, narrow the filter", fiber.Map{
+            "total":   total,
+            "maxRows": pointsSortMaxRows,
+        }))
+    }
+    return false, nil
+}
+
+// pageQuery membaca ?page dan ?limit dengan default dan batas yang sama dengan GET /achievements
+func pageQuery(c *fiber.Ctx) (int, int) {
+    page := c.QueryInt("page", 1)
+    if page < 1 {
+        page = 1
+    }
+    limit := c.QueryInt("limit", listDefaultLimit)
+    if limit < 1 {
+        limit = listDefaultLimit
+    }
+    if limit > listMaxLimit {
+        limit = listMaxLimit
+    }
+    return page, limit
+}
+
+// kolom yang boleh dipakai untuk filter tanggal / sorting (whitelist)
+var listDateColumns = map[string]string{
+    "createdAt":   "created_at",
+    "submittedAt": "submitted_at",
+    "verifiedAt":  "verified_at",
+}
+
+var listStatuses = map[string]bool{
+    model.StatusDraft:             true,
+    model.StatusSubmitted:         true,
+    model.StatusRevisionRequested: true,
+    model.StatusVerified:          true,
+    model.StatusRejected:          true,
+}
+
+// splitList memecah query param "a,b,c" menjadi slice tanpa elemen kosong
+func splitList(v string) []string {
+    var out []string
+    for _, part := range strings.Split(v, ",") {
+        if part = strings.TrimSpace(part); part != "" {
+            out = append(out, part)
+        }
+    }
+    return out
+}
+
+// parseListFilter memvalidasi query GET /achievements dan memisahkan filter
+// Postgres (status, tanggal, mahasiswa) dari filter Mongo (type, tags, q)
+func parseListFilter(q *model.FilterAchievementRequest) (model.ReferenceFilter, model.MongoAchievementFilter, bool, []model.ValidationError) {
+    var errs []model.ValidationError
+
+    if q.Page < 1 {
+        q.Page = 1
+    }
+    if q.Limit < 1 {
+        q.Limit = listDefaultLimit
+    }
+    if q.Limit > listMaxLimit {
+        q.Limit = listMaxLimit
+    }
+
+    ref := model.ReferenceFilter{
+        StudentID:    q.StudentID,
+        ProgramStudy: q.ProgramStudy,
+        Limit:        q.Limit,
+        Offset:       (q.Page - 1) * q.Limit,
+        SortDesc:     true,
+    }
+
+    for _, st := range splitList(q.Status) {
+        if !listStatuses[st] {
+            errs = append(errs, model.ValidationError{Field: "status", Message: "unknown status " + st})
+            continue
+        }
+        ref.Statuses = append(ref.Statuses, st)
+    }
+
+    if q.From != "" || q.To != "" {
+        dateField := q.DateField
+        if dateField == "" {
+            dateField = "createdAt"
+        }
+        column, ok := listDateColumns[dateField]
+        if !ok {
+            errs = append(errs, model.ValidationError{Field: "dateField", Message: "must be one of: createdAt, submittedAt, verifiedAt"})
+        }
+        ref.DateColumn = column
+    }
+    if q.From != "" {
+        from, err := time.Parse("2006-01-02", q.From)
+        if err != nil {
+            errs = append(errs, model.ValidationError{Field: "from", Message: "must be a date (YYYY-MM-DD)"})
+        } else {
+            ref.From = &from
+        }
+    }
+    if q.To != "" {
+        to, err := time.Parse("2006-01-02", q.To)
+        if err != nil {
+            errs = append(errs, model.ValidationError{Field: "to", Message: "must be a date (YYYY-MM-DD)"})
+        } else {
+            to = to.AddDate(0, 0, 1) // inklusif sampai akhir hari
+            ref.To = &to
+        }
+    }
+
+    sortByPoints := false
+    switch q.Sort {
+    case "":
+    case "points":
+        sortByPoints = true
+    default:
+        column, ok := listDateColumns[q.Sort]
+        if !ok {
+            errs = append(errs, model.ValidationError{Field: "sort", Message: "must be one of: createdAt, submittedAt, verifiedAt, points"})
+        }
+        ref.SortColumn = column
+    }
+
+    switch strings.ToLower(q.Order) {
+    case "", "desc":
+    case "asc":
+        ref.SortDesc = false
+    default:
+        errs = append(errs, model.ValidationError{Field: "order", Message: "must be asc or desc"})
+    }
+
+    mongoFilter := model.MongoAchievementFilter{
+        AchievementType: q.AchievementType,
+        Tags:            splitList(q.Tags),
+        Q:               strings.TrimSpace(q.Q),
+    }
+
+    return ref, mongoFilter, sortByPoints, errs
+}
+
+// applyRoleScope membatasi filter sesuai role: Mahasiswa hanya prestasinya,
+// Dosen Wali hanya mahasiswa bimbingannya, Admin tanpa batas, role approver
+// lain (mis. Kaprodi) hanya pengajuan dengan tahap persetujuan untuknya.
+// Prestasi tim ikut untuk setiap anggota yang sudah konfirmasi.
+func (s *AchievementService) applyRoleScope(claims *model.JWTClaims, f *model.ReferenceFilter) error {
+    f.IncludeMembers = true
+    switch claims.Role {
+    case "Mahasiswa":
+        student, err := s.studentRepo.FindByUserID(claims.UserID)
+        if err != nil {
+            return fiber.NewError(fiber.StatusNotFound, "student not found")
+        }
+        f.StudentID = student.ID
+    case "Dosen Wali":
+        f.AdvisorID = claims.UserID
+        // Termasuk mahasiswa bimbingan dosen wali yang sedang mendelegasikan
+        delegators, err := s.delegations.ActiveAdvisorIDs(claims.UserID)
+        if err != nil {
+            return fiber.NewError(fiber.StatusInternalServerError, "failed to fetch delegations")
+        }
+        f.DelegatorIDs = delegators
+    case "Admin":
+    default:
+        f.Approver = &model.ApproverFilter{UserID: claims.UserID, Role: claims.Role}
+    }
+    return nil
 }
 
 // ListAchievements godoc
 // @Summary List achievements
-// @Description List prestasi sesuai role user
+// @Description List prestasi sesuai role user dengan paging, filter, sorting dan pencarian
 // @Tags Achievements
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} model.APIResponse
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Param status query string false "Filter status, pisahkan dengan koma"
+// @Param achievementType query string false "Filter jenis prestasi"
+// @Param studentId query string false "Filter mahasiswa"
+// @Param programStudy query string false "Filter program studi"
+// @Param tags query string false "Filter tags (semua harus ada), pisahkan dengan koma"
+// @Param q query string false "Cari di judul dan deskripsi"
+// @Param dateField query string false "Kolom tanggal untuk from/to: createdAt, submittedAt, verifiedAt"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD)"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD)"
+// @Param sort query string false "createdAt, submittedAt, verifiedAt, points (points: maks. 5000 prestasi yang lolos filter)"
+// @Param order query string false "asc / desc (default desc)"
+// @Success 200 {object} model.APIResponse{data=model.PaginatedResponse}
+// @Failure 400 {object} model.APIResponse
 // @Failure 401 {object} model.APIResponse
 // @Router /achievements [get]
 func (s *AchievementService) List(c *fiber.Ctx) error {
     claims := c.Locals("user").(*model.JWTClaims)
-    if claims.Role == "Mahasiswa" {
-        student, err := s.studentRepo.FindByUserID(claims.UserID)
+
+    query := new(model.FilterAchievementRequest)
+    if err := c.QueryParser(query); err != nil {
+        return c.Status(400).JSON(model.ErrorResponse("invalid query param", err.Error()))
+    }
+    refFilter, mongoFilter, sortByPoints, errs := parseListFilter(query)
+    if len(errs) > 0 {
+        return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+    }
+
+    if err := s.applyRoleScope(claims, &refFilter); err != nil {
+        return err
+    }
+
+    ctx := context.Background()
+
+    // Filter Mongo dijalankan dulu, hasilnya membatasi query Postgres
+    if !mongoFilter.IsEmpty() {
+        ids, err := s.mongoRepo.FindIDs(ctx, mongoFilter)
         if err != nil {
-            return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+            return c.Status(500).JSON(model.ErrorResponse("failed to filter achievements", err.Error()))
+        }
+        refFilter.MongoIDs = ids
+        refFilter.RestrictMongoIDs = true
+    }
+
+    var items []fiber.Map
+    var total int
+
+    if sortByPoints {
+        // Poin hanya ada di Mongo: ambil semua reference yang lolos filter
+        // (paling banyak pointsSortMaxRows), lalu paging + sorting dilakukan oleh Mongo
+        if written, err := s.checkPointsSort(c, refFilter); written {
+            return err
         }
-        refs, err := s.postgresRepo.FindByStudentID(student.ID)
+        limit, offset := refFilter.Limit, refFilter.Offset
+        refFilter.Limit, refFilter.Offset = 0, 0
+
+        refs, _, err := s.postgresRepo.Search(refFilter)
         if err != nil {
             return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
         }
-        return c.JSON(model.SuccessResponse(refs))
-    }
-    if claims.Role == "Dosen Wali" {
-        students, err := s.studentRepo.FindByAdvisorID(claims.UserID)
+        byMongoID := map[string]model.AchievementReference{}
+        objIDs := make([]primitive.ObjectID, 0, len(refs))
+        for _, ref := range refs {
+            if objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID); err == nil {
+                objIDs = append(objIDs, objID)
+                byMongoID[ref.MongoAchievementID] = ref
+            }
+        }
+
+        docs, count, err := s.mongoRepo.FindPageByPoints(ctx, objIDs, model.MongoAchievementFilter{}, refFilter.SortDesc, int64(offset), int64(limit))
         if err != nil {
-            return c.Status(500).JSON(model.ErrorResponse("failed to fetch advisory students", nil))
+            return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
         }
-        var allRefs []model.AchievementReference
-        for _, stu := range students {
-            refs, _ := s.postgresRepo.FindByStudentID(stu.ID)
-            allRefs = append(allRefs, refs...)
+        total = int(count)
+        items = make([]fiber.Map, 0, len(docs))
+        for i := range docs {
+            items = append(items, fiber.Map{
+                "reference":   byMongoID[docs[i].ID.Hex()],
+                "achievement": &docs[i],
+            })
+        }
+    } else {
+        refs, count, err := s.postgresRepo.Search(refFilter)
+        if err != nil {
+            return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+        }
+        total = count
+        achievements, err := s.findAchievements(ctx, refs)
+        if err != nil {
+            return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+        }
+        items = make([]fiber.Map, 0, len(refs))
+        for _, ref := range refs {
+            items = append(items, fiber.Map{
+                "reference":   ref,
+                "achievement": achievements[ref.MongoAchievementID],
+            })
         }
-        return c.JSON(model.SuccessResponse(allRefs))
-    }
-    refs, err := s.postgresRepo.FindAll()
-    if err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
     }
-    return c.JSON(model.SuccessResponse(refs))
+
+    return c.JSON(model.SuccessResponse(model.PaginatedResponse{
+        Items:      items,
+        Pagination: model.NewPagination(query.Page, query.Limit, total),
+    }))
 }
 
 func (s *AchievementService) Detail(c *fiber.Ctx) error {
@@ -433,7 +958,7 @@ func (s *AchievementService) Detail(c *fiber.Ctx) error {
     if err != nil {
         return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
     }
-    if err := s.checkAdvisor(c, ref.StudentID); err != nil {
+    if err := s.checkAccess(c, ref); err != nil {
         return err
     }
     objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
@@ -444,76 +969,472 @@ func (s *AchievementService) Detail(c *fiber.Ctx) error {
     if err != nil {
         return c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
     }
+    comments, err := s.commentRepo.FindByReferenceID(ref.ID)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch comments", err.Error()))
+    }
+    // Hanya terisi selama prestasi menunggu verifikasi (submitted)
+    sla, err := s.sla.Status(*ref)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch sla status", err.Error()))
+    }
+    // Tahap persetujuan pengajuan terakhir
+    approvals := []model.AchievementApproval{}
+    if ref.SubmittedAt != nil {
+        if approvals, err = s.approvals.Stages(ref.ID, *ref.SubmittedAt); err != nil {
+            return c.Status(500).JSON(model.ErrorResponse("failed to fetch approval stages", err.Error()))
+        }
+    }
+    members, err := s.teams.Members(ref.ID)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch team members", err.Error()))
+    }
     return c.JSON(model.SuccessResponse(fiber.Map{
         "reference": ref,
         "achievement": ach,
+        "comments": comments,
+        "sla": sla,
+        "approvals": approvals,
+        "members": members,
     }))
 }
 
+// AchievementHistory godoc
+// @Summary Get achievement status history
+// @Description Riwayat lengkap perubahan status prestasi (urut dari yang terlama)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/history [get]
 func (s *AchievementService) History(c *fiber.Ctx) error {
     id := c.Params("id")
     ref, err := s.postgresRepo.FindReferenceByID(id)
     if err != nil {
         return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
     }
-    if err := s.checkAdvisor(c, ref.StudentID); err != nil {
+    if err := s.checkAccess(c, ref); err != nil {
         return err
     }
+    timeline, err := s.postgresRepo.FindStatusHistory(ref.ID)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch status history", err.Error()))
+    }
     c.Status(fiber.StatusOK)
     return c.JSON(model.SuccessResponse(fiber.Map{
         "id":          ref.ID,
         "status":      ref.Status,
+        "createdAt":   ref.CreatedAt,
         "submittedAt": ref.SubmittedAt,
         "verifiedAt":  ref.VerifiedAt,
         "rejection":   ref.RejectionNote,
+        "timeline":    timeline,
     }))
 }
 
-// UploadAchievementAttachment godoc
-// @Summary Upload achievement attachment
-// @Description Upload file pendukung prestasi
+// POST /achievements/:id/request-revision
+
+// RequestRevision godoc
+// @Summary Request revision
+// @Description Dosen wali meminta mahasiswa memperbaiki prestasi; catatan masuk ke thread komentar
 // @Tags Achievements
 // @Security BearerAuth
-// @Accept multipart/form-data
+// @Accept json
 // @Produce json
 // @Param id path string true "Achievement Reference ID"
-// @Param file formData file true "Attachment file"
+// @Param body body model.RequestRevisionRequest true "Revision request"
 // @Success 200 {object} model.APIResponse
-// @Router /achievements/{id}/attachments [post]
-func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
+// @Failure 400 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/request-revision [post]
+func (s *AchievementService) RequestRevision(c *fiber.Ctx) error {
     id := c.Params("id")
+
+    var req model.RequestRevisionRequest
+    if err := c.BodyParser(&req); err != nil {
+        return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+    }
+    if req.Note == "" {
+        return c.Status(400).JSON(model.ErrorResponse("note is required", nil))
+    }
+
     ref, err := s.postgresRepo.FindReferenceByID(id)
     if err != nil {
         return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
     }
-    file, err := c.FormFile("file")
+    step, err := s.approvalAccess(c, ref)
     if err != nil {
-        return c.Status(400).JSON(model.ErrorResponse("file is required", err.Error()))
+        return err
     }
-    filename := fmt.Sprintf("%d_%s", time.Now().Unix(), file.Filename)
-    savePath := fmt.Sprintf("./uploads/%s", filename)
-    if err := c.SaveFile(file, savePath); err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to save file", err.Error()))
+
+    change := model.StatusChange{
+        To:   model.StatusRevisionRequested,
+        Note: &req.Note,
     }
-    attachment := model.Attachment{
-        FileName:   file.Filename,
-        FileURL:    "/uploads/" + filename,
-        FileType:   file.Header.Get("Content-Type"),
-        UploadedAt: time.Now(),
+    step.apply(&change)
+    if err := s.transition(c, ref, change); err != nil {
+        return statusErrorResponse(c, err, "failed to request revision")
     }
-    objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
-    if err != nil {
-        return c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+
+    claims := c.Locals("user").(*model.JWTClaims)
+    comment := &model.AchievementComment{
+        AchievementRefID: ref.ID,
+        AuthorID:         claims.UserID,
+        AuthorRole:       claims.Role,
+        Body:             req.Note,
+        Field:            req.Field,
     }
-    if err := s.mongoRepo.AddAttachment(context.Background(), objID, attachment); err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to update mongo", err.Error()))
+    if err := s.commentRepo.Create(comment); err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("revision requested but failed to save comment", err.Error()))
     }
+
     return c.JSON(model.SuccessResponse(fiber.Map{
-        "message": "file uploaded successfully",
-        "file":    attachment,
+        "message": "revision requested",
+        "comment": comment,
     }))
 }
 
+// ListAchievementComments godoc
+// @Summary List achievement comments
+// @Description Thread komentar prestasi (pemilik, dosen wali, atau admin)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse{data=[]model.AchievementComment}
+// @Failure 403 {object} model.APIResponse
+// @Router /achievements/{id}/comments [get]
+func (s *AchievementService) ListComments(c *fiber.Ctx) error {
+    ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+    if err != nil {
+        return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+    }
+    if err := s.checkAccess(c, ref); err != nil {
+        return err
+    }
+
+    comments, err := s.commentRepo.FindByReferenceID(ref.ID)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch comments", err.Error()))
+    }
+    return c.JSON(model.SuccessResponse(comments))
+}
+
+// AddAchievementComment godoc
+// @Summary Add achievement comment
+// @Description Menambah komentar ke thread prestasi (pemilik, dosen wali, atau admin)
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param body body model.CreateCommentRequest true "Comment"
+// @Success 201 {object} model.APIResponse{data=model.AchievementComment}
+// @Failure 400 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Router /achievements/{id}/comments [post]
+func (s *AchievementService) AddComment(c *fiber.Ctx) error {
+    var req model.CreateCommentRequest
+    if err := c.BodyParser(&req); err != nil {
+        return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+    }
+    if req.Body == "" {
+        return c.Status(400).JSON(model.ErrorResponse("body is required", nil))
+    }
+
+    ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+    if err != nil {
+        return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+    }
+    if err := s.checkAccess(c, ref); err != nil {
+        return err
+    }
+
+    claims := c.Locals("user").(*model.JWTClaims)
+    comment := &model.AchievementComment{
+        AchievementRefID: ref.ID,
+        AuthorID:         claims.UserID,
+        AuthorRole:       claims.Role,
+        Body:             req.Body,
+        Field:            req.Field,
+    }
+    if err := s.commentRepo.Create(comment); err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to save comment", err.Error()))
+    }
+
+    return c.Status(201).JSON(model.SuccessResponse(comment))
+}
+
+// Batas bulk action: jumlah ID per request dan jumlah item per transaksi
+const (
+    bulkMaxItems  = 200
+    bulkChunkSize = 50
+)
+
+// BulkVerifyAchievements godoc
+// @Summary Bulk verify achievements
+// @Description Verifikasi banyak prestasi sekaligus; hasil dilaporkan per item
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.BulkActionRequest true "Reference IDs"
+// @Success 200 {object} model.APIResponse{data=[]model.BulkItemResult}
+// @Failure 400 {object} model.APIResponse
+// @Router /achievements/bulk/verify [post]
+func (s *AchievementService) BulkVerify(c *fiber.Ctx) error {
+    return s.bulkTransition(c, model.StatusVerified)
+}
+
+// BulkRejectAchievements godoc
+// @Summary Bulk reject achievements
+// @Description Tolak banyak prestasi sekaligus dengan catatan yang sama; hasil dilaporkan per item
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.BulkActionRequest true "Reference IDs + note"
+// @Success 200 {object} model.APIResponse{data=[]model.BulkItemResult}
+// @Failure 400 {object} model.APIResponse
+// @Router /achievements/bulk/reject [post]
+func (s *AchievementService) BulkReject(c *fiber.Ctx) error {
+    return s.bulkTransition(c, model.StatusRejected)
+}
+
+// bulkItem satu item bulk yang lolos pengecekan awal dan siap ditulis
+type bulkItem struct {
+    index    int
+    ref      *model.AchievementReference
+    objID    primitive.ObjectID
+    points   int
+    revision int
+    prev     *model.Achievement // dokumen sebelum poin ditulis (verifikasi akhir)
+    stage    string // tahap yang disetujui jika belum tahap terakhir
+    team     []model.TeamCredit
+    change   model.StatusChange
+}
+
+func (s *AchievementService) bulkTransition(c *fiber.Ctx, to string) error {
+    var req model.BulkActionRequest
+    if err := c.BodyParser(&req); err != nil {
+        return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+    }
+    if len(req.IDs) == 0 {
+        return c.Status(400).JSON(model.ErrorResponse("ids is required", nil))
+    }
+    if len(req.IDs) > bulkMaxItems {
+        return c.Status(400).JSON(model.ErrorResponse(fmt.Sprintf("maximum %d ids per request", bulkMaxItems), nil))
+    }
+
+    var rules []model.PointRule
+    if to == model.StatusVerified {
+        var err error
+        if rules, err = s.pointService.ActiveRules(); err != nil {
+            return c.Status(500).JSON(model.ErrorResponse("failed to load point rules", err.Error()))
+        }
+    }
+
+    claims := c.Locals("user").(*model.JWTClaims)
+    ctx := context.Background()
+    now := time.Now()
+    results := make([]model.BulkItemResult, len(req.IDs))
+    pending := []bulkItem{}
+    seen := map[string]bool{}
+
+    for i, id := range req.IDs {
+        results[i] = model.BulkItemResult{ID: id}
+
+        if seen[id] {
+            results[i].Error = "duplicate id in request"
+            continue
+        }
+        seen[id] = true
+
+        ref, err := s.postgresRepo.FindReferenceByID(id)
+        if err != nil {
+            results[i].Error = "reference not found"
+            continue
+        }
+        results[i].Status = ref.Status
+
+        step, err := s.approvalAccess(c, ref)
+        if err != nil {
+            results[i].Error = err.Error()
+            continue
+        }
+        if !model.CanTransition(ref.Status, to) {
+            results[i].Error = (&model.StatusTransitionError{Current: ref.Status, Requested: to}).Error()
+            continue
+        }
+
+        item := bulkItem{
+            index:  i,
+            ref:    ref,
+            change: model.StatusChange{From: ref.Status, To: to},
+        }
+        step.apply(&item.change)
+        withActor(c, &item.change)
+
+        switch {
+        case to == model.StatusVerified && step.next != nil:
+            // Masih ada tahap berikutnya: hanya tahap ini yang disetujui
+            note := fmt.Sprintf("approved stage %d (%s)", step.stage.StageOrder, step.stage.Name)
+            item.change.To = ref.Status
+            item.change.Note = &note
+            item.stage = step.stage.Name
+        case to == model.StatusVerified:
+            item.objID, err = primitive.ObjectIDFromHex(ref.MongoAchievementID)
+            if err != nil {
+                results[i].Error = "invalid mongo id"
+                continue
+            }
+            ach, err := s.mongoRepo.FindByID(ctx, item.objID)
+            if err != nil {
+                results[i].Error = "achievement not found in mongo"
+                continue
+            }
+            var rule *model.PointRule
+            item.points, rule = CalculatePoints(rules, ach.AchievementType, ach.Details)
+            if item.team, err = s.teamCredits(ref.ID, item.points, rule); err != nil {
+                results[i].Error = "failed to fetch team members"
+                continue
+            }
+            item.revision = ach.CurrentRevision
+            item.change.VerifiedAt = &now
+            item.change.VerifiedBy = &claims.UserID
+
+            // Poin ditulis lebih dulu; dikembalikan jika status gagal disimpan
+            if err := s.assignPoints(ctx, item.objID, item.points, item.revision, item.team); err != nil {
+                results[i].Error = "failed to assign points: " + err.Error()
+                continue
+            }
+            item.prev = ach
+        case to == model.StatusRejected:
+            note := req.Note
+            item.change.RejectionNote = &note
+            item.change.Note = &note
+        }
+
+        pending = append(pending, item)
+    }
+
+    for start := 0; start < len(pending); start += bulkChunkSize {
+        end := start + bulkChunkSize
+        if end > len(pending) {
+            end = len(pending)
+        }
+        chunk := pending[start:end]
+
+        ids := make([]string, len(chunk))
+        changes := make([]model.StatusChange, len(chunk))
+        for i, item := range chunk {
+            ids[i] = item.ref.ID
+            changes[i] = item.change
+        }
+
+        errs, err := s.postgresRepo.TransitionStatusBatch(ids, changes)
+        if err != nil {
+            for _, item := range chunk {
+                results[item.index].Error = "failed to save: " + err.Error()
+                if item.prev != nil {
+                    s.revertPoints(ctx, item.ref.ID, item.prev)
+                }
+            }
+            continue
+        }
+
+        for i, item := range chunk {
+            res := &results[item.index]
+            if errs[i] != nil {
+                res.Error = errs[i].Error()
+                if item.prev != nil {
+                    s.revertPoints(ctx, item.ref.ID, item.prev)
+                }
+                continue
+            }
+            res.Success = true
+            res.Status = item.change.To
+            if item.change.IsStageApproval() {
+                res.Stage = item.stage
+                continue
+            }
+
+            if to == model.StatusVerified {
+                points := item.points
+                res.Points = &points
+            }
+        }
+    }
+
+    return c.JSON(model.SuccessResponse(results))
+}
+
+// validateDetails memvalidasi details terhadap registry jenis prestasi.
+// Jika mengembalikan true, response error sudah ditulis ke c.
+func (s *AchievementService) validateDetails(c *fiber.Ctx, achievementType string, details map[string]interface{}) (bool, error) {
+    errs, err := s.typeService.ValidateAchievement(achievementType, details)
+    if err != nil {
+        return true, c.Status(500).JSON(model.ErrorResponse("failed to validate achievement", err.Error()))
+    }
+    if len(errs) > 0 {
+        return true, c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+    }
+    return false, nil
+}
+
+// validateStoredDetails seperti validateDetails untuk jenis yang sudah tersimpan
+// di prestasi (lihat AchievementTypeService.ValidateExisting)
+func (s *AchievementService) validateStoredDetails(c *fiber.Ctx, achievementType string, details map[string]interface{}) (bool, error) {
+    errs, err := s.typeService.ValidateExisting(achievementType, details)
+    if err != nil {
+        return true, c.Status(500).JSON(model.ErrorResponse("failed to validate achievement", err.Error()))
+    }
+    if len(errs) > 0 {
+        return true, c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+    }
+    return false, nil
+}
+
+// transition memvalidasi perpindahan status terhadap tabel transisi lalu
+// menuliskannya secara atomik (conditional UPDATE) ke Postgres beserta riwayatnya
+func (s *AchievementService) transition(c *fiber.Ctx, ref *model.AchievementReference, change model.StatusChange) error {
+    change.From = ref.Status
+    withActor(c, &change)
+    if !model.CanTransition(change.From, change.To) && !change.IsStageApproval() {
+        return &model.StatusTransitionError{Current: ref.Status, Requested: change.To}
+    }
+    if err := s.postgresRepo.TransitionStatus(ref.ID, change); err != nil {
+        return err
+    }
+    ref.Status = change.To
+    return nil
+}
+
+// withActor mengisi pelaku perubahan status dari JWT claims
+func withActor(c *fiber.Ctx, change *model.StatusChange) {
+    if claims, ok := c.Locals("user").(*model.JWTClaims); ok {
+        change.ActorID = claims.UserID
+        change.ActorRole = claims.Role
+    }
+}
+
+// statusErrorResponse: transisi tidak sah atau tahap persetujuan sudah
+// diputuskan -> 409 (dengan status terkini & yang diminta), lainnya -> 500
+func statusErrorResponse(c *fiber.Ctx, err error, message string) error {
+    var tErr *model.StatusTransitionError
+    if errors.As(err, &tErr) {
+        return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse(tErr.Error(), tErr))
+    }
+    if errors.Is(err, repository.ErrApprovalDecided) {
+        return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse(err.Error(), nil))
+    }
+    return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(message, err.Error()))
+}
+
+// checkOwnership: Mahasiswa hanya boleh mengakses prestasinya sendiri.
+// Error yang dikembalikan berupa *fiber.Error sehingga cukup di-return oleh handler.
 func (s *AchievementService) checkOwnership(c *fiber.Ctx, refStudentID string) error {
     claims := c.Locals("user").(*model.JWTClaims)
     if claims.Role != "Mahasiswa" {
@@ -521,48 +1442,111 @@ func (s *AchievementService) checkOwnership(c *fiber.Ctx, refStudentID string) e
     }
     student, err := s.studentRepo.FindByUserID(claims.UserID)
     if err != nil {
-        return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+        return fiber.NewError(fiber.StatusNotFound, "student not found")
     }
     if student.ID != refStudentID {
-        return c.Status(403).JSON(model.ErrorResponse("forbidden: not owner of the achievement", nil))
+        return fiber.NewError(fiber.StatusForbidden, "forbidden: not owner of the achievement")
     }
     return nil
 }
 
+// checkAdvisor: Dosen Wali hanya boleh mengakses prestasi mahasiswa bimbingannya
+// atau mahasiswa bimbingan dosen wali yang sedang mendelegasikan kepadanya
 func (s *AchievementService) checkAdvisor(c *fiber.Ctx, refStudentID string) error {
+    _, err := s.advisorAccess(c, refStudentID)
+    return err
+}
+
+// advisorAccess seperti checkAdvisor, dan mengembalikan user ID dosen wali asli
+// jika akses diperoleh lewat delegasi (kosong jika bukan delegasi)
+func (s *AchievementService) advisorAccess(c *fiber.Ctx, refStudentID string) (string, error) {
     claims := c.Locals("user").(*model.JWTClaims)
     if claims.Role == "Admin" {
-        return nil
+        return "", nil
     }
     if claims.Role != "Dosen Wali" {
-        return nil
+        return "", nil
     }
     students, err := s.studentRepo.FindByAdvisorID(claims.UserID)
     if err != nil {
-        return c.Status(500).JSON(model.ErrorResponse("failed to fetch advisory students", nil))
+        return "", fiber.NewError(fiber.StatusInternalServerError, "failed to fetch advisory students")
     }
     for _, s := range students {
         if s.ID == refStudentID {
-            return nil 
+            return "", nil
         }
     }
-    return c.Status(403).JSON(model.ErrorResponse("forbidden: student is not under your supervision", nil))
+
+    student, err := s.studentRepo.FindByID(refStudentID)
+    if err == nil && student.AdvisorID != nil {
+        delegated, err := s.delegations.IsActive(*student.AdvisorID, claims.UserID)
+        if err != nil {
+            return "", fiber.NewError(fiber.StatusInternalServerError, "failed to check delegation")
+        }
+        if delegated {
+            return *student.AdvisorID, nil
+        }
+    }
+    return "", fiber.NewError(fiber.StatusForbidden, "forbidden: student is not under your supervision")
 }
 
-func (s *AchievementService) GetByStudentID(studentID string) ([]fiber.Map, error) {
-    refs, err := s.postgresRepo.FindByStudentID(studentID)
+// checkAccess: pemilik (Mahasiswa), dosen wali mahasiswa tersebut, Admin,
+// anggota tim dan dosen walinya, atau approver salah satu tahap persetujuan prestasi
+func (s *AchievementService) checkAccess(c *fiber.Ctx, ref *model.AchievementReference) error {
+    claims := c.Locals("user").(*model.JWTClaims)
+    switch claims.Role {
+    case "Admin":
+        return nil
+    case "Mahasiswa":
+        return s.teamAccess(c, ref, s.checkOwnership(c, ref.StudentID))
+    case "Dosen Wali":
+        return s.teamAccess(c, ref, s.checkAdvisor(c, ref.StudentID))
+    }
+    ok, err := s.approvals.IsApprover(claims, ref.ID)
+    if err != nil {
+        return fiber.NewError(fiber.StatusInternalServerError, "failed to check approver")
+    }
+    if !ok {
+        return fiber.NewError(fiber.StatusForbidden, "forbidden role")
+    }
+    return nil
+}
+
+// findAchievements mengambil dokumen Mongo untuk semua reference dengan satu
+// query $in. Hasilnya di-key dengan mongo_achievement_id; reference dengan ID
+// tidak valid atau dokumen yang hilang tidak ada di map.
+func (s *AchievementService) findAchievements(ctx context.Context, refs []model.AchievementReference) (map[string]*model.Achievement, error) {
+    return achievementsByMongoID(ctx, s.mongoRepo, refs)
+}
+
+// achievementsByMongoID memuat dokumen Mongo untuk refs, dikunci dengan ID hex
+func achievementsByMongoID(ctx context.Context, mongoRepo *repository.MongoAchievementRepository, refs []model.AchievementReference) (map[string]*model.Achievement, error) {
+    objIDs := make([]primitive.ObjectID, 0, len(refs))
+    for _, ref := range refs {
+        if objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID); err == nil {
+            objIDs = append(objIDs, objID)
+        }
+    }
+
+    docs, err := mongoRepo.FindByIDs(ctx, objIDs)
     if err != nil {
         return nil, err
     }
+
+    byID := make(map[string]*model.Achievement, len(docs))
+    for i := range docs {
+        byID[docs[i].ID.Hex()] = &docs[i]
+    }
+    return byID, nil
+}
+
+// studentAchievementMaps menggabungkan reference dengan dokumennya sesuai
+// urutan reference; reference tanpa dokumen dilewati
+func studentAchievementMaps(refs []model.AchievementReference, achievements map[string]*model.Achievement) []fiber.Map {
     results := []fiber.Map{}
-    ctx := context.Background()
     for _, ref := range refs {
-        objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
-        if err != nil {
-            continue
-        }
-        ach, err := s.mongoRepo.FindByID(ctx, objID)
-        if err != nil {
+        ach, ok := achievements[ref.MongoAchievementID]
+        if !ok {
             continue
         }
         results = append(results, fiber.Map{
@@ -573,7 +1557,19 @@ func (s *AchievementService) GetByStudentID(studentID string) ([]fiber.Map, erro
             "achievement": ach,
         })
     }
-    return results, nil
+    return results
+}
+
+func (s *AchievementService) GetByStudentID(studentID string) ([]fiber.Map, error) {
+    refs, err := s.postgresRepo.FindByStudentID(studentID)
+    if err != nil {
+        return nil, err
+    }
+    achievements, err := s.findAchievements(context.Background(), refs)
+    if err != nil {
+        return nil, err
+    }
+    return studentAchievementMaps(refs, achievements), nil
 }
 
 func (s *AchievementService) GetStudentAchievements(c *fiber.Ctx) error {
@@ -582,24 +1578,9 @@ func (s *AchievementService) GetStudentAchievements(c *fiber.Ctx) error {
     if err != nil {
         return c.Status(500).JSON(model.ErrorResponse("failed to fetch references", err.Error()))
     }
-    ctx := context.Background()
-    results := []fiber.Map{}
-    for _, ref := range refs {
-        objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
-        if err != nil {
-            continue
-        }
-        ach, err := s.mongoRepo.FindByID(ctx, objID)
-        if err != nil {
-            continue
-        }
-        results = append(results, fiber.Map{
-            "referenceId": ref.ID,
-            "status":      ref.Status,
-            "createdAt":   ref.CreatedAt,
-            "updatedAt":   ref.UpdatedAt,
-            "achievement": ach,
-        })
+    achievements, err := s.findAchievements(context.Background(), refs)
+    if err != nil {
+        return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
     }
-    return c.JSON(model.SuccessResponse(results))
-}
\ No newline at end of file
+    return c.JSON(model.SuccessResponse(studentAchievementMaps(refs, achievements)))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListOverdueAchievements godoc
+// @Summary List overdue submissions
+// @Description Prestasi submitted yang tahap persetujuan aktifnya menunggu melewati batas SLA, yang terlama lebih dulu.
+// @Description Dosen Wali dan approver hanya melihat pengajuan yang tahap aktifnya menunggu keputusan mereka.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param escalated query bool false "Hanya yang sudah melewati batas eskalasi"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Success 200 {object} model.APIResponse{data=model.PaginatedResponse}
+// @Failure 401 {object} model.APIResponse
+// @Router /achievements/overdue [get]
+func (s *AchievementService) Overdue(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	page, limit := pageQuery(c)
+
+	now := time.Now()
+	filter := s.sla.OverdueFilter(now)
+	if c.QueryBool("escalated") {
+		threshold := now.Add(-s.sla.Policy().EscalateAfter)
+		filter.To = &threshold
+	}
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+	filter.AwaitingApproval = true
+	if err := s.applyRoleScope(claims, &filter); err != nil {
+		return err
+	}
+
+	refs, total, err := s.postgresRepo.Search(filter)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+	}
+	achievements, err := s.findAchievements(context.Background(), refs)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+	}
+	statuses, err := s.sla.Statuses(refs)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch sla status", err.Error()))
+	}
+
+	items := make([]fiber.Map, 0, len(refs))
+	for _, ref := range refs {
+		items = append(items, fiber.Map{
+			"reference":   ref,
+			"achievement": achievements[ref.MongoAchievementID],
+			"sla":         statuses[ref.ID],
+		})
+	}
+
+	return c.JSON(model.SuccessResponse(model.PaginatedResponse{
+		Items:      items,
+		Pagination: model.NewPagination(page, limit, total),
+	}))
+}
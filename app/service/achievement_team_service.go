@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+
+	"go-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// teamEditable: susunan tim hanya bisa diubah (dan dikonfirmasi) sebelum diajukan
+func teamEditable(status string) bool {
+	return status == model.StatusDraft || status == model.StatusRevisionRequested
+}
+
+// teamCredits poin setiap anggota tim saat prestasi diverifikasi; nil untuk
+// prestasi perorangan
+func (s *AchievementService) teamCredits(refID string, points int, rule *model.PointRule) ([]model.TeamCredit, error) {
+	members, err := s.teams.Members(refID)
+	if err != nil {
+		return nil, err
+	}
+	return TeamCredits(points, s.teams.Policy().PointsPolicy(rule), members), nil
+}
+
+// syncTeam menyalin anggota yang sudah konfirmasi ke dokumen Mongo agar
+// prestasi ikut terhitung di statistik setiap anggota. Poin baru dibagi saat verifikasi.
+func (s *AchievementService) syncTeam(ctx context.Context, ref *model.AchievementReference) ([]model.TeamMember, error) {
+	members, err := s.teams.Members(ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+	team := TeamCredits(0, s.teams.Policy().Points, members)
+	if err := s.mongoRepo.UpdateAchievement(ctx, objID, map[string]interface{}{"team": team}); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListTeamMembers godoc
+// @Summary List team members
+// @Description Anggota tim prestasi beserta peran dan status konfirmasinya (kosong untuk prestasi perorangan)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse{data=[]model.TeamMember}
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/members [get]
+func (s *AchievementService) ListTeamMembers(c *fiber.Ctx) error {
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if err := s.checkAccess(c, ref); err != nil {
+		return err
+	}
+	members, err := s.teams.Members(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch team members", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(members))
+}
+
+// UpdateTeamMembers godoc
+// @Summary Set team members
+// @Description Pemilik prestasi mengganti daftar anggota tim (selama draft / revision_requested).
+// @Description Pemilik selalu menjadi anggota; harus ada tepat satu ketua. Anggota baru diberi notifikasi dan harus mengonfirmasi sebelum prestasi bisa di-submit.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param body body model.UpdateTeamRequest true "Anggota tim"
+// @Success 200 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/members [put]
+func (s *AchievementService) UpdateTeamMembers(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if err := s.checkOwnership(c, ref.StudentID); err != nil {
+		return err
+	}
+	if !teamEditable(ref.Status) {
+		return c.Status(409).JSON(model.ErrorResponse("team members can only be changed while the achievement is draft or revision_requested", nil))
+	}
+
+	var req model.UpdateTeamRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+
+	owner, err := s.studentRepo.FindByID(ref.StudentID)
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+	}
+	current, err := s.teams.Members(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch team members", err.Error()))
+	}
+	members, invited, errs := s.teams.BuildTeam(&req, owner, claims.UserID, current)
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	if err := s.teams.Replace(ref, members, invited); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to update team members", err.Error()))
+	}
+	members, err = s.syncTeam(context.Background(), ref)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("team members updated but failed to sync achievement", err.Error()))
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message": "team members updated",
+		"members": members,
+	}))
+}
+
+// ConfirmTeamMembership godoc
+// @Summary Confirm team participation
+// @Description Anggota tim mengonfirmasi keikutsertaannya; prestasi lalu muncul di daftar dan statistik anggota tersebut
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/members/confirm [post]
+func (s *AchievementService) ConfirmTeamMembership(c *fiber.Ctx) error {
+	return s.respondTeam(c, model.MemberConfirmed)
+}
+
+// DeclineTeamMembership godoc
+// @Summary Decline team participation
+// @Description Anggota tim menolak keikutsertaan; prestasi tidak dihitung untuknya
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/members/decline [post]
+func (s *AchievementService) DeclineTeamMembership(c *fiber.Ctx) error {
+	return s.respondTeam(c, model.MemberDeclined)
+}
+
+func (s *AchievementService) respondTeam(c *fiber.Ctx, status string) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	if claims.Role != "Mahasiswa" {
+		return c.Status(403).JSON(model.ErrorResponse("forbidden: only team members can respond", nil))
+	}
+	student, err := s.studentRepo.FindByUserID(claims.UserID)
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+	}
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+
+	member, err := s.teams.Member(ref.ID, student.ID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch team member", err.Error()))
+	}
+	if member == nil {
+		return c.Status(403).JSON(model.ErrorResponse("forbidden: not a member of this team", nil))
+	}
+	if ref.StudentID == student.ID {
+		return c.Status(409).JSON(model.ErrorResponse("the submitter is always part of the team", nil))
+	}
+	if !teamEditable(ref.Status) {
+		return c.Status(409).JSON(model.ErrorResponse("team is locked once the achievement has been submitted", nil))
+	}
+
+	if err := s.teams.Respond(ref.ID, student.ID, status); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to save response", err.Error()))
+	}
+	if _, err := s.syncTeam(context.Background(), ref); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("response saved but failed to sync achievement", err.Error()))
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message": "participation " + status,
+		"status":  status,
+	}))
+}
+
+// TeamInvitations godoc
+// @Summary My team invitations
+// @Description Prestasi tim yang menunggu konfirmasi keikutsertaan mahasiswa yang login
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /me/team-invitations [get]
+func (s *AchievementService) TeamInvitations(c *fiber.Ctx) error {
+	claims, ok := c.Locals("user").(*model.JWTClaims)
+	if !ok {
+		return c.Status(401).JSON(model.ErrorResponse("unauthorized", nil))
+	}
+	student, err := s.studentRepo.FindByUserID(claims.UserID)
+	if err != nil || student == nil {
+		return c.Status(404).JSON(model.ErrorResponse("student not found", nil))
+	}
+
+	refs, err := s.teams.Invitations(student.ID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch invitations", err.Error()))
+	}
+	achievements, err := s.findAchievements(context.Background(), refs)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+	}
+
+	items := make([]fiber.Map, 0, len(refs))
+	for _, ref := range refs {
+		items = append(items, fiber.Map{
+			"reference":   ref,
+			"achievement": achievements[ref.MongoAchievementID],
+		})
+	}
+	return c.JSON(model.SuccessResponse(items))
+}
+
+// teamAccess meneruskan denied (hasil cek pemilik / dosen wali) kecuali user
+// adalah anggota tim (termasuk yang belum konfirmasi) atau dosen wali anggota
+// yang sudah konfirmasi
+func (s *AchievementService) teamAccess(c *fiber.Ctx, ref *model.AchievementReference, denied error) error {
+	if denied == nil {
+		return nil
+	}
+	claims := c.Locals("user").(*model.JWTClaims)
+	switch claims.Role {
+	case "Mahasiswa":
+		student, err := s.studentRepo.FindByUserID(claims.UserID)
+		if err != nil {
+			return denied
+		}
+		member, err := s.teams.Member(ref.ID, student.ID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to check team members")
+		}
+		if member != nil {
+			return nil
+		}
+	case "Dosen Wali":
+		members, err := s.teams.Members(ref.ID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to check team members")
+		}
+		for _, m := range members {
+			if m.Status == model.MemberConfirmed && m.StudentID != ref.StudentID && s.checkAdvisor(c, m.StudentID) == nil {
+				return nil
+			}
+		}
+	}
+	return denied
+}
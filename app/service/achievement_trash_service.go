@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+
+	"go-fiber/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListTrash godoc
+// @Summary List trash
+// @Description Prestasi yang sudah dihapus (soft delete) dan masih bisa dipulihkan, sesuai role user
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Success 200 {object} model.APIResponse{data=model.PaginatedResponse}
+// @Failure 401 {object} model.APIResponse
+// @Router /achievements/trash [get]
+func (s *AchievementService) Trash(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	page, limit := pageQuery(c)
+
+	filter := model.ReferenceFilter{
+		Statuses:   []string{model.StatusDeleted},
+		SortColumn: "updated_at",
+		SortDesc:   true,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+	if err := s.applyRoleScope(claims, &filter); err != nil {
+		return err
+	}
+	// Hanya pemilik yang bisa memulihkan, jadi prestasi tim tidak ikut di trash anggota
+	filter.IncludeMembers = false
+
+	refs, total, err := s.postgresRepo.Search(filter)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch trash", err.Error()))
+	}
+	achievements, err := s.findAchievements(context.Background(), refs)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievements", err.Error()))
+	}
+
+	retention := s.consistency.TrashRetention()
+	items := make([]fiber.Map, 0, len(refs))
+	for _, ref := range refs {
+		items = append(items, fiber.Map{
+			"reference":   ref,
+			"achievement": achievements[ref.MongoAchievementID],
+			"deletedAt":   ref.UpdatedAt,
+			"purgeAt":     ref.UpdatedAt.Add(retention),
+		})
+	}
+
+	return c.JSON(model.SuccessResponse(model.PaginatedResponse{
+		Items:      items,
+		Pagination: model.NewPagination(page, limit, total),
+	}))
+}
+
+// RestoreAchievement godoc
+// @Summary Restore achievement
+// @Description Pulihkan prestasi dari trash; status kembali menjadi draft
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /achievements/{id}/restore [post]
+func (s *AchievementService) Restore(c *fiber.Ctx) error {
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if err := s.checkOwnership(c, ref.StudentID); err != nil {
+		return err
+	}
+
+	note := "restored from trash"
+	change := model.StatusChange{
+		From: ref.Status,
+		To:   model.StatusDraft,
+		Note: &note,
+	}
+	withActor(c, &change)
+	if ref.Status != model.StatusDeleted || !model.CanTransition(change.From, change.To) {
+		return statusErrorResponse(c, &model.StatusTransitionError{Current: ref.Status, Requested: change.To}, "")
+	}
+
+	if err := s.consistency.RestoreAchievement(context.Background(), ref, change); err != nil {
+		return statusErrorResponse(c, err, "failed to restore achievement")
+	}
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message":   "achievement restored",
+		"reference": ref,
+	}))
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var allowedDetailTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+}
+
+type AchievementTypeService struct {
+	typeRepo *repository.AchievementTypeRepository
+}
+
+func NewAchievementTypeService(typeRepo *repository.AchievementTypeRepository) *AchievementTypeService {
+	return &AchievementTypeService{typeRepo: typeRepo}
+}
+
+// ValidateAchievement memvalidasi jenis prestasi dan details-nya terhadap registry.
+// Error kedua hanya untuk kegagalan database.
+func (s *AchievementTypeService) ValidateAchievement(achievementType string, details map[string]interface{}) ([]model.ValidationError, error) {
+	if achievementType == "" {
+		return []model.ValidationError{{Field: "achievementType", Message: "field is required"}}, nil
+	}
+
+	t, err := s.typeRepo.FindByCode(achievementType)
+	if err != nil && !errors.Is(err, repository.ErrAchievementTypeNotFound) {
+		return nil, err
+	}
+	if t == nil || !t.IsActive {
+		return []model.ValidationError{{Field: "achievementType", Message: "unknown achievement type"}}, nil
+	}
+
+	return helper.ValidateAchievementDetails(t.Schema, details), nil
+}
+
+// ValidateExisting memvalidasi details prestasi yang sudah tersimpan saat
+// diedit. Skema jenisnya tetap dipakai walaupun jenis sudah dinonaktifkan;
+// jenis lama yang tidak pernah terdaftar (sebelum ada registry) tidak punya
+// skema sehingga details tidak divalidasi.
+func (s *AchievementTypeService) ValidateExisting(achievementType string, details map[string]interface{}) ([]model.ValidationError, error) {
+	t, err := s.typeRepo.FindByCode(achievementType)
+	if errors.Is(err, repository.ErrAchievementTypeNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return helper.ValidateAchievementDetails(t.Schema, details), nil
+}
+
+func validateTypeRequest(req *model.AchievementTypeRequest, requireCode bool) []model.ValidationError {
+	var errs []model.ValidationError
+
+	if requireCode && req.Code == "" {
+		errs = append(errs, model.ValidationError{Field: "code", Message: "field is required"})
+	}
+	if req.Name == "" {
+		errs = append(errs, model.ValidationError{Field: "name", Message: "field is required"})
+	}
+	for name, prop := range req.Schema.Properties {
+		if !allowedDetailTypes[prop.Type] {
+			errs = append(errs, model.ValidationError{
+				Field:   "schema.properties." + name + ".type",
+				Message: "must be one of: string, number, integer, boolean",
+			})
+		}
+	}
+	for _, name := range req.Schema.Required {
+		if _, ok := req.Schema.Properties[name]; !ok {
+			errs = append(errs, model.ValidationError{
+				Field:   "schema.required",
+				Message: "required field " + name + " is not defined in properties",
+			})
+		}
+	}
+
+	return errs
+}
+
+// ListAchievementTypes godoc
+// @Summary List achievement types
+// @Description Registry jenis prestasi beserta skema details untuk form frontend
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Produce json
+// @Param all query bool false "Sertakan jenis yang nonaktif"
+// @Success 200 {object} model.APIResponse{data=[]model.AchievementType}
+// @Router /achievement-types [get]
+func (s *AchievementTypeService) List(c *fiber.Ctx) error {
+	types, err := s.typeRepo.FindAll(c.QueryBool("all"))
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch achievement types", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(types))
+}
+
+// GetAchievementType godoc
+// @Summary Get achievement type
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Achievement type code"
+// @Success 200 {object} model.APIResponse{data=model.AchievementType}
+// @Failure 404 {object} model.APIResponse
+// @Router /achievement-types/{code} [get]
+func (s *AchievementTypeService) Get(c *fiber.Ctx) error {
+	t, err := s.typeRepo.FindByCode(c.Params("code"))
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("achievement type not found", nil))
+	}
+	return c.JSON(model.SuccessResponse(t))
+}
+
+// CreateAchievementType godoc
+// @Summary Create achievement type
+// @Description Menambah jenis prestasi ke registry (Admin)
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.AchievementTypeRequest true "Achievement type"
+// @Success 201 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Router /achievement-types [post]
+func (s *AchievementTypeService) Create(c *fiber.Ctx) error {
+	var req model.AchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	if errs := validateTypeRequest(&req, true); len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	if err := s.typeRepo.Create(&req); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to create achievement type", err.Error()))
+	}
+	return c.Status(201).JSON(model.SuccessResponse("achievement type created"))
+}
+
+// UpdateAchievementType godoc
+// @Summary Update achievement type
+// @Description Mengubah nama/skema jenis prestasi (Admin)
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param code path string true "Achievement type code"
+// @Param body body model.AchievementTypeRequest true "Achievement type"
+// @Success 200 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievement-types/{code} [put]
+func (s *AchievementTypeService) Update(c *fiber.Ctx) error {
+	var req model.AchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	if errs := validateTypeRequest(&req, false); len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	if err := s.typeRepo.Update(c.Params("code"), &req); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("failed to update achievement type", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse("achievement type updated"))
+}
+
+// DeleteAchievementType godoc
+// @Summary Deactivate achievement type
+// @Description Menonaktifkan jenis prestasi (Admin)
+// @Tags Achievement Types
+// @Security BearerAuth
+// @Produce json
+// @Param code path string true "Achievement type code"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievement-types/{code} [delete]
+func (s *AchievementTypeService) Delete(c *fiber.Ctx) error {
+	if err := s.typeRepo.Deactivate(c.Params("code")); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("achievement type not found", nil))
+	}
+	return c.JSON(model.SuccessResponse("achievement type deactivated"))
+}
@@ -0,0 +1,60 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/repository"
+	"go-fiber/app/service"
+)
+
+var achievementTypeColumns = []string{"code", "name", "description", "schema", "is_active", "created_at", "updated_at"}
+
+// Test ValidateExisting - jenis nonaktif tetap memakai skemanya, prestasi baru ditolak
+func TestValidateExisting_InactiveTypeUsesSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	svc := service.NewAchievementTypeService(repository.NewAchievementTypeRepository(db))
+	schema := []byte(`{"properties":{"rank":{"type":"integer"}},"required":["rank"]}`)
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery(`FROM achievement_types WHERE code = \$1`).
+			WithArgs("olympiad").
+			WillReturnRows(sqlmock.NewRows(achievementTypeColumns).AddRow("olympiad", "Olimpiade", nil, schema, false, time.Now(), time.Now()))
+	}
+
+	errs, err := svc.ValidateExisting("olympiad", map[string]interface{}{"rank": float64(1)})
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = svc.ValidateExisting("olympiad", map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+
+	errs, err = svc.ValidateAchievement("olympiad", map[string]interface{}{"rank": float64(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown achievement type", errs[0].Message)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ValidateExisting - jenis lama yang tidak terdaftar tidak divalidasi
+func TestValidateExisting_LegacyType(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	svc := service.NewAchievementTypeService(repository.NewAchievementTypeRepository(db))
+	mock.ExpectQuery(`FROM achievement_types WHERE code = \$1`).
+		WithArgs("lomba").
+		WillReturnRows(sqlmock.NewRows(achievementTypeColumns))
+
+	errs, err := svc.ValidateExisting("lomba", map[string]interface{}{"bebas": "apa saja"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
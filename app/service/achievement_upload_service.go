@@ -0,0 +1,461 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/app/storage"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Klaim finalize yang lebih lama dari ini dianggap milik request yang
+// terputus dan boleh diambil alih (scan virus file besar bisa makan waktu)
+const uploadFinalizeTimeout = 30 * time.Minute
+
+// uploadChunkKey key storage untuk satu chunk; unik per request sehingga dua
+// PATCH pada offset yang sama tidak saling menimpa sebelum salah satunya
+// memenangkan Advance
+func uploadChunkKey(uploadID string) string {
+	return "resumable/" + uploadID + "/" + uuid.New().String()
+}
+
+// removeChunks menghapus data chunk dari storage
+func (s *AchievementService) removeChunks(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("failed to remove upload chunk %s: %v", key, err)
+		}
+	}
+}
+
+// tusHeaders menandai response sebagai bagian protokol tus
+func tusHeaders(c *fiber.Ctx) {
+	c.Set("Tus-Resumable", model.TusVersion)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+}
+
+// chunkHeaders mengumumkan batas ukuran satu PATCH (bukan header standar tus;
+// client perlu mengatur chunkSize, mis. tus-js-client, sesuai nilai ini)
+func (s *AchievementService) chunkHeaders(c *fiber.Ctx) {
+	c.Set("Upload-Max-Chunk-Size", strconv.FormatInt(s.uploadPolicy.MaxChunkSize, 10))
+}
+
+// UploadOptions godoc
+// @Summary Resumable upload capabilities
+// @Description Discovery protokol tus: versi, extension, ukuran file maksimum (Tus-Max-Size) dan ukuran maksimum satu PATCH (Upload-Max-Chunk-Size).
+// @Tags Achievements
+// @Success 204
+// @Router /uploads [options]
+func (s *AchievementService) UploadOptions(c *fiber.Ctx) error {
+	tusHeaders(c)
+	s.chunkHeaders(c)
+	c.Set("Tus-Version", model.TusVersion)
+	c.Set("Tus-Extension", "creation,termination")
+	c.Set("Tus-Max-Size", strconv.FormatInt(s.uploadPolicy.MaxResumableSize, 10))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// checkTusVersion: request wajib membawa Tus-Resumable yang didukung (412 jika tidak)
+func checkTusVersion(c *fiber.Ctx) error {
+	if c.Get("Tus-Resumable") == model.TusVersion {
+		return nil
+	}
+	c.Set("Tus-Version", model.TusVersion)
+	return c.Status(fiber.StatusPreconditionFailed).JSON(model.ErrorResponse("unsupported Tus-Resumable version", model.TusVersion))
+}
+
+// ownUpload mengambil upload milik user yang login. Upload milik orang lain
+// atau yang sudah kedaluwarsa diperlakukan sebagai tidak ada.
+func (s *AchievementService) ownUpload(c *fiber.Ctx) (*model.ResumableUpload, bool, error) {
+	claims := c.Locals("user").(*model.JWTClaims)
+	upload, err := s.uploadRepo.FindByID(c.Params("uploadId"))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && upload.UserID != claims.UserID) {
+		return nil, true, c.Status(404).JSON(model.ErrorResponse("upload not found", nil))
+	}
+	if err != nil {
+		return nil, true, c.Status(500).JSON(model.ErrorResponse("failed to fetch upload", err.Error()))
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, true, c.Status(fiber.StatusGone).JSON(model.ErrorResponse("upload expired", nil))
+	}
+	return upload, false, nil
+}
+
+// CreateUpload godoc
+// @Summary Create resumable upload
+// @Description Membuat upload lampiran bertahap (protokol tus 1.0, extension creation).
+// @Description Upload-Metadata: filename, category, caption (base64). Lokasi upload dikembalikan di header Location.
+// @Description Data dikirim lewat PATCH dengan chunk paling besar Upload-Max-Chunk-Size byte (ATTACHMENT_UPLOAD_CHUNK_MB, default 32 MB).
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Length header int true "Ukuran total file (byte)"
+// @Param Upload-Metadata header string false "filename <base64>,category <base64>,caption <base64>"
+// @Success 201 {object} model.APIResponse{data=model.ResumableUpload}
+// @Failure 400 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Failure 412 {object} model.APIResponse
+// @Failure 413 {object} model.APIResponse
+// @Router /achievements/{id}/uploads [post]
+func (s *AchievementService) CreateUpload(c *fiber.Ctx) error {
+	tusHeaders(c)
+	if err := checkTusVersion(c); err != nil {
+		return err
+	}
+	ref, _, written, err := s.editableAchievement(c, c.Params("id"))
+	if written {
+		return err
+	}
+
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 1 {
+		return c.Status(400).JSON(model.ErrorResponse("invalid Upload-Length", nil))
+	}
+	if length > s.uploadPolicy.MaxResumableSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(model.ErrorResponse("file too large", fiber.Map{
+			"maxSize": s.uploadPolicy.MaxResumableSize,
+		}))
+	}
+	// Ditolak sejak awal agar client tidak mengirim file yang pasti melebihi kuota
+	if written, err := s.checkQuota(c, ref, length, 0); written {
+		return err
+	}
+
+	meta, err := helper.ParseTusMetadata(c.Get("Upload-Metadata"))
+	if err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid Upload-Metadata", err.Error()))
+	}
+	category := meta["category"]
+	if category == "" {
+		category = model.AttachmentOther
+	}
+	if errs := validateAttachmentMetadata("", category, meta["caption"]); len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+	filename := meta["filename"]
+	if filename == "" {
+		filename = "file"
+	}
+
+	claims := c.Locals("user").(*model.JWTClaims)
+	upload := &model.ResumableUpload{
+		ID:               uuid.New().String(),
+		AchievementRefID: ref.ID,
+		UserID:           claims.UserID,
+		FileName:         filename,
+		Category:         category,
+		Caption:          meta["caption"],
+		Length:           length,
+		ExpiresAt:        time.Now().Add(s.uploadPolicy.UploadTTL),
+	}
+
+	if err := s.uploadRepo.Create(upload); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to create upload", err.Error()))
+	}
+
+	location, err := c.GetRouteURL("upload", fiber.Map{"uploadId": upload.ID})
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to build url", err.Error()))
+	}
+	c.Set(fiber.HeaderLocation, c.BaseURL()+location)
+	s.chunkHeaders(c)
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse(upload))
+}
+
+// UploadOffset godoc
+// @Summary Resumable upload offset
+// @Description Posisi (offset) upload saat ini untuk melanjutkan upload yang terputus
+// @Tags Achievements
+// @Security BearerAuth
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Success 200
+// @Failure 404 {object} model.APIResponse
+// @Failure 410 {object} model.APIResponse
+// @Router /uploads/{uploadId} [head]
+func (s *AchievementService) UploadOffset(c *fiber.Ctx) error {
+	tusHeaders(c)
+	if err := checkTusVersion(c); err != nil {
+		return err
+	}
+	upload, written, err := s.ownUpload(c)
+	if written {
+		return err
+	}
+
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	s.chunkHeaders(c)
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// PatchUpload godoc
+// @Summary Upload chunk
+// @Description Menambahkan chunk pada offset saat ini. Upload-Offset harus sama dengan offset di server.
+// @Description Satu chunk paling besar Upload-Max-Chunk-Size byte (ATTACHMENT_UPLOAD_CHUNK_MB, default 32 MB); chunk yang lebih besar ditolak dengan 413.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept application/offset+octet-stream
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Offset header int true "Offset chunk"
+// @Success 204
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Failure 413 {object} model.APIResponse
+// @Failure 415 {object} model.APIResponse
+// @Router /uploads/{uploadId} [patch]
+func (s *AchievementService) PatchUpload(c *fiber.Ctx) error {
+	tusHeaders(c)
+	if err := checkTusVersion(c); err != nil {
+		return err
+	}
+	if c.Get(fiber.HeaderContentType) != "application/offset+octet-stream" {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(model.ErrorResponse("content type must be application/offset+octet-stream", nil))
+	}
+	// Diperiksa dari header sebelum body dipakai, lalu dari body untuk
+	// request tanpa Content-Length (chunked)
+	if int64(c.Request().Header.ContentLength()) > s.uploadPolicy.MaxChunkSize || int64(len(c.Body())) > s.uploadPolicy.MaxChunkSize {
+		s.chunkHeaders(c)
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(model.ErrorResponse("chunk too large", fiber.Map{
+			"maxChunkSize": s.uploadPolicy.MaxChunkSize,
+		}))
+	}
+
+	upload, written, err := s.ownUpload(c)
+	if written {
+		return err
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset != upload.Offset {
+		c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("Upload-Offset does not match", fiber.Map{"offset": upload.Offset}))
+	}
+	chunk := c.Body()
+	if offset+int64(len(chunk)) > upload.Length {
+		return c.Status(400).JSON(model.ErrorResponse("chunk exceeds Upload-Length", nil))
+	}
+	if len(chunk) == 0 {
+		c.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	// Chunk ditulis ke storage lampiran (bisa dibaca semua instance API).
+	// Tidak ada lock: jika dua request menulis offset yang sama, hanya satu
+	// yang lolos Advance dan data yang kalah dihapus.
+	ctx := context.Background()
+	key := uploadChunkKey(upload.ID)
+	if err := s.uploadRepo.AddChunk(model.UploadChunk{StorageKey: key, UploadID: upload.ID, Offset: offset, Size: int64(len(chunk))}); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to write chunk", err.Error()))
+	}
+	discardChunk := func() {
+		s.removeChunks(ctx, []string{key})
+		if err := s.uploadRepo.DeleteChunk(key); err != nil {
+			log.Printf("failed to delete upload chunk %s: %v", key, err)
+		}
+	}
+	if err := s.storage.Put(ctx, key, bytes.NewReader(chunk), int64(len(chunk)), "application/octet-stream"); err != nil {
+		discardChunk()
+		return c.Status(500).JSON(model.ErrorResponse("failed to write chunk", err.Error()))
+	}
+
+	next := offset + int64(len(chunk))
+	expiresAt := time.Now().Add(s.uploadPolicy.UploadTTL)
+	if err := s.uploadRepo.Advance(upload.ID, offset, next, key, expiresAt); err != nil {
+		discardChunk()
+		if errors.Is(err, repository.ErrUploadOffsetConflict) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("Upload-Offset does not match", nil))
+		}
+		return c.Status(500).JSON(model.ErrorResponse("failed to update upload", err.Error()))
+	}
+
+	c.Set("Upload-Offset", strconv.FormatInt(next, 10))
+	c.Set("Upload-Expires", expiresAt.UTC().Format(http.TimeFormat))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// FinalizeUpload godoc
+// @Summary Finalize resumable upload
+// @Description Upload yang sudah lengkap divalidasi dan disimpan sebagai lampiran prestasi (seperti upload biasa).
+// @Description Selain tipe upload biasa, tipe ATTACHMENT_RESUMABLE_TYPES (default video/mp4, video/webm) diterima.
+// @Description 409 jika upload belum lengkap atau sedang di-finalize request lain.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param uploadId path string true "Upload ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Failure 413 {object} model.APIResponse
+// @Failure 415 {object} model.APIResponse
+// @Failure 422 {object} model.APIResponse
+// @Router /uploads/{uploadId}/finalize [post]
+func (s *AchievementService) FinalizeUpload(c *fiber.Ctx) error {
+	upload, written, err := s.ownUpload(c)
+	if written {
+		return err
+	}
+	if upload.Offset != upload.Length {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("upload incomplete", fiber.Map{
+			"offset": upload.Offset,
+			"length": upload.Length,
+		}))
+	}
+
+	// Status prestasi bisa sudah berubah sejak upload dibuat
+	ref, ach, written, err := s.editableAchievement(c, upload.AchievementRefID)
+	if written {
+		return err
+	}
+
+	// Hanya satu request (di instance mana pun) yang boleh mem-finalize upload
+	if err := s.uploadRepo.ClaimFinalize(upload.ID, time.Now().Add(-uploadFinalizeTimeout)); err != nil {
+		if errors.Is(err, repository.ErrUploadFinalizing) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("upload is already being finalized", nil))
+		}
+		return c.Status(500).JSON(model.ErrorResponse("failed to update upload", err.Error()))
+	}
+	att, written, err := s.finalizeUpload(c, upload, ref)
+	if written {
+		if err := s.uploadRepo.ReleaseFinalize(upload.ID); err != nil {
+			log.Printf("failed to release upload %s: %v", upload.ID, err)
+		}
+		return err
+	}
+
+	ctx := context.Background()
+	if err := s.mongoRepo.AddAttachments(ctx, ach.ID, []model.Attachment{att}); err != nil {
+		s.removeBlobs(ctx, []model.Attachment{att})
+		if err := s.uploadRepo.ReleaseFinalize(upload.ID); err != nil {
+			log.Printf("failed to release upload %s: %v", upload.ID, err)
+		}
+		return c.Status(500).JSON(model.ErrorResponse("failed to update mongo", err.Error()))
+	}
+	s.previews.Enqueue(ach.ID, att)
+	s.discardUpload(upload.ID)
+
+	return c.JSON(model.SuccessResponse(fiber.Map{
+		"message": "file uploaded successfully",
+		"file":    att,
+	}))
+}
+
+// finalizeUpload menyusun data upload dari chunk-nya lalu memvalidasi dan
+// menyimpannya seperti upload biasa. Jika return kedua true, response sudah ditulis.
+func (s *AchievementService) finalizeUpload(c *fiber.Ctx, upload *model.ResumableUpload, ref *model.AchievementReference) (model.Attachment, bool, error) {
+	// Kuota diperiksa ulang: lampiran lain bisa sudah ditambahkan selama upload
+	if written, err := s.checkQuota(c, ref, upload.Length, 0); written {
+		return model.Attachment{}, true, err
+	}
+
+	chunks, err := s.uploadRepo.FindChunks(upload.ID)
+	if err != nil {
+		return model.Attachment{}, true, c.Status(500).JSON(model.ErrorResponse("failed to fetch upload", err.Error()))
+	}
+	var next int64
+	for _, chunk := range chunks {
+		if chunk.Offset != next {
+			break
+		}
+		next += chunk.Size
+	}
+	if next != upload.Length {
+		return model.Attachment{}, true, c.Status(500).JSON(model.ErrorResponse("upload data is incomplete", fiber.Map{
+			"stored": next,
+			"length": upload.Length,
+		}))
+	}
+
+	file := chunkedFile(s.storage, upload.FileName, upload.Length, chunks)
+	att, written, err := s.storeAttachment(c, ref, "", file, 0, true)
+	if written {
+		return model.Attachment{}, true, err
+	}
+	att.Category, att.Caption = upload.Category, upload.Caption
+	return att, false, nil
+}
+
+// TerminateUpload godoc
+// @Summary Cancel resumable upload
+// @Description Membatalkan upload dan menghapus data sementara (extension termination)
+// @Tags Achievements
+// @Security BearerAuth
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Success 204
+// @Failure 404 {object} model.APIResponse
+// @Router /uploads/{uploadId} [delete]
+func (s *AchievementService) TerminateUpload(c *fiber.Ctx) error {
+	tusHeaders(c)
+	if err := checkTusVersion(c); err != nil {
+		return err
+	}
+	upload, written, err := s.ownUpload(c)
+	if written {
+		return err
+	}
+	s.discardUpload(upload.ID)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// discardUpload menghapus baris upload dan data chunk-nya
+func (s *AchievementService) discardUpload(id string) {
+	keys, err := s.uploadRepo.Delete(id)
+	if err != nil {
+		log.Printf("failed to delete upload %s: %v", id, err)
+		return
+	}
+	s.removeChunks(context.Background(), keys)
+}
+
+// ExpireUploads menghapus upload yang ditinggalkan (melewati expires_at)
+// beserta data chunk yang uploadnya sudah tidak ada, termasuk upload yang
+// dihapus StartPurge bersama prestasinya
+func (s *AchievementService) ExpireUploads(ctx context.Context) (int, error) {
+	ids, err := s.uploadRepo.DeleteExpired(time.Now())
+	if err != nil {
+		return 0, err
+	}
+	keys, err := s.uploadRepo.DeleteOrphanChunks()
+	if err != nil {
+		return len(ids), err
+	}
+	s.removeChunks(ctx, keys)
+	return len(ids), nil
+}
+
+// RunUploadExpiry menjalankan ExpireUploads saat start lalu setiap interval
+func (s *AchievementService) RunUploadExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.ExpireUploads(ctx); err != nil {
+			log.Printf("upload expiry failed: %v", err)
+		} else if n > 0 {
+			log.Printf("upload expiry: removed %d abandoned upload(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
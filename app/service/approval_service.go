@@ -0,0 +1,372 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/google/uuid"
+)
+
+// Jumlah maksimum tahap dalam satu rantai persetujuan
+const maxApprovalStages = 5
+
+// ApprovalService konfigurasi rantai persetujuan bertahap. Tahap disalin ke
+// pengajuan saat submit; prestasi baru verified setelah semua tahap menyetujui.
+type ApprovalService struct {
+	repo     *repository.ApprovalRepository
+	userRepo *repository.UserRepository
+}
+
+func NewApprovalService(repo *repository.ApprovalRepository, userRepo *repository.UserRepository) *ApprovalService {
+	return &ApprovalService{repo: repo, userRepo: userRepo}
+}
+
+// MatchApprovalChain memilih rantai aktif yang cocok dengan achievement. Jika
+// lebih dari satu cocok, dipakai yang paling spesifik (paling sedikit
+// wildcard), lalu yang dibuat lebih dulu. Nil jika tidak ada yang cocok.
+func MatchApprovalChain(chains []model.ApprovalChain, achievementType string, details map[string]interface{}) *model.ApprovalChain {
+	level, hasLevel := helper.DetailString(details, DetailCompetitionLevel)
+
+	var best *model.ApprovalChain
+	bestScore := -1
+
+	for i := range chains {
+		chain := &chains[i]
+		if !chain.IsActive || len(chain.Stages) == 0 {
+			continue
+		}
+
+		score := 0
+		if chain.AchievementType != nil {
+			if !strings.EqualFold(*chain.AchievementType, achievementType) {
+				continue
+			}
+			score++
+		}
+		if chain.CompetitionLevel != nil {
+			if !hasLevel || !strings.EqualFold(*chain.CompetitionLevel, level) {
+				continue
+			}
+			score++
+		}
+
+		if score > bestScore {
+			best = chain
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// BuildStages menyusun tahap persetujuan pengajuan baru dari rantai yang cocok.
+// Program studi dan departemen mahasiswa disalin saat ini juga.
+func (s *ApprovalService) BuildStages(ach *model.Achievement, student *model.Student) ([]model.AchievementApproval, error) {
+	chains, err := s.repo.FindActiveChains()
+	if err != nil {
+		return nil, err
+	}
+	stages := model.DefaultApprovalStages
+	if chain := MatchApprovalChain(chains, ach.AchievementType, ach.Details); chain != nil {
+		stages = chain.Stages
+	}
+
+	var department *string
+	result := make([]model.AchievementApproval, 0, len(stages))
+	for i, stage := range stages {
+		a := model.AchievementApproval{
+			ID:         uuid.New().String(),
+			StageOrder: i + 1,
+			Name:       stage.Name,
+			Role:       stage.Role,
+			Scope:      stage.Scope,
+		}
+		switch stage.Scope {
+		case model.ScopeProgramStudy:
+			if student.ProgramStudy != "" {
+				programStudy := student.ProgramStudy
+				a.ScopeValue = &programStudy
+			}
+		case model.ScopeDepartment:
+			if department == nil {
+				d, err := s.repo.StudentDepartment(student.ID)
+				if err != nil {
+					return nil, err
+				}
+				department = &d
+			}
+			if *department != "" {
+				a.ScopeValue = department
+			}
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// Stages tahap persetujuan satu pengajuan
+func (s *ApprovalService) Stages(refID string, submittedAt time.Time) ([]model.AchievementApproval, error) {
+	return s.repo.FindStages(refID, submittedAt)
+}
+
+// CurrentStages tahap aktif banyak reference berstatus submitted
+func (s *ApprovalService) CurrentStages(refIDs []string) (map[string]model.AchievementApproval, error) {
+	return s.repo.FindCurrentStages(refIDs)
+}
+
+// CanDecide memeriksa apakah user termasuk approver tahap (role dan cakupan).
+// Tahap dosen wali diperiksa terpisah lewat data bimbingan dan delegasi.
+func (s *ApprovalService) CanDecide(claims *model.JWTClaims, stage model.AchievementApproval) (bool, error) {
+	if claims.Role != stage.Role {
+		return false, nil
+	}
+	return s.repo.CanDecide(claims.UserID, claims.Role, stage.ID)
+}
+
+// IsApprover memeriksa apakah user termasuk approver salah satu tahap reference
+func (s *ApprovalService) IsApprover(claims *model.JWTClaims, refID string) (bool, error) {
+	return s.repo.IsApprover(claims.UserID, claims.Role, refID)
+}
+
+// validateChain memvalidasi request rantai; nama tahap kosong diisi nama role
+func (s *ApprovalService) validateChain(req *model.ApprovalChainRequest) ([]model.ValidationError, error) {
+	var errs []model.ValidationError
+	if len(req.Stages) == 0 {
+		return append(errs, model.ValidationError{Field: "stages", Message: "at least one stage is required"}), nil
+	}
+	if len(req.Stages) > maxApprovalStages {
+		return append(errs, model.ValidationError{
+			Field:   "stages",
+			Message: "must have at most " + strconv.Itoa(maxApprovalStages) + " stages",
+		}), nil
+	}
+
+	for i := range req.Stages {
+		stage := &req.Stages[i]
+		field := "stages[" + strconv.Itoa(i) + "]"
+		stage.Role = strings.TrimSpace(stage.Role)
+		stage.Name = strings.TrimSpace(stage.Name)
+		if stage.Name == "" {
+			stage.Name = stage.Role
+		}
+
+		switch stage.Scope {
+		case model.ScopeAdvisor:
+			if stage.Role != "Dosen Wali" {
+				errs = append(errs, model.ValidationError{Field: field + ".role", Message: "advisor scope requires role Dosen Wali"})
+				continue
+			}
+		case model.ScopeProgramStudy, model.ScopeDepartment, model.ScopeAll:
+		default:
+			errs = append(errs, model.ValidationError{Field: field + ".scope", Message: "must be one of advisor, program_study, department, all"})
+			continue
+		}
+
+		switch stage.Role {
+		case "":
+			errs = append(errs, model.ValidationError{Field: field + ".role", Message: "field is required"})
+		case "Mahasiswa":
+			errs = append(errs, model.ValidationError{Field: field + ".role", Message: "students cannot approve achievements"})
+		default:
+			exists, err := s.userRepo.RoleExists(stage.Role)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				errs = append(errs, model.ValidationError{Field: field + ".role", Message: "role not found"})
+			}
+		}
+	}
+	return errs, nil
+}
+
+// ListApprovalChains godoc
+// @Summary List approval chains
+// @Description Daftar rantai persetujuan prestasi. Tanpa rantai yang cocok, prestasi cukup diverifikasi dosen wali.
+// @Tags Approval Chains
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.APIResponse{data=[]model.ApprovalChain}
+// @Failure 403 {object} model.APIResponse
+// @Router /approval-chains [get]
+func (s *ApprovalService) List(c *fiber.Ctx) error {
+	chains, err := s.repo.FindAllChains()
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch approval chains", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(chains))
+}
+
+// CreateApprovalChain godoc
+// @Summary Create approval chain
+// @Description Membuat rantai persetujuan untuk jenis prestasi / tingkat kompetisi (null = semua).
+// @Description Scope tahap: advisor (dosen wali), program_study, department, all.
+// @Tags Approval Chains
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.ApprovalChainRequest true "Approval chain"
+// @Success 201 {object} model.APIResponse{data=model.ApprovalChain}
+// @Failure 400 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Router /approval-chains [post]
+func (s *ApprovalService) Create(c *fiber.Ctx) error {
+	var req model.ApprovalChainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	errs, err := s.validateChain(&req)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to validate approval chain", err.Error()))
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	chain, err := s.repo.CreateChain(&req)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to create approval chain", err.Error()))
+	}
+	return c.Status(201).JSON(model.SuccessResponse(chain))
+}
+
+// UpdateApprovalChain godoc
+// @Summary Update approval chain
+// @Description Mengubah rantai persetujuan; pengajuan yang sedang berjalan tetap memakai tahap saat submit
+// @Tags Approval Chains
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Approval Chain ID"
+// @Param body body model.ApprovalChainRequest true "Approval chain"
+// @Success 200 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /approval-chains/{id} [put]
+func (s *ApprovalService) Update(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req model.ApprovalChainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	errs, err := s.validateChain(&req)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to validate approval chain", err.Error()))
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("approval chain not found", nil))
+	}
+	if _, err := s.repo.FindChainByID(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("approval chain not found", nil))
+	}
+	if err := s.repo.UpdateChain(id, &req); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to update approval chain", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse("approval chain updated"))
+}
+
+// DeleteApprovalChain godoc
+// @Summary Delete approval chain
+// @Description Menghapus rantai persetujuan; pengajuan yang sedang berjalan tetap memakai tahap saat submit
+// @Tags Approval Chains
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Approval Chain ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /approval-chains/{id} [delete]
+func (s *ApprovalService) Delete(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("approval chain not found", nil))
+	}
+	if err := s.repo.DeleteChain(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("approval chain not found", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse("approval chain deleted"))
+}
+
+// ApproverScopes godoc
+// @Summary Get approver scopes
+// @Description Program studi / departemen yang ditugaskan ke seorang approver (mis. Kaprodi)
+// @Tags Approval Chains
+// @Security BearerAuth
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} model.APIResponse{data=[]model.ApproverScope}
+// @Failure 404 {object} model.APIResponse
+// @Router /approval-chains/approvers/{userId}/scopes [get]
+func (s *ApprovalService) Scopes(c *fiber.Ctx) error {
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("user not found", nil))
+	}
+	scopes, err := s.repo.FindScopes(userID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch approver scopes", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(scopes))
+}
+
+// UpdateApproverScopes godoc
+// @Summary Replace approver scopes
+// @Description Mengganti seluruh penugasan program studi / departemen seorang approver
+// @Tags Approval Chains
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param body body model.ApproverScopesRequest true "Scopes"
+// @Success 200 {object} model.APIResponse{data=[]model.ApproverScope}
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /approval-chains/approvers/{userId}/scopes [put]
+func (s *ApprovalService) UpdateScopes(c *fiber.Ctx) error {
+	userID := c.Params("userId")
+	if _, err := uuid.Parse(userID); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("user not found", nil))
+	}
+
+	var req model.ApproverScopesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	var errs []model.ValidationError
+	for i := range req.Scopes {
+		sc := &req.Scopes[i]
+		field := "scopes[" + strconv.Itoa(i) + "]"
+		sc.Value = strings.TrimSpace(sc.Value)
+		if sc.Scope != model.ScopeProgramStudy && sc.Scope != model.ScopeDepartment {
+			errs = append(errs, model.ValidationError{Field: field + ".scope", Message: "must be one of program_study, department"})
+		}
+		if sc.Value == "" {
+			errs = append(errs, model.ValidationError{Field: field + ".value", Message: "field is required"})
+		}
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("user not found", nil))
+	}
+	if err := s.repo.ReplaceScopes(userID, req.Scopes); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to update approver scopes", err.Error()))
+	}
+	scopes, err := s.repo.FindScopes(userID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch approver scopes", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(scopes))
+}
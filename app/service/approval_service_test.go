@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+)
+
+func testApprovalChains() []model.ApprovalChain {
+	kaprodi := []model.ApprovalStage{
+		{Name: "Dosen Wali", Role: "Dosen Wali", Scope: model.ScopeAdvisor},
+		{Name: "Kaprodi", Role: "Kaprodi", Scope: model.ScopeProgramStudy},
+	}
+	return []model.ApprovalChain{
+		{ID: "competition", AchievementType: strPtr("competition"), Stages: model.DefaultApprovalStages, IsActive: true},
+		{ID: "national", AchievementType: strPtr("competition"), CompetitionLevel: strPtr("national"), Stages: kaprodi, IsActive: true},
+		{ID: "international", CompetitionLevel: strPtr("international"), Stages: kaprodi, IsActive: true},
+		{ID: "inactive", AchievementType: strPtr("publication"), Stages: kaprodi, IsActive: false},
+	}
+}
+
+// Test MatchApprovalChain - rantai paling spesifik menang
+func TestMatchApprovalChain_MostSpecificWins(t *testing.T) {
+	chain := service.MatchApprovalChain(testApprovalChains(), "competition", map[string]interface{}{
+		"competitionLevel": "National",
+	})
+
+	assert.Equal(t, "national", chain.ID)
+	assert.Len(t, chain.Stages, 2)
+}
+
+// Test MatchApprovalChain - wildcard jenis prestasi, tingkat lain memakai rantai jenis
+func TestMatchApprovalChain_Wildcard(t *testing.T) {
+	chain := service.MatchApprovalChain(testApprovalChains(), "organization", map[string]interface{}{
+		"competitionLevel": "international",
+	})
+	assert.Equal(t, "international", chain.ID)
+
+	chain = service.MatchApprovalChain(testApprovalChains(), "competition", map[string]interface{}{
+		"competitionLevel": "regional",
+	})
+	assert.Equal(t, "competition", chain.ID)
+}
+
+// Test MatchApprovalChain - rantai non-aktif diabaikan, tanpa rantai = default dosen wali
+func TestMatchApprovalChain_NoMatch(t *testing.T) {
+	chain := service.MatchApprovalChain(testApprovalChains(), "publication", nil)
+
+	assert.Nil(t, chain)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/app/storage"
+	"go-fiber/helper"
+)
+
+// BlobService menyimpan isi file lampiran sekali per hash SHA-256 dengan
+// reference count di Postgres. Thumbnail/preview ikut dipakai bersama karena
+// key-nya diturunkan dari key blob.
+type BlobService struct {
+	repo    *repository.BlobRepository
+	storage storage.Storage
+}
+
+func NewBlobService(repo *repository.BlobRepository, fileStorage storage.Storage) *BlobService {
+	return &BlobService{repo: repo, storage: fileStorage}
+}
+
+// Store mencatat ref ke blob dengan hash sum dan menulis isi file ke storage
+// jika belum ada. Mengembalikan key storage blob, atau *model.QuotaExceededError
+// jika ref melebihi quota.
+func (s *BlobService) Store(ctx context.Context, file uploadFile, sum, contentType string, ref model.BlobRef, quota model.BlobQuota) (string, error) {
+	key, created, err := s.repo.Acquire(model.AttachmentBlob{
+		SHA256:      sum,
+		StorageKey:  helper.BlobKey(sum),
+		Size:        file.size,
+		ContentType: contentType,
+	}, ref, quota)
+	if err != nil {
+		return "", err
+	}
+
+	if !created {
+		// Baris blob ada tapi isinya bisa belum/tidak tersimpan (upload
+		// sebelumnya gagal di tengah atau hapus file gagal); tulis ulang
+		_, err := s.storage.Stat(ctx, key)
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			s.releaseRef(ctx, ref.ID)
+			return "", err
+		}
+	}
+
+	err = file.read(func(r io.Reader) error {
+		return s.storage.Put(ctx, key, r, file.size, contentType)
+	})
+	if err != nil {
+		s.releaseRef(ctx, ref.ID)
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *BlobService) releaseRef(ctx context.Context, refID string) {
+	if _, err := s.repo.Release(refID, func(key string) error { return s.deleteKeys(ctx, blobKeys(key)) }); err != nil {
+		log.Printf("failed to release blob ref %s: %v", refID, err)
+	}
+}
+
+// Release melepas file lampiran. Isi file (beserta thumbnail/preview) baru
+// dihapus saat tidak ada lampiran lain yang memakainya. Lampiran lama tanpa
+// blob langsung dihapus filenya. Aman dipanggil berulang.
+func (s *BlobService) Release(ctx context.Context, att model.Attachment) error {
+	if att.BlobRef == "" {
+		return s.deleteKeys(ctx, att.StorageKeys())
+	}
+	_, err := s.repo.Release(att.BlobRef, func(key string) error {
+		return s.deleteKeys(ctx, blobKeys(key))
+	})
+	return err
+}
+
+// InUse memeriksa apakah isi file dengan hash sum masih dipakai lampiran lain
+func (s *BlobService) InUse(sum string) (bool, error) {
+	return s.repo.InUse(sum)
+}
+
+// Usage pemakaian penyimpanan seorang mahasiswa: total dan per prestasi
+func (s *BlobService) Usage(studentID string) ([]model.AchievementStorageUsage, error) {
+	return s.repo.UsageByAchievement(studentID)
+}
+
+// UsedBytes pemakaian penyimpanan seorang mahasiswa dan satu prestasinya
+func (s *BlobService) UsedBytes(studentID, achievementRefID string) (int64, int64, error) {
+	return s.repo.UsedBytes(studentID, achievementRefID)
+}
+
+// blobKeys key isi file dan turunannya
+func blobKeys(key string) []string {
+	return []string{key, key + thumbnailSuffix, key + previewSuffix}
+}
+
+func (s *BlobService) deleteKeys(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) && !errors.Is(err, storage.ErrInvalidKey) {
+			return err
+		}
+	}
+	return nil
+}
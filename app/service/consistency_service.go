@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+
+	"github.com/google/uuid"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Jumlah saga yang diproses per putaran recovery
+const sagaRecoveryBatch = 100
+
+// ConsistencyService menjaga konsistensi reference di Postgres dengan dokumen
+// di Mongo. Setiap penulisan dua-store dicatat sebagai saga di Postgres sehingga
+// langkah yang terputus (crash, timeout) bisa diselesaikan setelah restart.
+type ConsistencyService struct {
+	sagaRepo       *repository.SagaRepository
+	postgresRepo   *repository.AchievementRepository
+	mongoRepo      *repository.MongoAchievementRepository
+	revisionRepo   *repository.MongoRevisionRepository
+	blobs          *BlobService
+	trashRetention time.Duration
+}
+
+func NewConsistencyService(
+	sagaRepo *repository.SagaRepository,
+	postgresRepo *repository.AchievementRepository,
+	mongoRepo *repository.MongoAchievementRepository,
+	revisionRepo *repository.MongoRevisionRepository,
+	blobs *BlobService,
+	trashRetention time.Duration,
+) *ConsistencyService {
+	return &ConsistencyService{
+		sagaRepo:       sagaRepo,
+		postgresRepo:   postgresRepo,
+		mongoRepo:      mongoRepo,
+		revisionRepo:   revisionRepo,
+		blobs:          blobs,
+		trashRetention: trashRetention,
+	}
+}
+
+// TrashRetention lama prestasi disimpan di trash sebelum dihapus permanen
+func (s *ConsistencyService) TrashRetention() time.Duration {
+	return s.trashRetention
+}
+
+// CreateAchievement menulis dokumen Mongo lalu reference Postgres.
+// Urutan: saga pending -> insert Mongo -> (insert reference + saga completed) dalam satu transaksi.
+// Jika gagal di tengah jalan, saga yang masih pending akan dikompensasi oleh recovery.
+// stages = tahap persetujuan untuk reference yang langsung diajukan (import).
+func (s *ConsistencyService) CreateAchievement(ctx context.Context, ach *model.Achievement, ref *model.AchievementReference, stages []model.AchievementApproval) error {
+	ach.ID = primitive.NewObjectID()
+	ref.MongoAchievementID = ach.ID.Hex()
+
+	saga := &model.AchievementSaga{
+		ID:                 uuid.New().String(),
+		Operation:          model.SagaCreate,
+		AchievementRefID:   ref.ID,
+		MongoAchievementID: ref.MongoAchievementID,
+		StudentID:          ref.StudentID,
+	}
+	if err := s.sagaRepo.Start(saga); err != nil {
+		return err
+	}
+
+	if _, err := s.mongoRepo.CreateAchievement(ctx, ach); err != nil {
+		s.recover(ctx, *saga)
+		return err
+	}
+
+	if err := s.sagaRepo.CompleteCreate(saga.ID, ref, stages); err != nil {
+		s.recover(ctx, *saga)
+		return err
+	}
+	return nil
+}
+
+// DeleteAchievement memindahkan prestasi ke trash: status reference -> deleted
+// (bersama saga, satu transaksi) lalu dokumen Mongo diberi deletedAt.
+// Kegagalan di Mongo tidak mengembalikan error: saga tetap pending dan dicoba
+// ulang oleh recovery.
+func (s *ConsistencyService) DeleteAchievement(ctx context.Context, ref *model.AchievementReference, change model.StatusChange) error {
+	return s.transition(ctx, model.SagaDelete, ref, change)
+}
+
+// RestoreAchievement mengembalikan prestasi dari trash (deleted -> draft)
+func (s *ConsistencyService) RestoreAchievement(ctx context.Context, ref *model.AchievementReference, change model.StatusChange) error {
+	return s.transition(ctx, model.SagaRestore, ref, change)
+}
+
+func (s *ConsistencyService) transition(ctx context.Context, operation string, ref *model.AchievementReference, change model.StatusChange) error {
+	saga := &model.AchievementSaga{
+		ID:                 uuid.New().String(),
+		Operation:          operation,
+		AchievementRefID:   ref.ID,
+		MongoAchievementID: ref.MongoAchievementID,
+		StudentID:          ref.StudentID,
+	}
+	if err := s.sagaRepo.StartTransition(change, saga); err != nil {
+		return err
+	}
+	ref.Status = change.To
+
+	s.recover(ctx, *saga)
+	return nil
+}
+
+// Purge menghapus permanen prestasi yang sudah berada di trash lebih lama dari
+// retention. Mengembalikan jumlah prestasi yang terhapus.
+func (s *ConsistencyService) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	before := time.Now().Add(-retention)
+	refs, _, err := s.postgresRepo.Search(model.ReferenceFilter{
+		Statuses:   []string{model.StatusDeleted},
+		DateColumn: "updated_at", // reference di trash tidak berubah lagi setelah dihapus
+		To:         &before,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, ref := range refs {
+		saga := &model.AchievementSaga{
+			ID:                 uuid.New().String(),
+			Operation:          model.SagaPurge,
+			AchievementRefID:   ref.ID,
+			MongoAchievementID: ref.MongoAchievementID,
+			StudentID:          ref.StudentID,
+		}
+		if err := s.sagaRepo.StartPurge(saga); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				log.Printf("purge %s: %v", ref.ID, err)
+			}
+			continue
+		}
+		s.recover(ctx, *saga)
+		purged++
+	}
+	return purged, nil
+}
+
+// RunPurge menjalankan Purge saat start lalu setiap interval
+func (s *ConsistencyService) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.Purge(ctx, s.trashRetention); err != nil {
+			log.Printf("trash purge failed: %v", err)
+		} else if n > 0 {
+			log.Printf("trash purge: %d achievement(s) permanently deleted", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recover menjalankan langkah saga yang tersisa dan mencatat hasilnya
+func (s *ConsistencyService) recover(ctx context.Context, saga model.AchievementSaga) bool {
+	state, err := s.resolve(ctx, saga)
+	if err != nil {
+		if recErr := s.sagaRepo.RecordFailure(saga.ID, err); recErr != nil {
+			log.Printf("saga %s: failed to record failure: %v", saga.ID, recErr)
+		}
+		log.Printf("saga %s (%s): %v", saga.ID, saga.Operation, err)
+		return false
+	}
+	if err := s.sagaRepo.Finish(saga.ID, state); err != nil {
+		log.Printf("saga %s: failed to finish: %v", saga.ID, err)
+		return false
+	}
+	return true
+}
+
+// resolve menentukan state akhir saga. Semua langkah idempotent sehingga aman
+// dijalankan ulang.
+func (s *ConsistencyService) resolve(ctx context.Context, saga model.AchievementSaga) (string, error) {
+	objID, err := primitive.ObjectIDFromHex(saga.MongoAchievementID)
+	if err != nil {
+		return "", err
+	}
+
+	switch saga.Operation {
+	case model.SagaCreate:
+		// Reference hanya ditulis bersama penanda completed; jika ternyata ada,
+		// saga sebenarnya sudah selesai
+		if _, err := s.postgresRepo.FindReferenceByID(saga.AchievementRefID); err == nil {
+			return model.SagaCompleted, nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return "", err
+		}
+		if err := s.mongoRepo.DeleteAchievement(ctx, objID); err != nil {
+			return "", err
+		}
+		return model.SagaCompensated, nil
+
+	case model.SagaDelete:
+		deletedAt := saga.CreatedAt
+		if err := s.mongoRepo.SetDeleted(ctx, objID, &deletedAt); err != nil {
+			return "", err
+		}
+		return model.SagaCompleted, nil
+
+	case model.SagaRestore:
+		if err := s.mongoRepo.SetDeleted(ctx, objID, nil); err != nil {
+			return "", err
+		}
+		return model.SagaCompleted, nil
+
+	case model.SagaPurge:
+		// File lampiran dihapus sebelum dokumennya agar key-nya masih diketahui
+		// jika langkah ini diulang oleh recovery
+		ach, err := s.mongoRepo.FindByID(ctx, objID)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return "", err
+		}
+		if ach != nil {
+			for _, att := range ach.Attachments {
+				// Isi file hanya dihapus jika tidak dipakai lampiran lain
+				if err := s.blobs.Release(ctx, att); err != nil {
+					return "", err
+				}
+			}
+		}
+		if err := s.mongoRepo.DeleteAchievement(ctx, objID); err != nil {
+			return "", err
+		}
+		if err := s.revisionRepo.DeleteByAchievementID(ctx, objID); err != nil {
+			return "", err
+		}
+		return model.SagaCompleted, nil
+	}
+
+	return "", errors.New("unknown saga operation " + saga.Operation)
+}
+
+// RecoverPending menyelesaikan saga pending yang lebih tua dari grace.
+// Mengembalikan jumlah saga yang berhasil diselesaikan.
+func (s *ConsistencyService) RecoverPending(ctx context.Context, grace time.Duration) (int, error) {
+	sagas, err := s.sagaRepo.FindPending(time.Now().Add(-grace), sagaRecoveryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	recovered := 0
+	for _, saga := range sagas {
+		if s.recover(ctx, saga) {
+			recovered++
+		}
+	}
+	return recovered, nil
+}
+
+// RunRecovery menjalankan RecoverPending saat start lalu setiap interval
+func (s *ConsistencyService) RunRecovery(ctx context.Context, interval, grace time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.RecoverPending(ctx, grace); err != nil {
+			log.Printf("saga recovery failed: %v", err)
+		} else if n > 0 {
+			log.Printf("saga recovery: %d saga(s) resolved", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile membandingkan seluruh reference dengan seluruh dokumen Mongo.
+// Dokumen/reference yang lebih baru dari grace dilewati karena mungkin masih
+// di tengah saga. Jika repair true, temuan diperbaiki:
+//   - dokumen tanpa reference dihapus
+//   - reference aktif tanpa dokumen diubah ke status deleted
+//   - deletedAt dokumen disamakan dengan status reference (trash atau bukan)
+//   - studentId dokumen disamakan dengan reference (Postgres sebagai acuan)
+func (s *ConsistencyService) Reconcile(ctx context.Context, grace time.Duration, repair bool) (*model.ReconcileReport, error) {
+	report := &model.ReconcileReport{Issues: []model.ReconcileIssue{}}
+
+	recovered, err := s.RecoverPending(ctx, grace)
+	if err != nil {
+		return nil, err
+	}
+	report.SagasRecovered = recovered
+
+	refs, _, err := s.postgresRepo.Search(model.ReferenceFilter{IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.mongoRepo.FindAllOwners(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.References = len(refs)
+	report.Documents = len(docs)
+
+	cutoff := time.Now().Add(-grace)
+	byMongoID := make(map[string]*model.AchievementReference, len(refs))
+	for i := range refs {
+		byMongoID[refs[i].MongoAchievementID] = &refs[i]
+	}
+
+	docIDs := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		docIDs[doc.ID.Hex()] = true
+		ref := byMongoID[doc.ID.Hex()]
+
+		switch {
+		case ref == nil:
+			if doc.CreatedAt.After(cutoff) {
+				continue
+			}
+			issue := model.ReconcileIssue{Kind: model.IssueOrphanDocument, MongoAchievementID: doc.ID.Hex(), MongoStudentID: doc.StudentID}
+			if repair {
+				setRepairResult(&issue, s.mongoRepo.DeleteAchievement(ctx, doc.ID))
+			}
+			report.Issues = append(report.Issues, issue)
+
+		case (ref.Status == model.StatusDeleted) != (doc.DeletedAt != nil):
+			if ref.UpdatedAt.After(cutoff) {
+				continue
+			}
+			issue := model.ReconcileIssue{
+				Kind:               model.IssueDeletedMismatch,
+				AchievementRefID:   ref.ID,
+				MongoAchievementID: doc.ID.Hex(),
+				StudentID:          ref.StudentID,
+			}
+			if repair {
+				var deletedAt *time.Time
+				if ref.Status == model.StatusDeleted {
+					deletedAt = &ref.UpdatedAt
+				}
+				setRepairResult(&issue, s.mongoRepo.SetDeleted(ctx, doc.ID, deletedAt))
+			}
+			report.Issues = append(report.Issues, issue)
+
+		case doc.StudentID != ref.StudentID:
+			issue := model.ReconcileIssue{
+				Kind:               model.IssueStudentMismatch,
+				AchievementRefID:   ref.ID,
+				MongoAchievementID: doc.ID.Hex(),
+				StudentID:          ref.StudentID,
+				MongoStudentID:     doc.StudentID,
+			}
+			if repair {
+				setRepairResult(&issue, s.mongoRepo.UpdateAchievement(ctx, doc.ID, bson.M{"studentId": ref.StudentID}))
+			}
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	for _, ref := range refs {
+		if ref.Status == model.StatusDeleted || docIDs[ref.MongoAchievementID] || ref.CreatedAt.After(cutoff) {
+			continue
+		}
+		issue := model.ReconcileIssue{
+			Kind:               model.IssueMissingDocument,
+			AchievementRefID:   ref.ID,
+			MongoAchievementID: ref.MongoAchievementID,
+			StudentID:          ref.StudentID,
+		}
+		if repair {
+			note := "reconcile: mongo document missing"
+			setRepairResult(&issue, s.postgresRepo.TransitionStatus(ref.ID, model.StatusChange{
+				From:          ref.Status,
+				To:            model.StatusDeleted,
+				ActorRole:     "System",
+				RejectionNote: &note,
+				Note:          &note,
+			}))
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	return report, nil
+}
+
+func setRepairResult(issue *model.ReconcileIssue, err error) {
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		issue.Error = err.Error()
+		return
+	}
+	issue.Repaired = true
+}
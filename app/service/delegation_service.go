@@ -0,0 +1,243 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/google/uuid"
+)
+
+// Panjang maksimum alasan delegasi
+const maxDelegationReasonLength = 500
+
+// DelegationService delegasi verifikasi sementara dari dosen wali (mis. cuti)
+// ke dosen lain. Selama aktif, delegasi bisa melihat, memverifikasi dan menolak
+// prestasi mahasiswa bimbingan dosen wali tersebut. Delegasi tidak berantai:
+// hanya mahasiswa bimbingan langsung dosen wali yang ikut didelegasikan.
+type DelegationService struct {
+	repo        *repository.DelegationRepository
+	userRepo    *repository.UserRepository
+	maxDuration time.Duration
+}
+
+func NewDelegationService(
+	repo *repository.DelegationRepository,
+	userRepo *repository.UserRepository,
+	maxDuration time.Duration,
+) *DelegationService {
+	return &DelegationService{
+		repo:        repo,
+		userRepo:    userRepo,
+		maxDuration: maxDuration,
+	}
+}
+
+// ActiveAdvisorIDs dosen wali yang saat ini mendelegasikan verifikasi ke delegateID
+func (s *DelegationService) ActiveAdvisorIDs(delegateID string) ([]string, error) {
+	return s.repo.ActiveAdvisorIDs(delegateID, time.Now())
+}
+
+// ActiveDelegateIDs dosen yang saat ini menerima delegasi dari advisorID
+func (s *DelegationService) ActiveDelegateIDs(advisorID string) ([]string, error) {
+	return s.repo.ActiveDelegateIDs(advisorID, time.Now())
+}
+
+// IsActive memeriksa apakah advisorID saat ini mendelegasikan verifikasi ke delegateID
+func (s *DelegationService) IsActive(advisorID, delegateID string) (bool, error) {
+	return s.repo.IsActive(advisorID, delegateID, time.Now())
+}
+
+// CreateDelegation godoc
+// @Summary Create advisor delegation
+// @Description Delegasikan verifikasi mahasiswa bimbingan ke dosen lain untuk rentang waktu tertentu.
+// @Description Dosen Wali mendelegasikan dirinya sendiri; Admin wajib mengisi advisorId.
+// @Tags Delegations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.CreateDelegationRequest true "Delegasi"
+// @Success 201 {object} model.APIResponse{data=model.AdvisorDelegation}
+// @Failure 400 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /delegations [post]
+func (s *DelegationService) Create(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	var req model.CreateDelegationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+
+	advisorID := req.AdvisorID
+	if claims.Role == "Dosen Wali" {
+		advisorID = claims.UserID
+	}
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+
+	var errs []model.ValidationError
+	if advisorID == "" {
+		errs = append(errs, model.ValidationError{Field: "advisorId", Message: "field is required"})
+	}
+	if req.DelegateID == "" {
+		errs = append(errs, model.ValidationError{Field: "delegateId", Message: "field is required"})
+	} else if req.DelegateID == advisorID {
+		errs = append(errs, model.ValidationError{Field: "delegateId", Message: "must be different from the advisor"})
+	}
+	switch {
+	case req.EndsAt.IsZero():
+		errs = append(errs, model.ValidationError{Field: "endsAt", Message: "field is required"})
+	case !req.EndsAt.After(startsAt):
+		errs = append(errs, model.ValidationError{Field: "endsAt", Message: "must be after startsAt"})
+	case !req.EndsAt.After(now):
+		errs = append(errs, model.ValidationError{Field: "endsAt", Message: "must be in the future"})
+	case req.EndsAt.Sub(startsAt) > s.maxDuration:
+		errs = append(errs, model.ValidationError{
+			Field:   "endsAt",
+			Message: "delegation can last at most " + strconv.Itoa(int(s.maxDuration/(24*time.Hour))) + " days",
+		})
+	}
+	if len(req.Reason) > maxDelegationReasonLength {
+		errs = append(errs, model.ValidationError{
+			Field:   "reason",
+			Message: "must be at most " + strconv.Itoa(maxDelegationReasonLength) + " characters",
+		})
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	// Kedua pihak harus dosen wali aktif
+	for _, check := range []struct{ field, userID string }{
+		{"advisorId", advisorID},
+		{"delegateId", req.DelegateID},
+	} {
+		if _, err := uuid.Parse(check.userID); err != nil {
+			errs = append(errs, model.ValidationError{Field: check.field, Message: "must be an active Dosen Wali user"})
+			continue
+		}
+		ok, err := s.userRepo.HasRole(check.userID, "Dosen Wali")
+		if err != nil {
+			return c.Status(500).JSON(model.ErrorResponse("failed to check user role", err.Error()))
+		}
+		if !ok {
+			errs = append(errs, model.ValidationError{Field: check.field, Message: "must be an active Dosen Wali user"})
+		}
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	delegation := &model.AdvisorDelegation{
+		AdvisorID:  advisorID,
+		DelegateID: req.DelegateID,
+		StartsAt:   startsAt,
+		EndsAt:     req.EndsAt,
+		Reason:     req.Reason,
+		CreatedBy:  claims.UserID,
+	}
+	if err := s.repo.Create(delegation); err != nil {
+		if errors.Is(err, repository.ErrDelegationOverlap) {
+			return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse(err.Error(), nil))
+		}
+		return c.Status(500).JSON(model.ErrorResponse("failed to create delegation", err.Error()))
+	}
+	delegation.Status = delegation.StatusAt(now)
+
+	return c.Status(fiber.StatusCreated).JSON(model.SuccessResponse(delegation))
+}
+
+// ListDelegations godoc
+// @Summary List advisor delegations
+// @Description Dosen Wali melihat delegasi yang ia berikan atau terima; Admin melihat semua
+// @Tags Delegations
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "scheduled | active | expired | revoked"
+// @Param userId query string false "Admin: filter delegasi yang melibatkan user ini"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Success 200 {object} model.APIResponse{data=model.PaginatedResponse}
+// @Failure 400 {object} model.APIResponse
+// @Router /delegations [get]
+func (s *DelegationService) List(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	page, limit := pageQuery(c)
+
+	status := c.Query("status")
+	switch status {
+	case "", model.DelegationScheduled, model.DelegationActive, model.DelegationExpired, model.DelegationRevoked:
+	default:
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", []model.ValidationError{
+			{Field: "status", Message: "must be one of scheduled, active, expired, revoked"},
+		}))
+	}
+
+	userID := c.Query("userId")
+	if claims.Role != "Admin" {
+		userID = claims.UserID
+	}
+
+	now := time.Now()
+	list, total, err := s.repo.Find(userID, status, now, limit, (page-1)*limit)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch delegations", err.Error()))
+	}
+	for i := range list {
+		list[i].Status = list[i].StatusAt(now)
+	}
+
+	return c.JSON(model.SuccessResponse(model.PaginatedResponse{
+		Items:      list,
+		Pagination: model.NewPagination(page, limit, total),
+	}))
+}
+
+// RevokeDelegation godoc
+// @Summary Revoke advisor delegation
+// @Description Mencabut delegasi yang belum berakhir; hanya dosen wali pemberi delegasi atau Admin
+// @Tags Delegations
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Delegation ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Failure 409 {object} model.APIResponse
+// @Router /delegations/{id} [delete]
+func (s *DelegationService) Revoke(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("delegation not found", nil))
+	}
+
+	delegation, err := s.repo.FindByID(id)
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("delegation not found", nil))
+	}
+	if claims.Role != "Admin" && delegation.AdvisorID != claims.UserID {
+		return c.Status(403).JSON(model.ErrorResponse("forbidden: only the delegating advisor or an admin can revoke", nil))
+	}
+
+	ok, err := s.repo.Revoke(id, claims.UserID, time.Now())
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to revoke delegation", err.Error()))
+	}
+	if !ok {
+		return c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("delegation already revoked or expired", fiber.Map{
+			"status": delegation.StatusAt(time.Now()),
+		}))
+	}
+
+	return c.JSON(model.SuccessResponse(fiber.Map{"message": "delegation revoked"}))
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ambang skor kemiripan: >= warn dikembalikan sebagai peringatan,
+// >= block dianggap duplikat (409)
+const (
+	DuplicateWarnScore  = 0.6
+	DuplicateBlockScore = 0.9
+)
+
+// Key details yang berisi nama kegiatan / tanggal, per jenis prestasi
+var (
+	duplicateEventKeys = []string{"competitionName", "publicationTitle", "organizationName", "certificationName", "activityName", "eventName"}
+	duplicateDateKeys  = []string{"eventDate", "publishedDate", "issuedDate", "periodStart", "date"}
+)
+
+// normalizeText: huruf kecil, tanda baca dibuang, spasi dirapikan
+func normalizeText(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// tokenSimilarity: Jaccard index kata-kata setelah normalisasi
+func tokenSimilarity(a, b string) float64 {
+	ta, tb := strings.Fields(normalizeText(a)), strings.Fields(normalizeText(b))
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	set := map[string]bool{}
+	for _, t := range ta {
+		set[t] = true
+	}
+	inter := 0
+	seen := map[string]bool{}
+	for _, t := range tb {
+		if set[t] && !seen[t] {
+			inter++
+		}
+		seen[t] = true
+	}
+	union := len(set) + len(seen) - inter
+	return float64(inter) / float64(union)
+}
+
+func firstDetail(details map[string]interface{}, keys []string) string {
+	for _, k := range keys {
+		if v, ok := helper.DetailString(details, k); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ScoreDuplicate menilai seberapa mungkin dua prestasi adalah laporan yang sama (0..1).
+// Lampiran dengan isi identik langsung bernilai 1. Selain itu skor dihitung dari
+// kemiripan judul (0.5), nama kegiatan (0.3) dan tanggal (0.2); jenis berbeda = 0.
+func ScoreDuplicate(a, b *model.Achievement) (float64, []string) {
+	hashes := map[string]bool{}
+	for _, att := range a.Attachments {
+		if att.SHA256 != "" {
+			hashes[att.SHA256] = true
+		}
+	}
+	for _, att := range b.Attachments {
+		if hashes[att.SHA256] {
+			return 1, []string{"identical attachment"}
+		}
+	}
+
+	if !strings.EqualFold(a.AchievementType, b.AchievementType) {
+		return 0, nil
+	}
+
+	var score float64
+	var reasons []string
+
+	if sim := tokenSimilarity(a.Title, b.Title); sim > 0 {
+		score += 0.5 * sim
+		if sim >= 0.8 {
+			reasons = append(reasons, "similar title")
+		}
+	}
+
+	eventA, eventB := firstDetail(a.Details, duplicateEventKeys), firstDetail(b.Details, duplicateEventKeys)
+	if sim := tokenSimilarity(eventA, eventB); sim > 0 {
+		score += 0.3 * sim
+		if sim >= 0.8 {
+			reasons = append(reasons, "same event")
+		}
+	}
+
+	dateA, dateB := firstDetail(a.Details, duplicateDateKeys), firstDetail(b.Details, duplicateDateKeys)
+	if dateA != "" && dateA == dateB {
+		score += 0.2
+		reasons = append(reasons, "same date")
+	}
+
+	return math.Round(score*100) / 100, reasons
+}
+
+// duplicateCandidate prestasi pembanding pada pemeriksaan duplikat. Row diisi
+// untuk baris import yang belum memiliki reference (dry run).
+type duplicateCandidate struct {
+	ref model.AchievementReference
+	ach *model.Achievement
+	row int
+}
+
+// duplicateCandidates memuat prestasi milik mahasiswa yang sama dan mahasiswa
+// lain dengan dosen wali yang sama, beserta ID mahasiswa yang dicakup
+func duplicateCandidates(
+	ctx context.Context,
+	studentRepo *repository.StudentRepository,
+	refRepo *repository.AchievementRepository,
+	mongoRepo *repository.MongoAchievementRepository,
+	studentID string,
+) ([]duplicateCandidate, []string, error) {
+	studentIDs := []string{studentID}
+	if student, err := studentRepo.FindByID(studentID); err == nil && student.AdvisorID != nil {
+		peers, err := studentRepo.FindByAdvisorID(*student.AdvisorID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range peers {
+			if p.ID != studentID {
+				studentIDs = append(studentIDs, p.ID)
+			}
+		}
+	}
+
+	refs, err := refRepo.FindByStudentIDs(studentIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	achievements, err := achievementsByMongoID(ctx, mongoRepo, refs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]duplicateCandidate, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Status == model.StatusDeleted {
+			continue
+		}
+		if other, ok := achievements[ref.MongoAchievementID]; ok {
+			candidates = append(candidates, duplicateCandidate{ref: ref, ach: other})
+		}
+	}
+	return candidates, studentIDs, nil
+}
+
+// matchDuplicates menilai ach terhadap setiap kandidat; hasil diurutkan dari
+// skor tertinggi. excludeRefID = reference ach sendiri.
+func matchDuplicates(ach *model.Achievement, candidates []duplicateCandidate, excludeRefID string) []model.DuplicateMatch {
+	matches := []model.DuplicateMatch{}
+	for _, cand := range candidates {
+		if cand.ref.ID != "" && cand.ref.ID == excludeRefID {
+			continue
+		}
+		score, reasons := ScoreDuplicate(ach, cand.ach)
+		if score < DuplicateWarnScore {
+			continue
+		}
+		matches = append(matches, model.DuplicateMatch{
+			ReferenceID:    cand.ref.ID,
+			AchievementID:  cand.ref.MongoAchievementID,
+			StudentID:      cand.ref.StudentID,
+			Title:          cand.ach.Title,
+			Status:         cand.ref.Status,
+			Score:          score,
+			HighConfidence: score >= DuplicateBlockScore,
+			Reasons:        reasons,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+// findDuplicates membandingkan ach dengan prestasi milik mahasiswa yang sama dan
+// mahasiswa lain dengan dosen wali yang sama. excludeRefID = reference ach sendiri.
+func (s *AchievementService) findDuplicates(ctx context.Context, studentID string, ach *model.Achievement, excludeRefID string) ([]model.DuplicateMatch, error) {
+	candidates, _, err := duplicateCandidates(ctx, s.studentRepo, s.postgresRepo, s.mongoRepo, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return matchDuplicates(ach, candidates, excludeRefID), nil
+}
+
+// duplicatesForStudent menyembunyikan ID, judul dan status prestasi milik
+// mahasiswa lain pada peringatan yang dikembalikan ke mahasiswa; yang tersisa
+// hanya skor dan alasannya
+func duplicatesForStudent(matches []model.DuplicateMatch, studentID string) []model.DuplicateMatch {
+	for i := range matches {
+		if matches[i].StudentID != studentID {
+			matches[i].ReferenceID = ""
+			matches[i].AchievementID = ""
+			matches[i].StudentID = ""
+			matches[i].Title = ""
+			matches[i].Status = ""
+		}
+	}
+	return matches
+}
+
+// checkDuplicates menjalankan pemeriksaan duplikat untuk Create/Submit.
+// Jika ada kecocokan high confidence, response 409 ditulis dan return kedua true.
+func (s *AchievementService) checkDuplicates(c *fiber.Ctx, studentID string, ach *model.Achievement, excludeRefID string) ([]model.DuplicateMatch, bool, error) {
+	matches, err := s.findDuplicates(context.Background(), studentID, ach, excludeRefID)
+	if err != nil {
+		return nil, true, c.Status(500).JSON(model.ErrorResponse("failed to check duplicates", err.Error()))
+	}
+	matches = duplicatesForStudent(matches, studentID)
+	if len(matches) > 0 && matches[0].HighConfidence {
+		return nil, true, c.Status(fiber.StatusConflict).JSON(model.ErrorResponse("possible duplicate achievement", matches))
+	}
+	return matches, false, nil
+}
+
+// PossibleDuplicates godoc
+// @Summary Possible duplicates
+// @Description Prestasi lain (milik mahasiswa yang sama atau mahasiswa bimbingan lain) yang mirip. Untuk mahasiswa, ID, judul dan status prestasi milik mahasiswa lain dikosongkan.
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Achievement Reference ID"
+// @Success 200 {object} model.APIResponse{data=[]model.DuplicateMatch}
+// @Failure 403 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/{id}/possible-duplicates [get]
+func (s *AchievementService) PossibleDuplicates(c *fiber.Ctx) error {
+	ref, err := s.postgresRepo.FindReferenceByID(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+	}
+	if err := s.checkAccess(c, ref); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+	}
+	ach, err := s.mongoRepo.FindByID(ctx, objID)
+	if err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+	}
+
+	matches, err := s.findDuplicates(ctx, ref.StudentID, ach, ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to check duplicates", err.Error()))
+	}
+	if claims := c.Locals("user").(*model.JWTClaims); claims.Role == "Mahasiswa" {
+		matches = duplicatesForStudent(matches, ref.StudentID)
+	}
+	return c.JSON(model.SuccessResponse(matches))
+}
@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+)
+
+// Test ScoreDuplicate - judul (beda format), kompetisi dan tanggal sama -> high confidence
+func TestScoreDuplicate_SameCompetition(t *testing.T) {
+	a := &model.Achievement{
+		AchievementType: "competition",
+		Title:           "Juara 1 GEMASTIK 2024",
+		Details:         map[string]interface{}{"competitionName": "GEMASTIK XVII", "eventDate": "2024-10-12"},
+	}
+	b := &model.Achievement{
+		AchievementType: "competition",
+		Title:           "juara 1 - gemastik 2024!",
+		Details:         map[string]interface{}{"competitionName": "Gemastik XVII", "eventDate": "2024-10-12"},
+	}
+
+	score, reasons := service.ScoreDuplicate(a, b)
+
+	assert.GreaterOrEqual(t, score, service.DuplicateBlockScore)
+	assert.Equal(t, []string{"similar title", "same event", "same date"}, reasons)
+}
+
+// Test ScoreDuplicate - lampiran identik selalu dianggap duplikat
+func TestScoreDuplicate_IdenticalAttachment(t *testing.T) {
+	a := &model.Achievement{AchievementType: "competition", Attachments: []model.Attachment{{SHA256: "abc"}}}
+	b := &model.Achievement{AchievementType: "certification", Attachments: []model.Attachment{{SHA256: ""}, {SHA256: "abc"}}}
+
+	score, reasons := service.ScoreDuplicate(a, b)
+
+	assert.Equal(t, 1.0, score)
+	assert.Equal(t, []string{"identical attachment"}, reasons)
+}
+
+// Test ScoreDuplicate - jenis berbeda atau judul berbeda tidak dianggap duplikat
+func TestScoreDuplicate_Different(t *testing.T) {
+	a := &model.Achievement{AchievementType: "competition", Title: "Juara 1 GEMASTIK", Attachments: []model.Attachment{{}}}
+	b := &model.Achievement{AchievementType: "certification", Title: "Juara 1 GEMASTIK", Attachments: []model.Attachment{{}}}
+
+	score, _ := service.ScoreDuplicate(a, b)
+	assert.Equal(t, 0.0, score)
+
+	c := &model.Achievement{AchievementType: "competition", Title: "Finalis Hackathon Kominfo"}
+	score, _ = service.ScoreDuplicate(a, c)
+	assert.Less(t, score, service.DuplicateWarnScore)
+}
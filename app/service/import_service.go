@@ -0,0 +1,674 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Field import yang wajib dipetakan ke kolom file
+var requiredImportFields = []string{
+	model.ImportFieldNIM,
+	model.ImportFieldAchievementType,
+	model.ImportFieldTitle,
+}
+
+var importFields = map[string]bool{
+	model.ImportFieldNIM:             true,
+	model.ImportFieldAchievementType: true,
+	model.ImportFieldTitle:           true,
+	model.ImportFieldDescription:     true,
+	model.ImportFieldTags:            true,
+	model.ImportFieldDate:            true,
+}
+
+// ImportService import prestasi historis secara massal dari CSV / XLSX oleh
+// admin (endpoint dan perintah CLI "import")
+type ImportService struct {
+	repo            *repository.ImportRepository
+	studentRepo     *repository.StudentRepository
+	typeRepo        *repository.AchievementTypeRepository
+	achievementRepo *repository.AchievementRepository
+	mongoRepo       *repository.MongoAchievementRepository
+	pointService    *PointService
+	approvals       *ApprovalService
+	consistency     *ConsistencyService
+	revisionRepo    *repository.MongoRevisionRepository
+	maxRows         int
+}
+
+func NewImportService(
+	repo *repository.ImportRepository,
+	studentRepo *repository.StudentRepository,
+	typeRepo *repository.AchievementTypeRepository,
+	achievementRepo *repository.AchievementRepository,
+	mongoRepo *repository.MongoAchievementRepository,
+	pointService *PointService,
+	approvals *ApprovalService,
+	consistency *ConsistencyService,
+	revisionRepo *repository.MongoRevisionRepository,
+	maxRows int,
+) *ImportService {
+	return &ImportService{
+		repo:            repo,
+		studentRepo:     studentRepo,
+		typeRepo:        typeRepo,
+		achievementRepo: achievementRepo,
+		mongoRepo:       mongoRepo,
+		pointService:    pointService,
+		approvals:       approvals,
+		consistency:     consistency,
+		revisionRepo:    revisionRepo,
+		maxRows:         maxRows,
+	}
+}
+
+// ResolveImportMapping mencocokkan mapping dengan header file (tanpa beda huruf
+// besar/kecil) dan mengembalikan mapping dengan judul kolom persis seperti di
+// file. Mapping kosong = kolom yang judulnya sama dengan nama field.
+func ResolveImportMapping(headers []string, mapping model.ImportMapping) (model.ImportMapping, []model.ValidationError) {
+	defaulted := len(mapping) == 0
+	if defaulted {
+		mapping = model.ImportMapping{}
+		for _, h := range headers {
+			mapping[h] = h
+		}
+	}
+
+	fields := make([]string, 0, len(mapping))
+	for field := range mapping {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var errs []model.ValidationError
+	resolved := model.ImportMapping{}
+	for _, field := range fields {
+		column := mapping[field]
+		name := field
+		if strings.HasPrefix(strings.ToLower(field), model.ImportDetailsPrefix) {
+			name = model.ImportDetailsPrefix + strings.TrimSpace(field[len(model.ImportDetailsPrefix):])
+			if name == model.ImportDetailsPrefix {
+				errs = append(errs, model.ValidationError{Field: "mapping." + field, Message: "details key is required"})
+				continue
+			}
+		} else if name = canonicalImportField(field); name == "" {
+			if defaulted {
+				// Mapping bawaan: kolom yang bukan field import diabaikan
+				continue
+			}
+			errs = append(errs, model.ValidationError{Field: "mapping." + field, Message: "unknown field"})
+			continue
+		}
+
+		header := ""
+		for _, h := range headers {
+			if strings.EqualFold(h, strings.TrimSpace(column)) {
+				header = h
+				break
+			}
+		}
+		if header == "" {
+			errs = append(errs, model.ValidationError{Field: "mapping." + field, Message: "column " + strconv.Quote(column) + " not found in file"})
+			continue
+		}
+		resolved[name] = header
+	}
+
+	for _, field := range requiredImportFields {
+		if _, ok := resolved[field]; !ok && !hasValidationField(errs, "mapping."+field) {
+			errs = append(errs, model.ValidationError{Field: "mapping." + field, Message: "field is required"})
+		}
+	}
+	return resolved, errs
+}
+
+func canonicalImportField(field string) string {
+	for name := range importFields {
+		if strings.EqualFold(name, strings.TrimSpace(field)) {
+			return name
+		}
+	}
+	return ""
+}
+
+func hasValidationField(errs []model.ValidationError, field string) bool {
+	for _, e := range errs {
+		if strings.EqualFold(e.Field, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// MapImportRows menyusun baris file menjadi ImportRow sesuai mapping hasil
+// ResolveImportMapping. Baris yang seluruh selnya kosong dilewati; nomor baris
+// mengikuti file (header = baris 1).
+func MapImportRows(headers []string, rows [][]string, mapping model.ImportMapping) []model.ImportRow {
+	index := map[string]int{}
+	for i, h := range headers {
+		if _, ok := index[h]; !ok {
+			index[h] = i
+		}
+	}
+
+	list := []model.ImportRow{}
+	for i, cells := range rows {
+		if blankRow(cells) {
+			continue
+		}
+		cell := func(field string) string {
+			column, ok := mapping[field]
+			if !ok {
+				return ""
+			}
+			if idx := index[column]; idx < len(cells) {
+				return strings.TrimSpace(cells[idx])
+			}
+			return ""
+		}
+
+		row := model.ImportRow{
+			Row:             i + 2,
+			NIM:             cell(model.ImportFieldNIM),
+			AchievementType: cell(model.ImportFieldAchievementType),
+			Title:           cell(model.ImportFieldTitle),
+			Description:     cell(model.ImportFieldDescription),
+			Tags:            splitTags(cell(model.ImportFieldTags)),
+			Date:            cell(model.ImportFieldDate),
+			Details:         map[string]string{},
+		}
+		for field := range mapping {
+			if strings.HasPrefix(field, model.ImportDetailsPrefix) {
+				row.Details[strings.TrimPrefix(field, model.ImportDetailsPrefix)] = cell(field)
+			}
+		}
+		list = append(list, row)
+	}
+	return list
+}
+
+func blankRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTags memecah sel tag yang dipisah koma atau titik koma
+func splitTags(value string) []string {
+	tags := []string{}
+	for _, t := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// validImportStatus status awal yang boleh dipakai untuk prestasi hasil import
+func validImportStatus(status string) bool {
+	switch status {
+	case model.StatusDraft, model.StatusSubmitted, model.StatusVerified:
+		return true
+	}
+	return false
+}
+
+// importRow baris yang lolos validasi dan siap dibuat
+type importRow struct {
+	model.ImportRow
+	student model.Student
+	details map[string]interface{}
+	date    *time.Time
+}
+
+// duplicatePool kandidat duplikat yang dimuat sekali per mahasiswa selama satu
+// import; baris yang sudah diimport ikut ditambahkan ke kandidat mahasiswa
+// yang mencakupnya sehingga baris ganda dalam file (atau import ulang file
+// yang sama) terdeteksi
+type duplicatePool struct {
+	studentIDs map[string]bool
+	candidates []duplicateCandidate
+}
+
+// importDuplicate mencari prestasi yang sama dengan baris: kecocokan high
+// confidence seperti saat Create, atau judul yang sama persis (setelah
+// normalisasi) untuk mahasiswa dan jenis yang sama
+func importDuplicate(ach *model.Achievement, candidates []duplicateCandidate) (duplicateCandidate, bool) {
+	title := normalizeText(ach.Title)
+	for _, cand := range candidates {
+		if cand.ref.StudentID == ach.StudentID &&
+			strings.EqualFold(cand.ach.AchievementType, ach.AchievementType) &&
+			normalizeText(cand.ach.Title) == title {
+			return cand, true
+		}
+	}
+	for _, cand := range candidates {
+		if score, _ := ScoreDuplicate(ach, cand.ach); score >= DuplicateBlockScore {
+			return cand, true
+		}
+	}
+	return duplicateCandidate{}, false
+}
+
+// duplicateMessage pesan error baris untuk duplikat yang ditemukan
+func duplicateMessage(cand duplicateCandidate) string {
+	if cand.ref.ID == "" {
+		return "duplicate of row " + strconv.Itoa(cand.row)
+	}
+	return "possible duplicate of achievement " + cand.ref.ID + " (" + cand.ref.Status + ")"
+}
+
+// Run membaca file, memvalidasi setiap baris dan (kecuali dry run) membuat
+// prestasi untuk baris yang valid. Baris yang gagal atau duplikat dari
+// prestasi yang sudah ada dicatat di Errors tanpa membatalkan baris lain. Error validasi dikembalikan untuk file atau opsi yang
+// tidak bisa diproses sama sekali; error ketiga untuk kegagalan database.
+func (s *ImportService) Run(ctx context.Context, r io.Reader, opts model.ImportOptions) (*model.AchievementImport, []model.ValidationError, error) {
+	if opts.Status == "" {
+		opts.Status = model.StatusVerified
+	}
+	if !validImportStatus(opts.Status) {
+		return nil, []model.ValidationError{{Field: "status", Message: "must be draft, submitted or verified"}}, nil
+	}
+
+	headers, cells, err := helper.ReadTable(opts.FileName, r, opts.Sheet)
+	if err != nil {
+		return nil, []model.ValidationError{{Field: "file", Message: err.Error()}}, nil
+	}
+	mapping, errs := ResolveImportMapping(headers, opts.Mapping)
+	if len(errs) > 0 {
+		return nil, errs, nil
+	}
+	rows := MapImportRows(headers, cells, mapping)
+	if len(rows) == 0 {
+		return nil, []model.ValidationError{{Field: "file", Message: "file has no data rows"}}, nil
+	}
+	if len(rows) > s.maxRows {
+		return nil, []model.ValidationError{{Field: "file", Message: "file must have at most " + strconv.Itoa(s.maxRows) + " rows"}}, nil
+	}
+
+	valid, rowErrs, err := s.validateRows(rows, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := s.pointService.ActiveRules()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imp := &model.AchievementImport{
+		ID:            uuid.New().String(),
+		FileName:      opts.FileName,
+		InitialStatus: opts.Status,
+		DryRun:        opts.DryRun,
+		TotalRows:     len(rows),
+		Errors:        rowErrs,
+		Rows:          []model.ImportRowResult{},
+	}
+	if opts.ActorID != "" {
+		imp.CreatedBy = &opts.ActorID
+	}
+
+	pools := map[string]*duplicatePool{}
+	for _, row := range valid {
+		pool, ok := pools[row.student.ID]
+		if !ok {
+			candidates, studentIDs, err := duplicateCandidates(ctx, s.studentRepo, s.achievementRepo, s.mongoRepo, row.student.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			pool = &duplicatePool{studentIDs: map[string]bool{}, candidates: candidates}
+			for _, id := range studentIDs {
+				pool.studentIDs[id] = true
+			}
+			pools[row.student.ID] = pool
+		}
+		ach := row.achievement()
+		if dup, ok := importDuplicate(ach, pool.candidates); ok {
+			imp.Errors = append(imp.Errors, model.ImportRowError{
+				Row:     row.Row,
+				NIM:     row.NIM,
+				Field:   model.ImportFieldTitle,
+				Column:  mapping[model.ImportFieldTitle],
+				Message: duplicateMessage(dup),
+			})
+			continue
+		}
+
+		result := model.ImportRowResult{
+			Row:             row.Row,
+			NIM:             row.NIM,
+			StudentID:       row.student.ID,
+			AchievementType: row.AchievementType,
+			Title:           row.Title,
+		}
+		if opts.Status == model.StatusVerified {
+			result.Points, _ = CalculatePoints(rules, row.AchievementType, row.details)
+		}
+		created := duplicateCandidate{ref: model.AchievementReference{StudentID: row.student.ID}, ach: ach, row: row.Row}
+		if !opts.DryRun {
+			ach.Points = result.Points
+			ref, err := s.create(ctx, imp, row, ach, opts)
+			if err != nil {
+				imp.Errors = append(imp.Errors, model.ImportRowError{
+					Row:     row.Row,
+					NIM:     row.NIM,
+					Message: "failed to import: " + err.Error(),
+				})
+				continue
+			}
+			result.ReferenceID = ref.ID
+			created.ref = *ref
+		}
+		imp.Rows = append(imp.Rows, result)
+
+		for _, p := range pools {
+			if p.studentIDs[row.student.ID] {
+				p.candidates = append(p.candidates, created)
+			}
+		}
+	}
+
+	imp.Imported = len(imp.Rows)
+	imp.Failed = imp.TotalRows - imp.Imported
+	if err := s.repo.Create(imp); err != nil {
+		// Prestasi sudah dibuat; hanya ringkasan (dan laporan error) yang hilang
+		log.Printf("import %s: failed to store import summary: %v", imp.ID, err)
+	}
+	return imp, nil, nil
+}
+
+// validateRows memeriksa setiap baris: mahasiswa berdasarkan NIM, judul, jenis
+// prestasi dan details terhadap skemanya, serta tanggal
+func (s *ImportService) validateRows(rows []model.ImportRow, mapping model.ImportMapping) ([]importRow, []model.ImportRowError, error) {
+	nims := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.NIM != "" {
+			nims = append(nims, row.NIM)
+		}
+	}
+	students, err := s.studentRepo.FindByStudentIDs(nims)
+	if err != nil {
+		return nil, nil, err
+	}
+	byNIM := map[string]model.Student{}
+	for _, st := range students {
+		byNIM[st.StudentID] = st
+	}
+
+	types, err := s.typeRepo.FindAll(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	byCode := map[string]model.AchievementType{}
+	for _, t := range types {
+		byCode[t.Code] = t
+	}
+
+	var valid []importRow
+	rowErrs := []model.ImportRowError{}
+	for _, row := range rows {
+		var errs []model.ValidationError
+		item := importRow{ImportRow: row}
+
+		if row.NIM == "" {
+			errs = append(errs, model.ValidationError{Field: model.ImportFieldNIM, Message: "field is required"})
+		} else if st, ok := byNIM[row.NIM]; !ok {
+			errs = append(errs, model.ValidationError{Field: model.ImportFieldNIM, Message: "student not found"})
+		} else {
+			item.student = st
+		}
+		if row.Title == "" {
+			errs = append(errs, model.ValidationError{Field: model.ImportFieldTitle, Message: "field is required"})
+		}
+		if row.AchievementType == "" {
+			errs = append(errs, model.ValidationError{Field: model.ImportFieldAchievementType, Message: "field is required"})
+		} else if t, ok := byCode[row.AchievementType]; !ok {
+			errs = append(errs, model.ValidationError{Field: model.ImportFieldAchievementType, Message: "unknown achievement type"})
+		} else {
+			item.details = helper.CoerceDetails(t.Schema, row.Details)
+			errs = append(errs, helper.ValidateAchievementDetails(t.Schema, item.details)...)
+		}
+		if row.Date != "" {
+			date, err := helper.ParseTableDate(row.Date)
+			if err != nil {
+				errs = append(errs, model.ValidationError{Field: model.ImportFieldDate, Message: err.Error()})
+			} else {
+				item.date = &date
+			}
+		}
+
+		if len(errs) == 0 {
+			valid = append(valid, item)
+			continue
+		}
+		for _, e := range errs {
+			rowErrs = append(rowErrs, model.ImportRowError{
+				Row:     row.Row,
+				NIM:     row.NIM,
+				Field:   e.Field,
+				Column:  mapping[e.Field],
+				Message: e.Message,
+			})
+		}
+	}
+	return valid, rowErrs, nil
+}
+
+// achievement dokumen prestasi untuk baris (revisi pertama, tanpa lampiran)
+func (row importRow) achievement() *model.Achievement {
+	return &model.Achievement{
+		StudentID:       row.student.ID,
+		AchievementType: row.AchievementType,
+		Title:           row.Title,
+		Description:     row.Description,
+		Details:         row.details,
+		Tags:            row.Tags,
+		Attachments:     []model.Attachment{},
+		CurrentRevision: 1,
+	}
+}
+
+// create membuat dokumen Mongo dan reference Postgres (lewat saga) untuk satu
+// baris. Tanggal prestasi menjadi created_at, dan untuk status verified juga
+// submitted_at / verified_at. Status submitted memakai waktu import sebagai
+// submitted_at agar SLA verifikasi dihitung sejak import, dan tahap
+// persetujuannya disusun seperti saat Submit.
+func (s *ImportService) create(ctx context.Context, imp *model.AchievementImport, row importRow, ach *model.Achievement, opts model.ImportOptions) (*model.AchievementReference, error) {
+	now := time.Now()
+	at := now
+	if row.date != nil {
+		at = *row.date
+	}
+
+	ref := &model.AchievementReference{
+		ID:        uuid.New().String(),
+		StudentID: row.student.ID,
+		Status:    opts.Status,
+		CreatedAt: at,
+		UpdatedAt: now,
+	}
+	var stages []model.AchievementApproval
+	switch opts.Status {
+	case model.StatusSubmitted:
+		ref.SubmittedAt = &now
+		var err error
+		if stages, err = s.approvals.BuildStages(ach, &row.student); err != nil {
+			return nil, err
+		}
+	case model.StatusVerified:
+		ref.SubmittedAt = &at
+		ref.VerifiedAt = &at
+		if opts.ActorID != "" {
+			ref.VerifiedBy = &opts.ActorID
+		}
+		ach.VerifiedRevision = &ach.CurrentRevision
+	}
+
+	if err := s.consistency.CreateAchievement(ctx, ach, ref, stages); err != nil {
+		return nil, err
+	}
+
+	// Riwayat dan revisi pertama; kegagalan di sini tidak membatalkan prestasi yang sudah tersimpan
+	rev := newRevision(nil, ach)
+	rev.EditedBy, rev.EditedByRole = opts.ActorID, opts.ActorRole
+	if err := s.revisionRepo.Create(ctx, rev); err != nil {
+		log.Printf("achievement %s: failed to store revision 1: %v", ref.MongoAchievementID, err)
+	}
+	if opts.Status != model.StatusDraft {
+		note := "imported from " + imp.FileName + " (import " + imp.ID + ")"
+		if err := s.repo.RecordHistory(ref.ID, opts.Status, opts.ActorID, opts.ActorRole, note, now); err != nil {
+			log.Printf("achievement %s: failed to record import history: %v", ref.ID, err)
+		}
+	}
+	return ref, nil
+}
+
+// WriteErrorReport menulis laporan baris yang gagal sebagai CSV. Sel berasal
+// dari file yang diunggah sehingga di-escape seperti export lainnya.
+func WriteErrorReport(w io.Writer, errs []model.ImportRowError) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"row", "nim", "field", "column", "message"})
+	for _, e := range errs {
+		out.Write([]string{
+			strconv.Itoa(e.Row),
+			helper.EscapeCSVCell(e.NIM),
+			helper.EscapeCSVCell(e.Field),
+			helper.EscapeCSVCell(e.Column),
+			helper.EscapeCSVCell(e.Message),
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ImportAchievements godoc
+// @Summary Import achievements
+// @Description Import prestasi historis dari CSV atau XLSX. Setiap baris dicocokkan ke mahasiswa berdasarkan NIM dan divalidasi terhadap jenis prestasinya; baris yang gagal atau duplikat dari prestasi yang sudah ada (termasuk import ulang file yang sama) dilewati dan dicatat di laporan error. Status submitted memakai tahap persetujuan seperti Submit.
+// @Description mapping: JSON field -> judul kolom, field: nim, achievementType, title, description, tags, date, details.<key>. Tanpa mapping, judul kolom harus sama dengan nama field.
+// @Tags Achievements
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File .csv atau .xlsx"
+// @Param mapping formData string false "Mapping kolom (JSON), mis. {\"nim\":\"NIM\",\"title\":\"Nama Lomba\",\"details.rank\":\"Juara\"}"
+// @Param sheet formData string false "Nama sheet XLSX (default sheet pertama)"
+// @Param status formData string false "Status awal: draft | submitted | verified (default verified)"
+// @Param dryRun formData bool false "Hanya validasi dan pratinjau, tanpa menyimpan prestasi"
+// @Success 200 {object} model.APIResponse{data=model.AchievementImport}
+// @Failure 400 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Router /achievements/import [post]
+func (s *ImportService) Import(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("file is required", err.Error()))
+	}
+	files := form.File["file"]
+	if len(files) == 0 {
+		return c.Status(400).JSON(model.ErrorResponse("file is required", nil))
+	}
+	value := func(key string) string {
+		if v := form.Value[key]; len(v) > 0 {
+			return strings.TrimSpace(v[0])
+		}
+		return ""
+	}
+
+	opts := model.ImportOptions{
+		FileName:  helper.SanitizeFilename(files[0].Filename),
+		Sheet:     value("sheet"),
+		Status:    value("status"),
+		ActorID:   claims.UserID,
+		ActorRole: claims.Role,
+	}
+	if raw := value("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &opts.Mapping); err != nil {
+			return c.Status(400).JSON(model.ErrorResponse("invalid mapping", err.Error()))
+		}
+	}
+	if raw := value("dryRun"); raw != "" {
+		if opts.DryRun, err = strconv.ParseBool(raw); err != nil {
+			return c.Status(400).JSON(model.ErrorResponse("invalid dryRun", err.Error()))
+		}
+	}
+
+	file, err := files[0].Open()
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to read file", err.Error()))
+	}
+	defer file.Close()
+
+	imp, errs, err := s.Run(context.Background(), file, opts)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("import failed", err.Error()))
+	}
+	if len(errs) > 0 {
+		return c.Status(400).JSON(model.ErrorResponse("validation failed", errs))
+	}
+	return c.JSON(model.SuccessResponse(imp))
+}
+
+// GetImport godoc
+// @Summary Get achievement import
+// @Description Ringkasan satu kali import beserta error per baris
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Import ID"
+// @Success 200 {object} model.APIResponse{data=model.AchievementImport}
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/imports/{id} [get]
+func (s *ImportService) Get(c *fiber.Ctx) error {
+	imp, err := s.repo.FindByID(c.Params("id"))
+	if errors.Is(err, repository.ErrImportNotFound) {
+		return c.Status(404).JSON(model.ErrorResponse("import not found", nil))
+	}
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch import", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(imp))
+}
+
+// ImportErrorReport godoc
+// @Summary Download import error report
+// @Description Laporan baris yang gagal diimpor (CSV: row, nim, field, column, message)
+// @Tags Achievements
+// @Security BearerAuth
+// @Produce text/csv
+// @Param id path string true "Import ID"
+// @Success 200 {file} file
+// @Failure 404 {object} model.APIResponse
+// @Router /achievements/imports/{id}/errors [get]
+func (s *ImportService) ErrorReport(c *fiber.Ctx) error {
+	imp, err := s.repo.FindByID(c.Params("id"))
+	if errors.Is(err, repository.ErrImportNotFound) {
+		return c.Status(404).JSON(model.ErrorResponse("import not found", nil))
+	}
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch import", err.Error()))
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="import-`+imp.ID+`-errors.csv"`)
+	return WriteErrorReport(c, imp.Errors)
+}
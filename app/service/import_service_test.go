@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+)
+
+// Test ResolveImportMapping - judul kolom dicocokkan tanpa beda huruf besar/kecil
+func TestResolveImportMapping_Success(t *testing.T) {
+	headers := []string{"NIM", "Nama Lomba", "Jenis", "Juara"}
+
+	mapping, errs := service.ResolveImportMapping(headers, model.ImportMapping{
+		"nim":             "nim",
+		"title":           "nama lomba",
+		"achievementType": "Jenis",
+		"details.rank":    "Juara",
+	})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, model.ImportMapping{
+		"nim":             "NIM",
+		"title":           "Nama Lomba",
+		"achievementType": "Jenis",
+		"details.rank":    "Juara",
+	}, mapping)
+}
+
+// Test ResolveImportMapping - field tidak dikenal, kolom tidak ada dan field wajib belum dipetakan
+func TestResolveImportMapping_Invalid(t *testing.T) {
+	_, errs := service.ResolveImportMapping([]string{"NIM", "Judul"}, model.ImportMapping{
+		"nim":      "NIM",
+		"title":    "Nama Lomba",
+		"semester": "Judul",
+	})
+
+	assert.Equal(t, []model.ValidationError{
+		{Field: "mapping.semester", Message: "unknown field"},
+		{Field: "mapping.title", Message: `column "Nama Lomba" not found in file`},
+		{Field: "mapping.achievementType", Message: "field is required"},
+	}, errs)
+}
+
+// Test ResolveImportMapping - tanpa mapping, kolom yang bukan field import diabaikan
+func TestResolveImportMapping_Default(t *testing.T) {
+	mapping, errs := service.ResolveImportMapping(
+		[]string{"nim", "title", "achievementType", "details.rank", "Catatan"}, nil,
+	)
+
+	assert.Empty(t, errs)
+	assert.Len(t, mapping, 4)
+	assert.Equal(t, "details.rank", mapping["details.rank"])
+}
+
+// Test MapImportRows - baris kosong dilewati, nomor baris mengikuti file
+func TestMapImportRows(t *testing.T) {
+	headers := []string{"NIM", "Judul", "Jenis", "Tag", "Juara"}
+	mapping := model.ImportMapping{
+		"nim":             "NIM",
+		"title":           "Judul",
+		"achievementType": "Jenis",
+		"tags":            "Tag",
+		"details.rank":    "Juara",
+	}
+
+	rows := service.MapImportRows(headers, [][]string{
+		{"2201", " Gemastik ", "competition", "it; nasional", "1"},
+		{"", "", ""},
+		{"2202", "Debat", "competition"}, // sel terakhir kosong tidak ikut terbaca
+	}, mapping)
+
+	assert.Equal(t, []model.ImportRow{
+		{
+			Row: 2, NIM: "2201", Title: "Gemastik", AchievementType: "competition",
+			Tags: []string{"it", "nasional"}, Details: map[string]string{"rank": "1"},
+		},
+		{
+			Row: 4, NIM: "2202", Title: "Debat", AchievementType: "competition",
+			Tags: []string{}, Details: map[string]string{"rank": ""},
+		},
+	}, rows)
+}
+
+// Test WriteErrorReport - sel dari file yang diunggah tidak dieksekusi sebagai formula
+func TestWriteErrorReport_EscapesFormula(t *testing.T) {
+	var buf bytes.Buffer
+	err := service.WriteErrorReport(&buf, []model.ImportRowError{
+		{Row: 3, NIM: "=HYPERLINK(\"http://x\")", Field: "nim", Column: "@NIM", Message: "student not found"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "row,nim,field,column,message\n"+
+		"3,\"'=HYPERLINK(\"\"http://x\"\")\",nim,'@NIM,student not found\n", buf.String())
+}
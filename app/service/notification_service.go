@@ -0,0 +1,89 @@
+package service
+
+import (
+	"log"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/google/uuid"
+)
+
+// NotificationService notifikasi in-app (mis. pengingat SLA verifikasi)
+type NotificationService struct {
+	repo *repository.NotificationRepository
+}
+
+func NewNotificationService(repo *repository.NotificationRepository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+// Notify mengirim notifikasi yang sama ke beberapa user. Kegagalan per user
+// dicatat di log; error terakhir dikembalikan.
+func (s *NotificationService) Notify(userIDs []string, n model.Notification) error {
+	var lastErr error
+	for _, userID := range userIDs {
+		item := n
+		item.ID = ""
+		item.UserID = userID
+		if err := s.repo.Create(&item); err != nil {
+			log.Printf("failed to notify user %s (%s): %v", userID, n.Type, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// ListNotifications godoc
+// @Summary List my notifications
+// @Description Notifikasi user yang login, terbaru lebih dulu
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Param unread query bool false "Hanya yang belum dibaca"
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 20, max 100)"
+// @Success 200 {object} model.APIResponse{data=model.PaginatedResponse}
+// @Failure 401 {object} model.APIResponse
+// @Router /notifications [get]
+func (s *NotificationService) List(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	page, limit := pageQuery(c)
+
+	list, total, err := s.repo.FindByUserID(claims.UserID, c.QueryBool("unread"), limit, (page-1)*limit)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch notifications", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(model.PaginatedResponse{
+		Items:      list,
+		Pagination: model.NewPagination(page, limit, total),
+	}))
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Tags Notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /notifications/{id}/read [post]
+func (s *NotificationService) MarkRead(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	id := c.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("notification not found", nil))
+	}
+
+	ok, err := s.repo.MarkRead(id, claims.UserID)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to update notification", err.Error()))
+	}
+	if !ok {
+		return c.Status(404).JSON(model.ErrorResponse("notification not found", nil))
+	}
+	return c.JSON(model.SuccessResponse(fiber.Map{"message": "notification marked as read"}))
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Key details yang dibaca oleh scoring engine
+const (
+	DetailCompetitionLevel = "competitionLevel"
+	DetailRank             = "rank"
+	DetailIsTeam           = "isTeam"
+)
+
+type PointService struct {
+	pointRepo    *repository.PointRuleRepository
+	postgresRepo *repository.AchievementRepository
+	mongoRepo    *repository.MongoAchievementRepository
+}
+
+func NewPointService(
+	pointRepo *repository.PointRuleRepository,
+	postgresRepo *repository.AchievementRepository,
+	mongoRepo *repository.MongoAchievementRepository,
+) *PointService {
+	return &PointService{
+		pointRepo:    pointRepo,
+		postgresRepo: postgresRepo,
+		mongoRepo:    mongoRepo,
+	}
+}
+
+// CalculatePoints memilih rule aktif yang cocok dengan achievement. Jika lebih
+// dari satu rule cocok, dipakai yang paling spesifik (paling sedikit wildcard),
+// lalu yang poinnya paling besar. Tanpa rule yang cocok hasilnya 0.
+func CalculatePoints(rules []model.PointRule, achievementType string, details map[string]interface{}) (int, *model.PointRule) {
+	level, hasLevel := helper.DetailString(details, DetailCompetitionLevel)
+	rank, hasRank := helper.DetailInt(details, DetailRank)
+	isTeam, hasTeam := helper.DetailBool(details, DetailIsTeam)
+
+	var best *model.PointRule
+	bestScore := -1
+
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.IsActive {
+			continue
+		}
+
+		score := 0
+		if rule.AchievementType != nil {
+			if !strings.EqualFold(*rule.AchievementType, achievementType) {
+				continue
+			}
+			score++
+		}
+		if rule.CompetitionLevel != nil {
+			if !hasLevel || !strings.EqualFold(*rule.CompetitionLevel, level) {
+				continue
+			}
+			score++
+		}
+		if rule.Rank != nil {
+			if !hasRank || *rule.Rank != rank {
+				continue
+			}
+			score++
+		}
+		if rule.IsTeam != nil {
+			if !hasTeam || *rule.IsTeam != isTeam {
+				continue
+			}
+			score++
+		}
+
+		if score > bestScore || (score == bestScore && rule.Points > best.Points) {
+			best = rule
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return 0, nil
+	}
+	return best.Points, best
+}
+
+// ActiveRules mengambil rule aktif; dipakai saat menghitung banyak prestasi sekaligus
+func (s *PointService) ActiveRules() ([]model.PointRule, error) {
+	return s.pointRepo.FindActive()
+}
+
+// Calculate menghitung poin achievement berdasarkan rule aktif di database
+func (s *PointService) Calculate(ach *model.Achievement) (int, *model.PointRule, error) {
+	rules, err := s.pointRepo.FindActive()
+	if err != nil {
+		return 0, nil, err
+	}
+	points, rule := CalculatePoints(rules, ach.AchievementType, ach.Details)
+	return points, rule, nil
+}
+
+// ListPointRules godoc
+// @Summary List point rules
+// @Description Daftar aturan poin prestasi
+// @Tags Point Rules
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} model.APIResponse{data=[]model.PointRule}
+// @Failure 403 {object} model.APIResponse
+// @Router /point-rules [get]
+func (s *PointService) List(c *fiber.Ctx) error {
+	rules, err := s.pointRepo.FindAll()
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to fetch point rules", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse(rules))
+}
+
+// CreatePointRule godoc
+// @Summary Create point rule
+// @Description Membuat aturan poin baru (Admin)
+// @Tags Point Rules
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.PointRuleRequest true "Point rule"
+// @Success 201 {object} model.APIResponse{data=model.PointRule}
+// @Failure 400 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Router /point-rules [post]
+func (s *PointService) Create(c *fiber.Ctx) error {
+	var req model.PointRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	if req.Points < 0 {
+		return c.Status(400).JSON(model.ErrorResponse("points must be >= 0", nil))
+	}
+	if req.TeamPolicy != nil && *req.TeamPolicy != model.TeamPointsDuplicate && *req.TeamPolicy != model.TeamPointsSplit {
+		return c.Status(400).JSON(model.ErrorResponse("teamPolicy must be duplicate or split", nil))
+	}
+
+	rule, err := s.pointRepo.Create(&req)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to create point rule", err.Error()))
+	}
+	return c.Status(201).JSON(model.SuccessResponse(rule))
+}
+
+// UpdatePointRule godoc
+// @Summary Update point rule
+// @Description Mengubah aturan poin (Admin)
+// @Tags Point Rules
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Point Rule ID"
+// @Param body body model.PointRuleRequest true "Point rule"
+// @Success 200 {object} model.APIResponse
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /point-rules/{id} [put]
+func (s *PointService) Update(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req model.PointRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+	if req.Points < 0 {
+		return c.Status(400).JSON(model.ErrorResponse("points must be >= 0", nil))
+	}
+	if req.TeamPolicy != nil && *req.TeamPolicy != model.TeamPointsDuplicate && *req.TeamPolicy != model.TeamPointsSplit {
+		return c.Status(400).JSON(model.ErrorResponse("teamPolicy must be duplicate or split", nil))
+	}
+
+	if _, err := s.pointRepo.FindByID(id); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("point rule not found", nil))
+	}
+	if err := s.pointRepo.Update(id, &req); err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to update point rule", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse("point rule updated"))
+}
+
+// DeletePointRule godoc
+// @Summary Delete point rule
+// @Description Menghapus aturan poin (Admin)
+// @Tags Point Rules
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Point Rule ID"
+// @Success 200 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /point-rules/{id} [delete]
+func (s *PointService) Delete(c *fiber.Ctx) error {
+	if err := s.pointRepo.Delete(c.Params("id")); err != nil {
+		return c.Status(404).JSON(model.ErrorResponse("point rule not found", err.Error()))
+	}
+	return c.JSON(model.SuccessResponse("point rule deleted"))
+}
+
+// DryRunPoints godoc
+// @Summary Dry-run point calculation
+// @Description Simulasi poin yang akan didapat sebuah prestasi tanpa menyimpan apa pun
+// @Tags Point Rules
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body model.PointDryRunRequest true "Achievement reference ID atau type + details"
+// @Success 200 {object} model.APIResponse{data=model.PointDryRunResponse}
+// @Failure 400 {object} model.APIResponse
+// @Failure 404 {object} model.APIResponse
+// @Router /point-rules/dry-run [post]
+func (s *PointService) DryRun(c *fiber.Ctx) error {
+	var req model.PointDryRunRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(model.ErrorResponse("invalid body", err.Error()))
+	}
+
+	ach := &model.Achievement{
+		AchievementType: req.AchievementType,
+		Details:         req.Details,
+	}
+
+	if req.ReferenceID != "" {
+		ref, err := s.postgresRepo.FindReferenceByID(req.ReferenceID)
+		if err != nil {
+			return c.Status(404).JSON(model.ErrorResponse("reference not found", nil))
+		}
+		objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+		if err != nil {
+			return c.Status(400).JSON(model.ErrorResponse("invalid mongo id", nil))
+		}
+		ach, err = s.mongoRepo.FindByID(context.Background(), objID)
+		if err != nil {
+			return c.Status(404).JSON(model.ErrorResponse("achievement not found in mongo", nil))
+		}
+	} else if req.AchievementType == "" {
+		return c.Status(400).JSON(model.ErrorResponse("referenceId or achievementType is required", nil))
+	}
+
+	points, rule, err := s.Calculate(ach)
+	if err != nil {
+		return c.Status(500).JSON(model.ErrorResponse("failed to calculate points", err.Error()))
+	}
+
+	return c.JSON(model.SuccessResponse(model.PointDryRunResponse{
+		Points:      points,
+		MatchedRule: rule,
+	}))
+}
@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+)
+
+func strPtr(v string) *string { return &v }
+func intPtr(v int) *int       { return &v }
+func boolPtr(v bool) *bool    { return &v }
+
+func testPointRules() []model.PointRule {
+	return []model.PointRule{
+		{ID: "base", Points: 5, IsActive: true},
+		{ID: "national", AchievementType: strPtr("competition"), CompetitionLevel: strPtr("national"), Points: 30, IsActive: true},
+		{ID: "national-1", AchievementType: strPtr("competition"), CompetitionLevel: strPtr("national"), Rank: intPtr(1), Points: 60, IsActive: true},
+		{ID: "national-1-team", AchievementType: strPtr("competition"), CompetitionLevel: strPtr("national"), Rank: intPtr(1), IsTeam: boolPtr(true), Points: 40, IsActive: true},
+		{ID: "inactive", AchievementType: strPtr("competition"), Points: 999, IsActive: false},
+	}
+}
+
+// Test CalculatePoints - rule paling spesifik menang
+func TestCalculatePoints_MostSpecificRuleWins(t *testing.T) {
+	points, rule := service.CalculatePoints(testPointRules(), "competition", map[string]interface{}{
+		"competitionLevel": "National",
+		"rank":             float64(1),
+		"isTeam":           true,
+	})
+
+	assert.Equal(t, 40, points)
+	assert.Equal(t, "national-1-team", rule.ID)
+}
+
+// Test CalculatePoints - field details yang tidak ada tidak cocok dengan rule spesifik
+func TestCalculatePoints_MissingDetailFallsBack(t *testing.T) {
+	points, rule := service.CalculatePoints(testPointRules(), "competition", map[string]interface{}{
+		"competitionLevel": "national",
+	})
+
+	assert.Equal(t, 30, points)
+	assert.Equal(t, "national", rule.ID)
+}
+
+// Test CalculatePoints - rule wildcard untuk tipe lain, rule non-aktif diabaikan
+func TestCalculatePoints_WildcardAndInactive(t *testing.T) {
+	points, rule := service.CalculatePoints(testPointRules(), "publication", nil)
+
+	assert.Equal(t, 5, points)
+	assert.Equal(t, "base", rule.ID)
+}
+
+// Test CalculatePoints - tanpa rule
+func TestCalculatePoints_NoRules(t *testing.T) {
+	points, rule := service.CalculatePoints(nil, "competition", nil)
+
+	assert.Equal(t, 0, points)
+	assert.Nil(t, rule)
+}
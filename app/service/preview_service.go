@@ -0,0 +1,231 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/app/storage"
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// Sisi terpanjang thumbnail dan preview PDF (px)
+	thumbnailSize = 320
+	previewSize   = 1024
+	// Batas waktu pembuatan preview satu lampiran
+	previewTimeout = time.Minute
+
+	// Akhiran key storage thumbnail dan preview terhadap key file aslinya
+	thumbnailSuffix = ".thumb.jpg"
+	previewSuffix   = ".preview.png"
+)
+
+type previewJob struct {
+	achievementID primitive.ObjectID
+	attachment    model.Attachment
+}
+
+// PreviewService membuat thumbnail gambar dan preview halaman pertama PDF di
+// background, lalu menyimpannya di storage di samping file aslinya
+type PreviewService struct {
+	storage   storage.Storage
+	mongoRepo *repository.MongoAchievementRepository
+	blobs     *BlobService
+	// path pdftoppm; kosong = PDF tidak dibuatkan preview
+	pdfRenderer string
+	jobs        chan previewJob
+}
+
+func NewPreviewService(
+	fileStorage storage.Storage,
+	mongoRepo *repository.MongoAchievementRepository,
+	blobs *BlobService,
+	pdfRenderer string,
+	queueSize int,
+) *PreviewService {
+	return &PreviewService{
+		storage:     fileStorage,
+		mongoRepo:   mongoRepo,
+		blobs:       blobs,
+		pdfRenderer: pdfRenderer,
+		jobs:        make(chan previewJob, queueSize),
+	}
+}
+
+// Enqueue menjadwalkan pembuatan preview tanpa menunggu. Jika antrean penuh,
+// lampiran tetap berstatus pending.
+func (s *PreviewService) Enqueue(achievementID primitive.ObjectID, att model.Attachment) {
+	select {
+	case s.jobs <- previewJob{achievementID: achievementID, attachment: att}:
+	default:
+		log.Printf("preview queue full, skipping attachment %s", att.ID)
+	}
+}
+
+// Run menjalankan sejumlah worker sampai ctx selesai
+func (s *PreviewService) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.jobs:
+					s.process(ctx, job)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *PreviewService) process(ctx context.Context, job previewJob) {
+	att := job.attachment
+	if err := s.Generate(ctx, &att); err != nil {
+		log.Printf("preview for attachment %s failed: %v", att.ID, err)
+	}
+
+	if err := s.mongoRepo.SetAttachmentPreview(ctx, job.achievementID, att); err != nil {
+		// Lampiran sudah diganti atau dihapus; hasilnya tidak terpakai kecuali
+		// isi file yang sama masih dipakai lampiran lain
+		if !s.shared(att) {
+			s.deleteKeys(ctx, att.ThumbnailURL, att.PreviewURL)
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("failed to save preview for attachment %s: %v", att.ID, err)
+		}
+	}
+}
+
+// shared: isi file lampiran masih dipakai lampiran lain (blob yang sama)
+func (s *PreviewService) shared(att model.Attachment) bool {
+	if att.BlobRef == "" || s.blobs == nil {
+		return false
+	}
+	inUse, err := s.blobs.InUse(att.SHA256)
+	// Jika tidak bisa dipastikan, file dibiarkan
+	return err != nil || inUse
+}
+
+// Generate membuat thumbnail (dan preview untuk PDF) lalu mengisi field
+// lampiran. PreviewStatus selalu diisi, termasuk saat gagal. Thumbnail/preview
+// yang sudah ada (file yang sama diupload ulang) dipakai kembali.
+func (s *PreviewService) Generate(ctx context.Context, att *model.Attachment) error {
+	ctx, cancel := context.WithTimeout(ctx, previewTimeout)
+	defer cancel()
+
+	if s.reuse(ctx, att) {
+		return nil
+	}
+
+	var thumb, page []byte
+	var err error
+	switch att.FileType {
+	case "image/jpeg", "image/png":
+		thumb, err = s.imageThumbnail(ctx, att.FileURL)
+	case "application/pdf":
+		if s.pdfRenderer == "" {
+			att.PreviewStatus = model.PreviewUnsupported
+			return nil
+		}
+		page, thumb, err = s.pdfPreview(ctx, att.FileURL)
+	default:
+		att.PreviewStatus = model.PreviewUnsupported
+		return nil
+	}
+	if err != nil {
+		att.PreviewStatus = model.PreviewFailed
+		return err
+	}
+
+	thumbKey := att.FileURL + thumbnailSuffix
+	if err := s.storage.Put(ctx, thumbKey, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err != nil {
+		att.PreviewStatus = model.PreviewFailed
+		return err
+	}
+	if page != nil {
+		pageKey := att.FileURL + previewSuffix
+		if err := s.storage.Put(ctx, pageKey, bytes.NewReader(page), int64(len(page)), "image/png"); err != nil {
+			s.deleteKeys(ctx, thumbKey)
+			att.PreviewStatus = model.PreviewFailed
+			return err
+		}
+		att.PreviewURL = pageKey
+	}
+	att.ThumbnailURL = thumbKey
+	att.PreviewStatus = model.PreviewReady
+	return nil
+}
+
+// reuse mengisi field lampiran dari thumbnail/preview yang sudah ada di storage
+func (s *PreviewService) reuse(ctx context.Context, att *model.Attachment) bool {
+	keys := []string{att.FileURL + thumbnailSuffix}
+	switch att.FileType {
+	case "image/jpeg", "image/png":
+	case "application/pdf":
+		keys = append(keys, att.FileURL+previewSuffix)
+	default:
+		return false
+	}
+	for _, key := range keys {
+		if _, err := s.storage.Stat(ctx, key); err != nil {
+			return false
+		}
+	}
+	att.ThumbnailURL = keys[0]
+	if len(keys) > 1 {
+		att.PreviewURL = keys[1]
+	}
+	att.PreviewStatus = model.PreviewReady
+	return true
+}
+
+func (s *PreviewService) imageThumbnail(ctx context.Context, key string) ([]byte, error) {
+	body, _, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return helper.ImageThumbnail(body, thumbnailSize)
+}
+
+func (s *PreviewService) pdfPreview(ctx context.Context, key string) ([]byte, []byte, error) {
+	body, _, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer body.Close()
+
+	page, err := helper.RenderPDFPreview(ctx, s.pdfRenderer, body, previewSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	thumb, err := helper.ThumbnailFromPNG(page, thumbnailSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return page, thumb, nil
+}
+
+func (s *PreviewService) deleteKeys(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("failed to remove preview file %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+	"go-fiber/app/storage"
+)
+
+// Test Generate - gambar PNG dibuatkan thumbnail di samping file aslinya
+func TestPreviewGenerate_Image(t *testing.T) {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 800, 400))))
+	assert.NoError(t, fileStorage.Put(ctx, "achievements/a/foto.png", bytes.NewReader(buf.Bytes()), int64(buf.Len()), "image/png"))
+
+	previews := service.NewPreviewService(fileStorage, nil, nil, "", 1)
+	att := model.Attachment{FileURL: "achievements/a/foto.png", FileType: "image/png"}
+
+	assert.NoError(t, previews.Generate(ctx, &att))
+	assert.Equal(t, model.PreviewReady, att.PreviewStatus)
+	assert.Equal(t, "achievements/a/foto.png.thumb.jpg", att.ThumbnailURL)
+	assert.Empty(t, att.PreviewURL)
+
+	info, err := fileStorage.Stat(ctx, att.ThumbnailURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", info.ContentType)
+}
+
+// Test Generate - PDF tanpa renderer ditandai unsupported, tidak dianggap gagal
+func TestPreviewGenerate_PDFWithoutRenderer(t *testing.T) {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	previews := service.NewPreviewService(fileStorage, nil, nil, "", 1)
+	att := model.Attachment{FileURL: "achievements/a/sertifikat.pdf", FileType: "application/pdf"}
+
+	assert.NoError(t, previews.Generate(context.Background(), &att))
+	assert.Equal(t, model.PreviewUnsupported, att.PreviewStatus)
+	assert.Empty(t, att.ThumbnailURL)
+}
+
+// Test Generate - file asli tidak ada di storage
+func TestPreviewGenerate_MissingFile(t *testing.T) {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	previews := service.NewPreviewService(fileStorage, nil, nil, "", 1)
+	att := model.Attachment{FileURL: "achievements/a/hilang.jpg", FileType: "image/jpeg"}
+
+	assert.ErrorIs(t, previews.Generate(context.Background(), &att), storage.ErrNotFound)
+	assert.Equal(t, model.PreviewFailed, att.PreviewStatus)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"sort"
+
+	"go-fiber/app/model"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statisticsSection satu tabel statistik dua kolom (key, nilai)
+type statisticsSection struct {
+	Name    string
+	Headers []string
+	Rows    [][]interface{}
+}
+
+// sections statistik sebagai tabel ekspor. Mahasiswa ditampilkan dengan NIM
+// (student id jika NIM tidak ditemukan).
+func (st *statistics) sections(nims map[string]string) []statisticsSection {
+	nim := func(studentID string) string {
+		if n, ok := nims[studentID]; ok {
+			return n
+		}
+		return studentID
+	}
+
+	topByPoints := make([][]interface{}, 0, len(st.TopByPoints))
+	for _, p := range st.TopByPoints {
+		topByPoints = append(topByPoints, []interface{}{nim(p.StudentID), p.Points})
+	}
+
+	topByCount := sortedCounts(st.TopByCount)
+	sort.SliceStable(topByCount, func(i, j int) bool {
+		return topByCount[i][1].(int) > topByCount[j][1].(int)
+	})
+	for _, row := range topByCount {
+		row[0] = nim(row[0].(string))
+	}
+
+	return []statisticsSection{
+		{Name: "By Status", Headers: []string{"Status", "Count"}, Rows: sortedCounts(st.ByStatus)},
+		{Name: "By Type", Headers: []string{"Type", "Count"}, Rows: sortedCounts(st.ByType)},
+		{Name: "Competition Levels", Headers: []string{"Level", "Count"}, Rows: sortedCounts(st.CompetitionLevels)},
+		{Name: "Top Students by Points", Headers: []string{"NIM", "Points"}, Rows: topByPoints},
+		{Name: "Monthly", Headers: []string{"Month", "Count"}, Rows: sortedCounts(st.Monthly)},
+		{Name: "Top Students by Count", Headers: []string{"NIM", "Achievements"}, Rows: topByCount},
+	}
+}
+
+// sortedCounts isi map sebagai baris (key, count) urut key
+func sortedCounts(counts map[string]int) [][]interface{} {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([][]interface{}, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, []interface{}{k, counts[k]})
+	}
+	return rows
+}
+
+// writeStatistics menulis satu sheet per section (XLSX / PDF). CSV hanya punya
+// satu tabel, jadi ditulis dalam format panjang: Section, Key, Value.
+func writeStatistics(w *bufio.Writer, format string, sections []statisticsSection) error {
+	table, err := helper.NewTableWriter(format, w, "Achievement Statistics")
+	if err != nil {
+		return err
+	}
+
+	if format == helper.ExportCSV {
+		if err := table.Section("Statistics", []string{"Section", "Key", "Value"}); err != nil {
+			return err
+		}
+		for _, section := range sections {
+			for _, row := range section.Rows {
+				if err := table.Row(section.Name, row[0], row[1]); err != nil {
+					return err
+				}
+			}
+		}
+		return table.Close()
+	}
+
+	for _, section := range sections {
+		if err := table.Section(section.Name, section.Headers); err != nil {
+			return err
+		}
+		for _, row := range section.Rows {
+			if err := table.Row(row...); err != nil {
+				return err
+			}
+		}
+	}
+	return table.Close()
+}
+
+// ExportStatistics godoc
+// @Summary Export achievement statistics
+// @Description Ekspor statistik prestasi (scope sama dengan GET /reports/statistics) ke CSV, XLSX (satu sheet per bagian) atau PDF
+// @Tags Reports
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Param format query string false "csv | xlsx | pdf (default csv)"
+// @Success 200 {file} file
+// @Failure 400 {object} model.APIResponse
+// @Failure 401 {object} model.APIResponse
+// @Failure 403 {object} model.APIResponse
+// @Router /reports/statistics/export [get]
+func (s *ReportService) ExportStatistics(c *fiber.Ctx) error {
+	format, errs := exportFormat(c)
+	if len(errs) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ErrorResponse("validation failed", errs))
+	}
+
+	studentIDs, written, err := s.statisticsScope(c)
+	if written {
+		return err
+	}
+
+	stats, failed, err := s.collectStatistics(context.Background(), studentIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(failed, err.Error()))
+	}
+
+	ids := []string{}
+	for _, p := range stats.TopByPoints {
+		ids = append(ids, p.StudentID)
+	}
+	for id := range stats.TopByCount {
+		ids = append(ids, id)
+	}
+	students, err := s.studentRepo.FindByIDs(ids)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed fetch students", err.Error()))
+	}
+	nims := map[string]string{}
+	for _, st := range students {
+		nims[st.ID] = st.StudentID
+	}
+
+	sections := stats.sections(nims)
+	return streamExport(c, "statistics", format, func(w *bufio.Writer) error {
+		return writeStatistics(w, format, sections)
+	})
+}
@@ -38,9 +38,26 @@ func NewReportService(
 // @Failure 403 {object} model.APIResponse
 // @Router /reports/statistics [get]
 func (s *ReportService) Statistics(c *fiber.Ctx) error {
+	studentIDs, written, err := s.statisticsScope(c)
+	if written {
+		return err
+	}
+
+	stats, failed, err := s.collectStatistics(context.Background(), studentIDs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse(failed, err.Error()))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.SuccessResponse(stats.response()))
+}
+
+// statisticsScope mahasiswa yang statistiknya boleh dilihat user: Mahasiswa
+// dirinya sendiri, Dosen Wali mahasiswa bimbingannya, Admin semua (nil).
+// Jika return kedua true, response sudah ditulis.
+func (s *ReportService) statisticsScope(c *fiber.Ctx) ([]string, bool, error) {
 	claims, ok := c.Locals("user").(*model.JWTClaims)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse("unauthorized", nil))
+		return nil, true, c.Status(fiber.StatusUnauthorized).JSON(model.ErrorResponse("unauthorized", nil))
 	}
 
 	var studentIDs []string
@@ -49,65 +66,83 @@ func (s *ReportService) Statistics(c *fiber.Ctx) error {
 	case "Mahasiswa":
 		student, err := s.studentRepo.FindByUserID(claims.UserID)
 		if err != nil || student == nil {
-			return c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse("student not found", nil))
+			return nil, true, c.Status(fiber.StatusNotFound).JSON(model.ErrorResponse("student not found", nil))
 		}
 		studentIDs = []string{student.ID}
 
 	case "Dosen Wali":
 		students, err := s.studentRepo.FindByAdvisorID(claims.UserID)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed fetch advisees", err.Error()))
+			return nil, true, c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed fetch advisees", err.Error()))
 		}
 		for _, st := range students {
 			studentIDs = append(studentIDs, st.ID)
 		}
 	case "Admin":
 	default:
-		return c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse("forbidden role", nil))
+		return nil, true, c.Status(fiber.StatusForbidden).JSON(model.ErrorResponse("forbidden role", nil))
 	}
+	return studentIDs, false, nil
+}
 
-	ctx := context.Background()
+// statistics hasil GET /reports/statistics, dipakai juga untuk ekspor
+type statistics struct {
+	ByStatus          map[string]int
+	ByType            map[string]int
+	CompetitionLevels map[string]int
+	TopByPoints       []model.StudentPoints
+	Monthly           map[string]int
+	TopByCount        map[string]int
+}
 
-	statusCounts, err := s.reportRepo.CountByStatus(studentIDs)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed count by status", err.Error()))
+func (st *statistics) response() fiber.Map {
+	return fiber.Map{
+		"totalByStatus":       st.ByStatus,
+		"totalByType":         st.ByType,
+		"competitionLevels":   st.CompetitionLevels,
+		"topStudentsByPoints": st.TopByPoints,
+		"monthly":             st.Monthly,
+		"topByCount":          st.TopByCount,
 	}
+}
 
-	typeCounts, err := s.mongoReportRepo.GetTotalByType(ctx, studentIDs)
+// collectStatistics menghitung statistik untuk studentIDs (kosong = semua).
+// Jika gagal, string kedua adalah pesan error untuk response.
+func (s *ReportService) collectStatistics(ctx context.Context, studentIDs []string) (*statistics, string, error) {
+	var st statistics
+	var err error
+
+	st.ByStatus, err = s.reportRepo.CountByStatus(studentIDs)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed total by type", err.Error()))
+		return nil, "failed count by status", err
 	}
 
-	competitionDist, err := s.mongoReportRepo.GetCompetitionLevelDistribution(ctx, studentIDs)
+	st.ByType, err = s.mongoReportRepo.GetTotalByType(ctx, studentIDs)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed competition distribution", err.Error()))
+		return nil, "failed total by type", err
 	}
 
-	topStudents, err := s.mongoReportRepo.GetTopStudentsByPoints(ctx, int64(10), studentIDs)
+	st.CompetitionLevels, err = s.mongoReportRepo.GetCompetitionLevelDistribution(ctx, studentIDs)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed top students", err.Error()))
+		return nil, "failed competition distribution", err
 	}
 
-	monthly, err := s.mongoReportRepo.GetMonthlyCounts(ctx, 6, studentIDs)
+	st.TopByPoints, err = s.mongoReportRepo.GetTopStudentsByPoints(ctx, int64(10), studentIDs)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(model.ErrorResponse("failed monthly counts", err.Error()))
+		return nil, "failed top students", err
 	}
 
-	topByCount, err := s.reportRepo.CountTotalPerStudent(studentIDs, 10)
+	st.Monthly, err = s.mongoReportRepo.GetMonthlyCounts(ctx, 6, studentIDs)
 	if err != nil {
-		fmt.Println("warning: CountTotalPerStudent failed:", err)
+		return nil, "failed monthly counts", err
 	}
 
-	resp := fiber.Map{
-		"totalByStatus":    statusCounts,
-		"totalByType":      typeCounts,
-		"competitionLevels": competitionDist,
-		"topStudentsByPoints": topStudents,
-		"monthly":          monthly,
-		"topByCount":       topByCount,
+	st.TopByCount, err = s.reportRepo.CountTotalPerStudent(studentIDs, 10)
+	if err != nil {
+		fmt.Println("warning: CountTotalPerStudent failed:", err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(model.SuccessResponse(resp))
+	return &st, "", nil
 }
 
 // GetStudentStatistics godoc
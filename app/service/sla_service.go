@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+)
+
+// SLAPolicy batas waktu tiap tahap persetujuan sejak tahap mulai menunggu:
+// setelah OverdueAfter approver tahap diberi notifikasi, setelah EscalateAfter admin
+type SLAPolicy struct {
+	OverdueAfter  time.Duration
+	EscalateAfter time.Duration
+}
+
+// SLAService memantau prestasi submitted yang terlalu lama menunggu verifikasi
+type SLAService struct {
+	postgresRepo  *repository.AchievementRepository
+	slaRepo       *repository.SLARepository
+	approvalRepo  *repository.ApprovalRepository
+	studentRepo   *repository.StudentRepository
+	userRepo      *repository.UserRepository
+	notifications *NotificationService
+	delegations   *DelegationService
+	policy        SLAPolicy
+}
+
+func NewSLAService(
+	postgresRepo *repository.AchievementRepository,
+	slaRepo *repository.SLARepository,
+	approvalRepo *repository.ApprovalRepository,
+	studentRepo *repository.StudentRepository,
+	userRepo *repository.UserRepository,
+	notifications *NotificationService,
+	delegations *DelegationService,
+	policy SLAPolicy,
+) *SLAService {
+	return &SLAService{
+		postgresRepo:  postgresRepo,
+		slaRepo:       slaRepo,
+		approvalRepo:  approvalRepo,
+		studentRepo:   studentRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+		delegations:   delegations,
+		policy:        policy,
+	}
+}
+
+// Policy batas waktu SLA yang berlaku
+func (s *SLAService) Policy() SLAPolicy {
+	return s.policy
+}
+
+// Evaluate menghitung status SLA reference pada waktu now. Nil jika reference
+// tidak sedang menunggu verifikasi. stageStartedAt = waktu tahap aktif mulai
+// menunggu (nil = waktu submit), flags = penanda dari scheduler (boleh nil).
+func (p SLAPolicy) Evaluate(ref model.AchievementReference, stageStartedAt *time.Time, flags *model.AchievementSLA, now time.Time) *model.SLAStatus {
+	if ref.Status != model.StatusSubmitted || ref.SubmittedAt == nil {
+		return nil
+	}
+	started := *ref.SubmittedAt
+	if stageStartedAt != nil && stageStartedAt.After(started) {
+		started = *stageStartedAt
+	}
+	status := &model.SLAStatus{
+		State:          model.SLAOnTrack,
+		SubmittedAt:    *ref.SubmittedAt,
+		StageStartedAt: started,
+		DueAt:          started.Add(p.OverdueAfter),
+		EscalateAt:     started.Add(p.EscalateAfter),
+		WaitingDays:    int(now.Sub(started) / (24 * time.Hour)),
+	}
+	switch {
+	case !now.Before(status.EscalateAt):
+		status.State = model.SLAEscalated
+	case !now.Before(status.DueAt):
+		status.State = model.SLAOverdue
+	}
+	// Penanda tahap sebelumnya atau pengajuan sebelumnya (sebelum revisi) tidak berlaku
+	if flags != nil && flags.StartedAt.Equal(started) {
+		status.AdvisorNotifiedAt = flags.OverdueAt
+		status.EscalatedAt = flags.EscalatedAt
+	}
+	return status
+}
+
+// Status status SLA satu reference (untuk detail prestasi)
+func (s *SLAService) Status(ref model.AchievementReference) (*model.SLAStatus, error) {
+	if ref.Status != model.StatusSubmitted {
+		return nil, nil
+	}
+	statuses, err := s.Statuses([]model.AchievementReference{ref})
+	if err != nil {
+		return nil, err
+	}
+	return statuses[ref.ID], nil
+}
+
+// Statuses status SLA banyak reference, di-key dengan ID reference
+func (s *SLAService) Statuses(refs []model.AchievementReference) (map[string]*model.SLAStatus, error) {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.ID)
+	}
+	flags, err := s.slaRepo.FindByRefIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := s.approvalRepo.FindStageStarts(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make(map[string]*model.SLAStatus, len(refs))
+	for _, ref := range refs {
+		var f *model.AchievementSLA
+		if v, ok := flags[ref.ID]; ok {
+			f = &v
+		}
+		var started *time.Time
+		if v, ok := starts[ref.ID]; ok {
+			started = &v
+		}
+		result[ref.ID] = s.policy.Evaluate(ref, started, f, now)
+	}
+	return result, nil
+}
+
+// OverdueFilter filter reference submitted yang tahap aktifnya sudah melewati
+// batas SLA
+func (s *SLAService) OverdueFilter(now time.Time) model.ReferenceFilter {
+	threshold := now.Add(-s.policy.OverdueAfter)
+	return model.ReferenceFilter{
+		Statuses:   []string{model.StatusSubmitted},
+		DateColumn: model.StageStartedColumn,
+		To:         &threshold,
+		SortColumn: model.StageStartedColumn,
+	}
+}
+
+// Check menandai tahap yang overdue (notifikasi ke approver tahap aktif) dan
+// yang melewati batas eskalasi (notifikasi ke semua admin). Setiap tahap hanya
+// dinotifikasi sekali; tahap yang diputuskan dan submit ulang memulai SLA baru.
+func (s *SLAService) Check(ctx context.Context) (int, int, error) {
+	now := time.Now()
+	refs, _, err := s.postgresRepo.Search(s.OverdueFilter(now))
+	if err != nil {
+		return 0, 0, err
+	}
+	statuses, err := s.Statuses(refs)
+	if err != nil {
+		return 0, 0, err
+	}
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.ID)
+	}
+	stages, err := s.approvalRepo.FindCurrentStages(ids)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var admins []string
+	overdue, escalated := 0, 0
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			return overdue, escalated, ctx.Err()
+		}
+		status := statuses[ref.ID]
+		if status == nil {
+			continue
+		}
+
+		if status.AdvisorNotifiedAt == nil {
+			marked, err := s.slaRepo.MarkOverdue(ref.ID, status.StageStartedAt, now)
+			if err != nil {
+				log.Printf("sla %s: %v", ref.ID, err)
+				continue
+			}
+			if marked {
+				var stage *model.AchievementApproval
+				if v, ok := stages[ref.ID]; ok {
+					stage = &v
+				}
+				s.notifyApprovers(ref, stage, status)
+				overdue++
+			}
+		}
+
+		if status.State == model.SLAEscalated && status.EscalatedAt == nil {
+			marked, err := s.slaRepo.MarkEscalated(ref.ID, status.StageStartedAt, now)
+			if err != nil {
+				log.Printf("sla %s: %v", ref.ID, err)
+				continue
+			}
+			if !marked {
+				continue
+			}
+			if admins == nil {
+				if admins, err = s.userRepo.FindIDsByRole("Admin"); err != nil {
+					log.Printf("sla %s: failed to fetch admins: %v", ref.ID, err)
+					continue
+				}
+			}
+			refID := ref.ID
+			s.notifications.Notify(admins, model.Notification{
+				Type:             model.NotificationSLAEscalated,
+				Title:            "Verification overdue, escalated",
+				Message:          fmt.Sprintf("An achievement submission has been waiting at its current approval stage for %d days and the approver has not acted on it.", status.WaitingDays),
+				AchievementRefID: &refID,
+			})
+			escalated++
+		}
+	}
+	return overdue, escalated, nil
+}
+
+// notifyApprovers mengingatkan approver tahap aktif. Tahap dosen wali dan
+// pengajuan tanpa tahap (sebelum rantai persetujuan) diingatkan ke dosen wali
+// mahasiswa beserta delegasinya yang sedang aktif.
+func (s *SLAService) notifyApprovers(ref model.AchievementReference, stage *model.AchievementApproval, status *model.SLAStatus) {
+	var recipients []string
+	stageName := "verification"
+	switch {
+	case stage == nil || stage.Scope == model.ScopeAdvisor:
+		recipients = s.advisorRecipients(ref.ID, ref.StudentID)
+	case stage.Scope == model.ScopeMemberAdvisor:
+		if stage.ScopeValue != nil {
+			recipients = s.advisorRecipients(ref.ID, *stage.ScopeValue)
+		}
+	default:
+		ids, err := s.approvalRepo.FindApproverIDs(stage.ID)
+		if err != nil {
+			log.Printf("sla %s: failed to fetch approvers: %v", ref.ID, err)
+		}
+		recipients = ids
+	}
+	if stage != nil {
+		stageName = stage.Name
+	}
+	if len(recipients) == 0 {
+		// Tanpa approver, pengajuan tetap sampai ke admin lewat eskalasi
+		log.Printf("sla %s: no approver to notify for stage %q", ref.ID, stageName)
+		return
+	}
+
+	refID := ref.ID
+	s.notifications.Notify(recipients, model.Notification{
+		Type:             model.NotificationSLAOverdue,
+		Title:            "Achievement waiting for approval",
+		Message:          fmt.Sprintf("An achievement submission has been waiting for your approval (%s) for %d days.", stageName, status.WaitingDays),
+		AchievementRefID: &refID,
+	})
+}
+
+// advisorRecipients dosen wali mahasiswa dan delegasinya yang sedang aktif
+func (s *SLAService) advisorRecipients(refID, studentID string) []string {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil || student == nil || student.AdvisorID == nil {
+		log.Printf("sla %s: student %s has no advisor to notify", refID, studentID)
+		return nil
+	}
+	recipients := []string{*student.AdvisorID}
+	if delegates, err := s.delegations.ActiveDelegateIDs(*student.AdvisorID); err != nil {
+		log.Printf("sla %s: failed to fetch delegations: %v", refID, err)
+	} else {
+		recipients = append(recipients, delegates...)
+	}
+	return recipients
+}
+
+// RunSLACheck menjalankan Check saat start lalu setiap interval
+func (s *SLAService) RunSLACheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if overdue, escalated, err := s.Check(ctx); err != nil {
+			log.Printf("sla check failed: %v", err)
+		} else if overdue > 0 || escalated > 0 {
+			log.Printf("sla check: %d overdue, %d escalated", overdue, escalated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+)
+
+var testSLAPolicy = service.SLAPolicy{
+	OverdueAfter:  7 * 24 * time.Hour,
+	EscalateAfter: 14 * 24 * time.Hour,
+}
+
+// Test Evaluate - prestasi bukan submitted tidak punya SLA
+func TestSLAEvaluate_NotSubmitted(t *testing.T) {
+	submitted := time.Now().Add(-30 * 24 * time.Hour)
+	ref := model.AchievementReference{Status: model.StatusVerified, SubmittedAt: &submitted}
+
+	assert.Nil(t, testSLAPolicy.Evaluate(ref, nil, nil, time.Now()))
+}
+
+// Test Evaluate - on track, overdue dan escalated sesuai lama menunggu
+func TestSLAEvaluate_States(t *testing.T) {
+	now := time.Now()
+	cases := map[time.Duration]string{
+		2 * 24 * time.Hour:  model.SLAOnTrack,
+		8 * 24 * time.Hour:  model.SLAOverdue,
+		15 * 24 * time.Hour: model.SLAEscalated,
+	}
+	for waiting, state := range cases {
+		submitted := now.Add(-waiting)
+		ref := model.AchievementReference{Status: model.StatusSubmitted, SubmittedAt: &submitted}
+
+		status := testSLAPolicy.Evaluate(ref, nil, nil, now)
+
+		assert.Equal(t, state, status.State)
+		assert.Equal(t, int(waiting/(24*time.Hour)), status.WaitingDays)
+		assert.Equal(t, submitted.Add(testSLAPolicy.OverdueAfter), status.DueAt)
+	}
+}
+
+// Test Evaluate - penanda dari pengajuan sebelumnya (sebelum submit ulang) diabaikan
+func TestSLAEvaluate_StaleFlags(t *testing.T) {
+	now := time.Now()
+	submitted := now.Add(-8 * 24 * time.Hour)
+	ref := model.AchievementReference{Status: model.StatusSubmitted, SubmittedAt: &submitted}
+
+	notified := now.Add(-20 * 24 * time.Hour)
+	stale := &model.AchievementSLA{StartedAt: submitted.Add(-30 * 24 * time.Hour), OverdueAt: &notified}
+	assert.Nil(t, testSLAPolicy.Evaluate(ref, nil, stale, now).AdvisorNotifiedAt)
+
+	current := &model.AchievementSLA{StartedAt: submitted, OverdueAt: &notified}
+	assert.Equal(t, &notified, testSLAPolicy.Evaluate(ref, nil, current, now).AdvisorNotifiedAt)
+}
+
+// Test Evaluate - tahap sebelumnya sudah disetujui, SLA dihitung ulang dari keputusan tahap itu
+func TestSLAEvaluate_StageRestartsClock(t *testing.T) {
+	now := time.Now()
+	submitted := now.Add(-20 * 24 * time.Hour)
+	stageStarted := now.Add(-2 * 24 * time.Hour)
+	ref := model.AchievementReference{Status: model.StatusSubmitted, SubmittedAt: &submitted}
+
+	// Penanda tahap dosen wali tidak berlaku untuk tahap berikutnya
+	notified := now.Add(-12 * 24 * time.Hour)
+	previous := &model.AchievementSLA{StartedAt: submitted, OverdueAt: &notified, EscalatedAt: &notified}
+
+	status := testSLAPolicy.Evaluate(ref, &stageStarted, previous, now)
+
+	assert.Equal(t, model.SLAOnTrack, status.State)
+	assert.Equal(t, submitted, status.SubmittedAt)
+	assert.Equal(t, stageStarted, status.StageStartedAt)
+	assert.Equal(t, stageStarted.Add(testSLAPolicy.OverdueAfter), status.DueAt)
+	assert.Equal(t, 2, status.WaitingDays)
+	assert.Nil(t, status.AdvisorNotifiedAt)
+	assert.Nil(t, status.EscalatedAt)
+}
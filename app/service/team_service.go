@@ -0,0 +1,303 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// TeamPolicy kebijakan prestasi tim: pembagian poin default (jika point rule
+// tidak menentukan), siapa yang memverifikasi, dan jumlah anggota maksimum
+type TeamPolicy struct {
+	Points       string // duplicate | split
+	Verification string // submitter | each_member
+	MaxMembers   int
+}
+
+// TeamService prestasi tim: satu prestasi dengan beberapa anggota yang masing-masing
+// mengonfirmasi keikutsertaannya
+type TeamService struct {
+	repo          *repository.TeamRepository
+	studentRepo   *repository.StudentRepository
+	notifications *NotificationService
+	policy        TeamPolicy
+}
+
+func NewTeamService(
+	repo *repository.TeamRepository,
+	studentRepo *repository.StudentRepository,
+	notifications *NotificationService,
+	policy TeamPolicy,
+) *TeamService {
+	return &TeamService{
+		repo:          repo,
+		studentRepo:   studentRepo,
+		notifications: notifications,
+		policy:        policy,
+	}
+}
+
+// Policy kebijakan prestasi tim yang berlaku
+func (s *TeamService) Policy() TeamPolicy {
+	return s.policy
+}
+
+// Members anggota tim prestasi (kosong untuk prestasi perorangan)
+func (s *TeamService) Members(refID string) ([]model.TeamMember, error) {
+	return s.repo.FindMembers(refID)
+}
+
+// PointsPolicy pembagian poin untuk rule yang cocok; rule tanpa team_policy
+// memakai default aplikasi
+func (p TeamPolicy) PointsPolicy(rule *model.PointRule) string {
+	if rule != nil && rule.TeamPolicy != nil {
+		return *rule.TeamPolicy
+	}
+	return p.Points
+}
+
+// TeamCredits poin setiap anggota tim yang sudah konfirmasi. duplicate: setiap
+// anggota mendapat poin penuh; split: dibagi rata dan sisa pembagian untuk
+// ketua. Nil untuk prestasi perorangan.
+func TeamCredits(points int, policy string, members []model.TeamMember) []model.TeamCredit {
+	var credits []model.TeamCredit
+	leader := -1
+	for _, m := range members {
+		if m.Status != model.MemberConfirmed {
+			continue
+		}
+		if m.Role == model.TeamRoleLeader {
+			leader = len(credits)
+		}
+		credits = append(credits, model.TeamCredit{StudentID: m.StudentID, Role: m.Role, Points: points})
+	}
+	if len(credits) == 0 || policy != model.TeamPointsSplit {
+		return credits
+	}
+
+	share := points / len(credits)
+	for i := range credits {
+		credits[i].Points = share
+	}
+	if leader < 0 {
+		leader = 0
+	}
+	credits[leader].Points += points - share*len(credits)
+	return credits
+}
+
+// BuildTeam memvalidasi request anggota tim dan menyusun daftar anggota baru.
+// Pemilik selalu ikut (confirmed); anggota lama mempertahankan statusnya,
+// anggota baru menunggu konfirmasi (anggota yang pernah menolak tetap declined).
+// Mengembalikan juga mahasiswa yang baru diundang.
+func (s *TeamService) BuildTeam(req *model.UpdateTeamRequest, owner *model.Student, invitedBy string, current []model.TeamMember) ([]model.TeamMember, []model.Student, []model.ValidationError) {
+	var errs []model.ValidationError
+	if len(req.Members) == 0 {
+		return nil, nil, nil
+	}
+
+	existing := map[string]model.TeamMember{}
+	for _, m := range current {
+		existing[m.StudentID] = m
+	}
+
+	now := time.Now()
+	members := []model.TeamMember{}
+	var invited []model.Student
+	seen := map[string]bool{}
+	ownerListed := false
+	leaders := 0
+
+	for i, r := range req.Members {
+		field := "members[" + strconv.Itoa(i) + "]"
+		r.StudentID = strings.TrimSpace(r.StudentID)
+		if r.Role == "" {
+			r.Role = model.TeamRoleMember
+		}
+		if r.Role != model.TeamRoleLeader && r.Role != model.TeamRoleMember {
+			errs = append(errs, model.ValidationError{Field: field + ".role", Message: "must be leader or member"})
+			continue
+		}
+		if r.StudentID == "" {
+			errs = append(errs, model.ValidationError{Field: field + ".studentId", Message: "field is required"})
+			continue
+		}
+		if seen[r.StudentID] {
+			errs = append(errs, model.ValidationError{Field: field + ".studentId", Message: "duplicate team member"})
+			continue
+		}
+		seen[r.StudentID] = true
+		if r.Role == model.TeamRoleLeader {
+			leaders++
+		}
+
+		if r.StudentID == owner.ID {
+			ownerListed = true
+			members = append(members, model.TeamMember{
+				StudentID:   owner.ID,
+				Role:        r.Role,
+				Status:      model.MemberConfirmed,
+				RespondedAt: &now,
+				CreatedAt:   now,
+			})
+			continue
+		}
+		if m, ok := existing[r.StudentID]; ok {
+			m.Role = r.Role
+			members = append(members, m)
+			continue
+		}
+
+		student, err := s.studentRepo.FindByID(r.StudentID)
+		if err != nil {
+			errs = append(errs, model.ValidationError{Field: field + ".studentId", Message: "student not found"})
+			continue
+		}
+		invitedByID := invitedBy
+		members = append(members, model.TeamMember{
+			StudentID: r.StudentID,
+			Role:      r.Role,
+			Status:    model.MemberPending,
+			InvitedBy: &invitedByID,
+			CreatedAt: now,
+		})
+		invited = append(invited, *student)
+	}
+
+	if !ownerListed {
+		// Pemilik yang tidak disebut menjadi ketua jika tidak ada ketua lain
+		role := model.TeamRoleMember
+		if leaders == 0 {
+			role = model.TeamRoleLeader
+			leaders++
+		}
+		members = append([]model.TeamMember{{
+			StudentID:   owner.ID,
+			Role:        role,
+			Status:      model.MemberConfirmed,
+			RespondedAt: &now,
+			CreatedAt:   now,
+		}}, members...)
+	}
+	if len(errs) == 0 && leaders != 1 {
+		errs = append(errs, model.ValidationError{Field: "members", Message: "team must have exactly one leader"})
+	}
+	if len(errs) == 0 && len(members) > s.policy.MaxMembers {
+		errs = append(errs, model.ValidationError{
+			Field:   "members",
+			Message: "team must have at most " + strconv.Itoa(s.policy.MaxMembers) + " members",
+		})
+	}
+	return members, invited, errs
+}
+
+// Replace menyimpan daftar anggota tim dan mengirim notifikasi ke anggota baru
+func (s *TeamService) Replace(ref *model.AchievementReference, members []model.TeamMember, invited []model.Student) error {
+	if err := s.repo.ReplaceMembers(ref.ID, members); err != nil {
+		return err
+	}
+	if len(invited) == 0 {
+		return nil
+	}
+	userIDs := make([]string, 0, len(invited))
+	for _, st := range invited {
+		userIDs = append(userIDs, st.UserID)
+	}
+	refID := ref.ID
+	// Kegagalan notifikasi dicatat oleh NotificationService; undangan tetap
+	// terlihat di /me/team-invitations
+	s.notifications.Notify(userIDs, model.Notification{
+		Type:             model.NotificationTeamInvite,
+		Title:            "Team achievement invitation",
+		Message:          "You have been added as a member of a team achievement. Please confirm your participation.",
+		AchievementRefID: &refID,
+	})
+	return nil
+}
+
+// Respond mencatat konfirmasi atau penolakan keikutsertaan anggota
+func (s *TeamService) Respond(refID, studentID, status string) error {
+	return s.repo.Respond(refID, studentID, status, time.Now())
+}
+
+// Member keanggotaan satu mahasiswa di prestasi; nil jika bukan anggota
+func (s *TeamService) Member(refID, studentID string) (*model.TeamMember, error) {
+	return s.repo.FindMember(refID, studentID)
+}
+
+// Invitations prestasi tim yang menunggu konfirmasi mahasiswa
+func (s *TeamService) Invitations(studentID string) ([]model.AchievementReference, error) {
+	return s.repo.FindInvitations(studentID)
+}
+
+// ApprovalStages menambahkan tahap dosen wali anggota tim jika kebijakan
+// verifikasi each_member. Anggota dengan dosen wali yang sama dengan pengaju
+// (atau anggota lain) cukup diwakili satu tahap; anggota tanpa dosen wali dilewati.
+func (s *TeamService) ApprovalStages(stages []model.AchievementApproval, owner *model.Student, members []model.TeamMember) ([]model.AchievementApproval, error) {
+	if s.policy.Verification != model.TeamVerifyEachMember {
+		return stages, nil
+	}
+	var students []model.Student
+	for _, m := range members {
+		if m.Status != model.MemberConfirmed || m.StudentID == owner.ID {
+			continue
+		}
+		st, err := s.studentRepo.FindByID(m.StudentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch team member %s: %v", m.StudentID, err)
+		}
+		students = append(students, *st)
+	}
+	return InsertMemberAdvisorStages(stages, owner, students), nil
+}
+
+// InsertMemberAdvisorStages menyisipkan satu tahap member_advisor per dosen wali
+// anggota tim tepat setelah tahap dosen wali pengaju (atau di awal jika rantai
+// tidak punya tahap dosen wali), lalu menomori ulang urutan tahap
+func InsertMemberAdvisorStages(stages []model.AchievementApproval, owner *model.Student, members []model.Student) []model.AchievementApproval {
+	advisors := map[string]bool{}
+	if owner.AdvisorID != nil {
+		advisors[*owner.AdvisorID] = true
+	}
+
+	var extra []model.AchievementApproval
+	for _, m := range members {
+		if m.AdvisorID == nil || advisors[*m.AdvisorID] {
+			continue
+		}
+		advisors[*m.AdvisorID] = true
+		studentID := m.ID
+		extra = append(extra, model.AchievementApproval{
+			ID:         uuid.New().String(),
+			Name:       "Dosen Wali " + m.StudentID,
+			Role:       "Dosen Wali",
+			Scope:      model.ScopeMemberAdvisor,
+			ScopeValue: &studentID,
+		})
+	}
+	if len(extra) == 0 {
+		return stages
+	}
+
+	at := 0
+	for i, stage := range stages {
+		if stage.Scope == model.ScopeAdvisor {
+			at = i + 1
+			break
+		}
+	}
+	result := make([]model.AchievementApproval, 0, len(stages)+len(extra))
+	result = append(result, stages[:at]...)
+	result = append(result, extra...)
+	result = append(result, stages[at:]...)
+	for i := range result {
+		result[i].StageOrder = i + 1
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/app/service"
+)
+
+func testTeam() []model.TeamMember {
+	return []model.TeamMember{
+		{StudentID: "student-1", Role: model.TeamRoleMember, Status: model.MemberConfirmed},
+		{StudentID: "student-2", Role: model.TeamRoleLeader, Status: model.MemberConfirmed},
+		{StudentID: "student-3", Role: model.TeamRoleMember, Status: model.MemberConfirmed},
+		{StudentID: "student-4", Role: model.TeamRoleMember, Status: model.MemberDeclined},
+	}
+}
+
+// Test TeamCredits - duplicate: setiap anggota yang konfirmasi mendapat poin penuh
+func TestTeamCredits_Duplicate(t *testing.T) {
+	credits := service.TeamCredits(100, model.TeamPointsDuplicate, testTeam())
+
+	assert.Len(t, credits, 3)
+	for _, c := range credits {
+		assert.Equal(t, 100, c.Points)
+	}
+}
+
+// Test TeamCredits - split: dibagi rata, sisa pembagian untuk ketua
+func TestTeamCredits_SplitRemainderToLeader(t *testing.T) {
+	credits := service.TeamCredits(100, model.TeamPointsSplit, testTeam())
+
+	assert.Equal(t, []model.TeamCredit{
+		{StudentID: "student-1", Role: model.TeamRoleMember, Points: 33},
+		{StudentID: "student-2", Role: model.TeamRoleLeader, Points: 34},
+		{StudentID: "student-3", Role: model.TeamRoleMember, Points: 33},
+	}, credits)
+}
+
+// Test TeamCredits - prestasi perorangan tidak punya credit tim
+func TestTeamCredits_NoTeam(t *testing.T) {
+	assert.Nil(t, service.TeamCredits(100, model.TeamPointsSplit, nil))
+}
+
+// Test InsertMemberAdvisorStages - satu tahap per dosen wali anggota, setelah tahap dosen wali pengaju
+func TestInsertMemberAdvisorStages(t *testing.T) {
+	stages := []model.AchievementApproval{
+		{StageOrder: 1, Name: "Dosen Wali", Role: "Dosen Wali", Scope: model.ScopeAdvisor},
+		{StageOrder: 2, Name: "Kaprodi", Role: "Kaprodi", Scope: model.ScopeProgramStudy},
+	}
+	owner := &model.Student{ID: "student-1", AdvisorID: strPtr("lecturer-1")}
+	members := []model.Student{
+		{ID: "student-2", StudentID: "2201", AdvisorID: strPtr("lecturer-1")}, // dosen wali sama dengan pengaju
+		{ID: "student-3", StudentID: "2202", AdvisorID: strPtr("lecturer-2")},
+		{ID: "student-4", StudentID: "2203", AdvisorID: strPtr("lecturer-2")}, // sudah diwakili student-3
+		{ID: "student-5", StudentID: "2204"},                                  // tanpa dosen wali
+	}
+
+	result := service.InsertMemberAdvisorStages(stages, owner, members)
+
+	assert.Len(t, result, 3)
+	assert.Equal(t, model.ScopeAdvisor, result[0].Scope)
+	assert.Equal(t, model.ScopeMemberAdvisor, result[1].Scope)
+	assert.Equal(t, "student-3", *result[1].ScopeValue)
+	assert.Equal(t, "Dosen Wali 2202", result[1].Name)
+	assert.Equal(t, "Kaprodi", result[2].Name)
+	for i, stage := range result {
+		assert.Equal(t, i+1, stage.StageOrder)
+	}
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFSStorage menyimpan file di MongoDB GridFS dengan filename = key.
+// Put pada key yang sama menimpa versi sebelumnya.
+type GridFSStorage struct {
+	bucket *gridfs.Bucket
+}
+
+type gridFSFile struct {
+	ID         interface{} `bson:"_id"`
+	Length     int64       `bson:"length"`
+	UploadDate time.Time   `bson:"uploadDate"`
+	Metadata   struct {
+		ContentType string `bson:"contentType"`
+	} `bson:"metadata"`
+}
+
+func NewGridFSStorage(db *mongo.Database, bucketName string) (*GridFSStorage, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName(bucketName))
+	if err != nil {
+		return nil, err
+	}
+	return &GridFSStorage{bucket: bucket}, nil
+}
+
+func (s *GridFSStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	// Versi lama diingat dulu dan baru dihapus setelah upload baru berhasil
+	old, err := s.files(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	opts := options.GridFSUpload().SetMetadata(bson.M{"contentType": contentType})
+	if _, err := s.bucket.UploadFromStream(key, r, opts); err != nil {
+		return err
+	}
+	for _, f := range old {
+		if err := s.bucket.DeleteContext(ctx, f.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GridFSStorage) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	info, id, err := s.latest(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, err := s.bucket.OpenDownloadStream(id)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return stream, info, nil
+}
+
+func (s *GridFSStorage) Delete(ctx context.Context, key string) error {
+	key, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	files, err := s.files(ctx, key)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return ErrNotFound
+	}
+	for _, f := range files {
+		if err := s.bucket.DeleteContext(ctx, f.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GridFSStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, _, err := s.latest(ctx, key)
+	return info, err
+}
+
+// files mengambil semua versi file dengan filename = key, terbaru lebih dulu
+func (s *GridFSStorage) files(ctx context.Context, key string) ([]gridFSFile, error) {
+	opts := options.GridFSFind().SetSort(bson.D{{Key: "uploadDate", Value: -1}})
+	cursor, err := s.bucket.FindContext(ctx, bson.M{"filename": key}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []gridFSFile
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (s *GridFSStorage) latest(ctx context.Context, key string) (*ObjectInfo, interface{}, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := s.files(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil, ErrNotFound
+	}
+
+	f := files[0]
+	info := &ObjectInfo{Key: key, Size: f.Length, ContentType: f.Metadata.ContentType, ModTime: f.UploadDate}
+	if info.ContentType == "" {
+		info.ContentType = "application/octet-stream"
+	}
+	return info, f.ID, nil
+}
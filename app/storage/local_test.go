@@ -0,0 +1,79 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/storage"
+)
+
+// Test LocalStorage - put, stat, get lalu delete satu object
+func TestLocalStorage_RoundTrip(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	err = s.Put(ctx, "achievements/abc/sertifikat.pdf", strings.NewReader("isi file"), 8, "application/pdf")
+	assert.NoError(t, err)
+
+	info, err := s.Stat(ctx, "achievements/abc/sertifikat.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+	assert.Equal(t, "application/pdf", info.ContentType)
+
+	r, _, err := s.Get(ctx, "achievements/abc/sertifikat.pdf")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "isi file", string(body))
+
+	assert.NoError(t, s.Delete(ctx, "achievements/abc/sertifikat.pdf"))
+	_, err = s.Stat(ctx, "achievements/abc/sertifikat.pdf")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.ErrorIs(t, s.Delete(ctx, "achievements/abc/sertifikat.pdf"), storage.ErrNotFound)
+}
+
+// Test LocalStorage - key dengan ".." tetap di dalam root
+func TestLocalStorage_KeyStaysInRoot(t *testing.T) {
+	root := t.TempDir()
+	s, err := storage.NewLocalStorage(root)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, s.Put(ctx, "../../escape.txt", strings.NewReader("x"), 1, "text/plain"))
+
+	info, err := s.Stat(ctx, "escape.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "escape.txt", info.Key)
+
+	_, err = storage.CleanKey("/")
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+}
+
+// Test LocalStorage - path lampiran lama "/uploads/<nama>" dibaca dari root, bukan root/uploads
+func TestLocalStorage_LegacyUploadPath(t *testing.T) {
+	root := t.TempDir()
+	s, err := storage.NewLocalStorage(root)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "lama.pdf"), []byte("isi lama"), 0644))
+
+	r, info, err := s.Get(ctx, "/uploads/lama.pdf")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "isi lama", string(body))
+	assert.Equal(t, "lama.pdf", info.Key)
+
+	// key baru yang kebetulan diawali "uploads/" tidak diubah
+	assert.NoError(t, s.Put(ctx, "uploads/baru.pdf", strings.NewReader("x"), 1, "application/pdf"))
+	_, err = os.Stat(filepath.Join(root, "uploads", "baru.pdf"))
+	assert.NoError(t, err)
+}
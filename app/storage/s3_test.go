@@ -0,0 +1,39 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/storage"
+)
+
+// Test S3Storage - hanya jalan jika S3_TEST_ENDPOINT diisi (mis. MinIO lokal)
+func TestS3Storage_RoundTrip(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+	ctx := context.Background()
+
+	s, err := storage.NewS3Storage(ctx, storage.S3Config{
+		Endpoint:  endpoint,
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		Bucket:    "storage-test",
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Put(ctx, "test/a.txt", strings.NewReader("halo"), 4, "text/plain"))
+	info, err := s.Stat(ctx, "test/a.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), info.Size)
+	assert.Equal(t, "text/plain", info.ContentType)
+
+	assert.NoError(t, s.Delete(ctx, "test/a.txt"))
+	_, err = s.Stat(ctx, "test/a.txt")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,45 @@
+// Package storage menyimpan file lampiran prestasi di backend yang bisa diganti
+// lewat env (local disk, S3-compatible, Mongo GridFS). Lampiran hanya menyimpan
+// key; lokasi fisik file ditentukan oleh backend.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// ObjectInfo metadata object yang tersimpan
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage backend penyimpanan file
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get mengembalikan isi object; pemanggil wajib menutup reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+}
+
+// CleanKey menormalkan key ("a/b/c.pdf") dan menolak key yang keluar dari root
+// (mis. "../x") agar aman dipakai sebagai path file maupun nama object
+func CleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+	if key == "" || key == "." {
+		return "", ErrInvalidKey
+	}
+	return key, nil
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -10,8 +12,10 @@ import (
 	swagger "github.com/gofiber/swagger"
 	_ "go-fiber/docs"
 
+	"go-fiber/app/model"
 	"go-fiber/app/repository"
 	"go-fiber/app/service"
+	"go-fiber/helper"
 	"go-fiber/middleware"
 	"go-fiber/route"
 )
@@ -19,10 +23,20 @@ import (
 
 func NewApp(db *sql.DB) *fiber.App {
 
+	// URL lampiran bertanda tangan tidak boleh memakai key kosong
+	if err := helper.CheckSigningSecret(); err != nil {
+		log.Fatal("❌ ", err)
+	}
+
+	attachmentPolicy := AttachmentPolicy()
+
 	// Buat Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:      GetEnv("APP_NAME", "Prestasi Backend API"),
 		ErrorHandler: customErrorHandler,
+		// Body harus muat upload lampiran terbesar yang diizinkan (atau satu
+		// chunk upload resumable) + overhead multipart
+		BodyLimit: int(max(attachmentPolicy.MaxSize*int64(attachmentPolicy.MaxFiles), attachmentPolicy.MaxChunkSize)) + 1<<20,
 	})
 
 	// Middleware global
@@ -42,6 +56,18 @@ func NewApp(db *sql.DB) *fiber.App {
 	lecturerRepo := repository.NewLecturerRepository(db)
 	achievementRepo := repository.NewAchievementRepository(db)
 	reportRepo := repository.NewReportRepository(db)
+	pointRuleRepo := repository.NewPointRuleRepository(db)
+	achievementTypeRepo := repository.NewAchievementTypeRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	sagaRepo := repository.NewSagaRepository(db)
+	uploadRepo := repository.NewUploadRepository(db)
+	blobRepo := repository.NewBlobRepository(db)
+	slaRepo := repository.NewSLARepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	delegationRepo := repository.NewDelegationRepository(db)
+	approvalRepo := repository.NewApprovalRepository(db)
+	teamRepo := repository.NewTeamRepository(db)
+	importRepo := repository.NewImportRepository(db)
 
 	// Mongo
 	mongoClient, err := NewMongoClient()
@@ -54,17 +80,121 @@ func NewApp(db *sql.DB) *fiber.App {
 
 	mongoAchievementRepo := repository.NewMongoAchievementRepository(achievementsColl)
 	mongoReportRepo := repository.NewMongoReportRepository(achievementsColl)
+	revisionRepo := repository.NewMongoRevisionRepository(mongoDB.Collection("achievement_revisions"))
+	if err := revisionRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Println("Warning: failed to create achievement_revisions index:", err)
+	}
+
+	// Penyimpanan file lampiran
+	fileStorage, err := NewStorage(mongoDB)
+	if err != nil {
+		log.Fatal("❌ Failed to init attachment storage:", err)
+	}
+
+	// Isi file lampiran disimpan sekali per hash (dedup)
+	blobService := service.NewBlobService(blobRepo, fileStorage)
 
 	// service
 	authService := service.NewAuthService(authRepo)
 	userService := service.NewUserService(userRepo, studentRepo, lecturerRepo)
 	studentService := service.NewStudentService(studentRepo)
 	lecturerService := service.NewLecturerService(lecturerRepo)
+	pointService := service.NewPointService(pointRuleRepo, achievementRepo, mongoAchievementRepo)
+	achievementTypeService := service.NewAchievementTypeService(achievementTypeRepo)
+	consistencyService := service.NewConsistencyService(
+		sagaRepo,
+		achievementRepo,
+		mongoAchievementRepo,
+		revisionRepo,
+		blobService,
+		TrashRetention(),
+	)
+
+	// Delegasi verifikasi saat dosen wali cuti
+	delegationService := service.NewDelegationService(
+		delegationRepo,
+		userRepo,
+		time.Duration(GetEnvInt("DELEGATION_MAX_DAYS", 180))*24*time.Hour,
+	)
+
+	// Rantai persetujuan bertahap (dosen wali -> kaprodi -> ...)
+	approvalService := service.NewApprovalService(approvalRepo, userRepo)
+
+	// Notifikasi in-app dan pemantauan SLA verifikasi
+	notificationService := service.NewNotificationService(notificationRepo)
+	slaService := service.NewSLAService(
+		achievementRepo,
+		slaRepo,
+		approvalRepo,
+		studentRepo,
+		userRepo,
+		notificationService,
+		delegationService,
+		SLAPolicy(),
+	)
+	go slaService.RunSLACheck(
+		context.Background(),
+		helper.ParseDuration("SLA_CHECK_INTERVAL", time.Hour),
+	)
+
+	// Prestasi tim: konfirmasi anggota, pembagian poin dan verifikasi
+	teamService := service.NewTeamService(teamRepo, studentRepo, notificationService, TeamPolicy())
+
+	// Thumbnail/preview lampiran dibuat di background
+	previewService := service.NewPreviewService(fileStorage, mongoAchievementRepo, blobService, PDFRenderer(), 100)
+	go previewService.Run(context.Background(), GetEnvInt("PREVIEW_WORKERS", 2))
 
 	achievementService := service.NewAchievementService(
 		achievementRepo,
 		mongoAchievementRepo,
 		studentRepo,
+		commentRepo,
+		pointService,
+		achievementTypeService,
+		consistencyService,
+		revisionRepo,
+		uploadRepo,
+		fileStorage,
+		attachmentPolicy,
+		previewService,
+		blobService,
+		slaService,
+		delegationService,
+		approvalService,
+		teamService,
+	)
+
+	// Selesaikan saga Postgres/Mongo yang terputus (mis. crash sebelum restart)
+	go consistencyService.RunRecovery(
+		context.Background(),
+		helper.ParseDuration("SAGA_RECOVERY_INTERVAL", time.Minute),
+		helper.ParseDuration("SAGA_RECOVERY_GRACE", 5*time.Minute),
+	)
+
+	// Hapus permanen prestasi yang sudah melewati masa simpan trash
+	go consistencyService.RunPurge(
+		context.Background(),
+		helper.ParseDuration("TRASH_PURGE_INTERVAL", 24*time.Hour),
+	)
+
+	// Bersihkan upload resumable yang ditinggalkan
+	go achievementService.RunUploadExpiry(
+		context.Background(),
+		helper.ParseDuration("UPLOAD_EXPIRY_INTERVAL", time.Hour),
+	)
+
+	// Import prestasi historis dari CSV / XLSX oleh admin
+	importService := service.NewImportService(
+		importRepo,
+		studentRepo,
+		achievementTypeRepo,
+		achievementRepo,
+		mongoAchievementRepo,
+		pointService,
+		approvalService,
+		consistencyService,
+		revisionRepo,
+		GetEnvInt("IMPORT_MAX_ROWS", 5000),
 	)
 
 	reportService := service.NewReportService(
@@ -89,11 +219,17 @@ func NewApp(db *sql.DB) *fiber.App {
 
 	// Register route groups
 	route.SetupAuthRoutes(api, authService)
-	route.SetupAchievementRoutes(api, achievementService)
+	route.SetupAchievementRoutes(api, achievementService, importService)
+	route.SetupMeRoutes(api, achievementService)
+	route.SetupNotificationRoutes(api, notificationService)
+	route.SetupDelegationRoutes(api, delegationService)
 	route.SetupUserRoutes(api, userService)
 	route.SetupStudentRoutes(api, studentService, achievementService)
 	route.SetupLecturerRoutes(api, lecturerService)
 	route.SetupReportRoutes(api, reportService)
+	route.SetupPointRuleRoutes(api, pointService)
+	route.SetupApprovalChainRoutes(api, approvalService)
+	route.SetupAchievementTypeRoutes(api, achievementTypeService)
 
 	// 404 Handler
 	app.Use(func(c *fiber.Ctx) error {
@@ -116,3 +252,45 @@ func customErrorHandler(c *fiber.Ctx, err error) error {
 		"message": err.Error(),
 	})
 }
+
+// TrashRetention lama prestasi disimpan di trash (TRASH_RETENTION_DAYS, default 30 hari)
+func TrashRetention() time.Duration {
+	return time.Duration(GetEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+}
+
+// SLAPolicy batas waktu tiap tahap persetujuan: SLA_OVERDUE_DAYS (default 7)
+// sampai approver tahap diingatkan, SLA_ESCALATE_DAYS (default 14) sampai
+// dieskalasi ke admin
+func SLAPolicy() service.SLAPolicy {
+	overdue := GetEnvInt("SLA_OVERDUE_DAYS", 7)
+	escalate := GetEnvInt("SLA_ESCALATE_DAYS", 14)
+	if escalate < overdue {
+		log.Printf("Warning: SLA_ESCALATE_DAYS (%d) < SLA_OVERDUE_DAYS (%d), using %d", escalate, overdue, overdue)
+		escalate = overdue
+	}
+	return service.SLAPolicy{
+		OverdueAfter:  time.Duration(overdue) * 24 * time.Hour,
+		EscalateAfter: time.Duration(escalate) * 24 * time.Hour,
+	}
+}
+
+// TeamPolicy prestasi tim: TEAM_POINTS_POLICY (duplicate | split, default
+// duplicate) untuk rule tanpa team_policy, TEAM_VERIFICATION (submitter |
+// each_member, default submitter) dan TEAM_MAX_MEMBERS (default 10)
+func TeamPolicy() service.TeamPolicy {
+	points := GetEnv("TEAM_POINTS_POLICY", model.TeamPointsDuplicate)
+	if points != model.TeamPointsDuplicate && points != model.TeamPointsSplit {
+		log.Printf("Warning: unknown TEAM_POINTS_POLICY %q, using %s", points, model.TeamPointsDuplicate)
+		points = model.TeamPointsDuplicate
+	}
+	verification := GetEnv("TEAM_VERIFICATION", model.TeamVerifySubmitter)
+	if verification != model.TeamVerifySubmitter && verification != model.TeamVerifyEachMember {
+		log.Printf("Warning: unknown TEAM_VERIFICATION %q, using %s", verification, model.TeamVerifySubmitter)
+		verification = model.TeamVerifySubmitter
+	}
+	return service.TeamPolicy{
+		Points:       points,
+		Verification: verification,
+		MaxMembers:   GetEnvInt("TEAM_MAX_MEMBERS", 10),
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go-fiber/app/scanner"
+	"go-fiber/app/service"
+	"go-fiber/app/storage"
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewStorage memilih backend penyimpanan lampiran dari STORAGE_DRIVER
+// (local | s3 | gridfs, default local)
+func NewStorage(mongoDB *mongo.Database) (storage.Storage, error) {
+	driver := GetEnv("STORAGE_DRIVER", "local")
+
+	switch driver {
+	case "local":
+		// Lampiran lama ("/uploads/<nama>") dibaca relatif terhadap direktori ini
+		dir := GetEnv("STORAGE_LOCAL_DIR", "./uploads")
+		log.Println("Attachment storage: local disk", dir)
+		return storage.NewLocalStorage(dir)
+
+	case "s3":
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cfg := storage.S3Config{
+			Endpoint:  GetEnv("S3_ENDPOINT", "localhost:9000"),
+			AccessKey: GetEnv("S3_ACCESS_KEY", ""),
+			SecretKey: GetEnv("S3_SECRET_KEY", ""),
+			Bucket:    GetEnv("S3_BUCKET", "achievements"),
+			Region:    GetEnv("S3_REGION", ""),
+			UseSSL:    GetEnv("S3_USE_SSL", "false") == "true",
+		}
+		log.Println("Attachment storage: s3", cfg.Endpoint+"/"+cfg.Bucket)
+		return storage.NewS3Storage(ctx, cfg)
+
+	case "gridfs":
+		bucket := GetEnv("GRIDFS_BUCKET", "attachments")
+		log.Println("Attachment storage: gridfs", bucket)
+		return storage.NewGridFSStorage(mongoDB, bucket)
+	}
+
+	return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+}
+
+// AttachmentPolicy batasan upload lampiran dari env. Scanner ClamAV dipakai
+// jika CLAMAV_ADDR diisi (mis. tcp://localhost:3310), selain itu no-op;
+// StreamMaxLength di clamd.conf harus setidaknya ATTACHMENT_RESUMABLE_MAX_SIZE_MB
+// (default clamd 25 MB), file yang lebih besar ditolak dengan 413.
+// Kuota STORAGE_QUOTA_*_MB = 0 berarti tidak dibatasi; kuota yang lebih kecil
+// dari ATTACHMENT_RESUMABLE_MAX_SIZE_MB dinaikkan agar satu file resumable
+// ukuran maksimum tetap muat.
+func AttachmentPolicy() service.AttachmentPolicy {
+	var fileScanner scanner.Scanner = scanner.NoopScanner{}
+	if addr := GetEnv("CLAMAV_ADDR", ""); addr != "" {
+		fileScanner = scanner.NewClamAVScanner(addr, helper.ParseDuration("CLAMAV_TIMEOUT", 30*time.Second))
+	}
+
+	resumableMB := GetEnvInt("ATTACHMENT_RESUMABLE_MAX_SIZE_MB", 500)
+	quota := func(key string, fallback int) int64 {
+		mb := GetEnvInt(key, fallback)
+		if mb > 0 && mb < resumableMB {
+			log.Printf("Warning: %s (%d) < ATTACHMENT_RESUMABLE_MAX_SIZE_MB (%d), using %d", key, mb, resumableMB, resumableMB)
+			mb = resumableMB
+		}
+		return int64(mb) << 20
+	}
+
+	return service.AttachmentPolicy{
+		MaxSize:      int64(GetEnvInt("ATTACHMENT_MAX_SIZE_MB", 10)) << 20,
+		MaxFiles:     GetEnvInt("ATTACHMENT_MAX_FILES", 5),
+		AllowedTypes: strings.Split(GetEnv("ATTACHMENT_ALLOWED_TYPES", "application/pdf,image/jpeg,image/png"), ","),
+		Scanner:      fileScanner,
+
+		MaxResumableSize: int64(resumableMB) << 20,
+		ResumableTypes:   strings.Split(GetEnv("ATTACHMENT_RESUMABLE_TYPES", "video/mp4,video/webm"), ","),
+		MaxChunkSize:     int64(GetEnvInt("ATTACHMENT_UPLOAD_CHUNK_MB", 32)) << 20,
+		UploadTTL:        helper.ParseDuration("UPLOAD_TTL", 24*time.Hour),
+
+		StudentQuota:     quota("STORAGE_QUOTA_STUDENT_MB", 2048),
+		AchievementQuota: quota("STORAGE_QUOTA_ACHIEVEMENT_MB", 1024),
+	}
+}
+
+// PDFRenderer path pdftoppm untuk preview PDF (PDF_RENDERER, default pdftoppm
+// di PATH). Kosong jika tidak tersedia; PDF lalu ditandai preview unsupported.
+func PDFRenderer() string {
+	if renderer := GetEnv("PDF_RENDERER", ""); renderer != "" {
+		return renderer
+	}
+	renderer, err := exec.LookPath("pdftoppm")
+	if err != nil {
+		log.Println("Warning: pdftoppm not found, PDF previews disabled")
+		return ""
+	}
+	return renderer
+}
@@ -11,8 +11,28 @@ import (
 func Migrate(db *sql.DB) {
 	log.Println("🔥 Running migrations...")
 
-	if err := migrations.CreateTables(db); err != nil {
-		log.Fatalf("Migration error: %v", err)
+	steps := []func(*sql.DB) error{
+		migrations.CreateTables,
+		migrations.CreateAchievementStatusHistory,
+		migrations.CreatePointRules,
+		migrations.CreateAchievementTypes,
+		migrations.CreateAchievementComments,
+		migrations.CreateAchievementSagas,
+		migrations.CreateAchievementUploads,
+		migrations.CreateAttachmentBlobs,
+		migrations.CreateAchievementSLA,
+		migrations.CreateAdvisorDelegations,
+		migrations.CreateApprovalChains,
+		migrations.CreateAchievementMembers,
+		migrations.CreateAchievementImports,
+		migrations.AlterAchievementSLAStage,
+		migrations.CreateAchievementUploadChunks,
+	}
+
+	for _, step := range steps {
+		if err := step(db); err != nil {
+			log.Fatalf("Migration error: %v", err)
+		}
 	}
 
 	log.Println("✅ Migration completed")
@@ -21,8 +41,17 @@ func Migrate(db *sql.DB) {
 func Seed(db *sql.DB) {
 	log.Println("🌱 Running seeders...")
 
-	if err := seeders.SeedRolesPermissions(db); err != nil {
-		log.Fatalf("Seeder error: %v", err)
+	steps := []func(*sql.DB) error{
+		seeders.SeedRolesPermissions,
+		seeders.SeedPointRules,
+		seeders.SeedAchievementTypes,
+		seeders.SeedApprovalChains,
+	}
+
+	for _, step := range steps {
+		if err := step(db); err != nil {
+			log.Fatalf("Seeder error: %v", err)
+		}
 	}
 
 	log.Println("✅ Seeder completed")
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementStatusHistory(db *sql.DB) error {
+	query := `
+-- Tabel riwayat perubahan status prestasi
+CREATE TABLE IF NOT EXISTS achievement_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL,
+    from_status VARCHAR(30) NOT NULL,
+    to_status VARCHAR(30) NOT NULL,
+    actor_id UUID,
+    actor_role VARCHAR(50),
+    note TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_achievement_status_history_ref ON achievement_status_history(achievement_ref_id, created_at);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 002_create_achievement_status_history executed successfully")
+	return nil
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreatePointRules(db *sql.DB) error {
+	query := `
+-- Tabel aturan poin prestasi (kolom NULL = berlaku untuk semua nilai)
+CREATE TABLE IF NOT EXISTS point_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_type VARCHAR(50),
+    competition_level VARCHAR(30),
+    rank INTEGER,
+    is_team BOOLEAN,
+    points INTEGER NOT NULL DEFAULT 0,
+    description TEXT,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_point_rules_type ON point_rules(achievement_type);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 003_create_point_rules executed successfully")
+	return nil
+}
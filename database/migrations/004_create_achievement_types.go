@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementTypes(db *sql.DB) error {
+	query := `
+-- Tabel registry jenis prestasi + skema details (JSON Schema style)
+CREATE TABLE IF NOT EXISTS achievement_types (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    schema JSONB NOT NULL DEFAULT '{}'::jsonb,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 004_create_achievement_types executed successfully")
+	return nil
+}
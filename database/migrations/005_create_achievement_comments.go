@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementComments(db *sql.DB) error {
+	query := `
+-- Tabel thread komentar prestasi (permintaan revisi, diskusi mahasiswa - dosen wali)
+CREATE TABLE IF NOT EXISTS achievement_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL,
+    author_id UUID NOT NULL,
+    author_role VARCHAR(50) NOT NULL,
+    body TEXT NOT NULL,
+    field VARCHAR(100),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_ref ON achievement_comments(achievement_ref_id, created_at);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 005_create_achievement_comments executed successfully")
+	return nil
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementSagas(db *sql.DB) error {
+	query := `
+-- Tabel saga untuk penulisan dua-store (Postgres + Mongo) yang bisa dipulihkan
+CREATE TABLE IF NOT EXISTS achievement_sagas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    operation VARCHAR(20) NOT NULL,
+    achievement_ref_id UUID NOT NULL,
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    student_id UUID NOT NULL,
+    state VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_achievement_sagas_pending ON achievement_sagas(created_at) WHERE state = 'pending';
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 006_create_achievement_sagas executed successfully")
+	return nil
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementUploads(db *sql.DB) error {
+	query := `
+-- Tabel upload lampiran resumable (tus); data file sementara ada di disk
+CREATE TABLE IF NOT EXISTS achievement_uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    category VARCHAR(30) NOT NULL DEFAULT 'other',
+    caption TEXT NOT NULL DEFAULT '',
+    upload_length BIGINT NOT NULL,
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_achievement_uploads_expires ON achievement_uploads(expires_at);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 007_create_achievement_uploads executed successfully")
+	return nil
+}
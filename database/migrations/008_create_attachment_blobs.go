@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAttachmentBlobs(db *sql.DB) error {
+	query := `
+-- Isi file lampiran disimpan sekali per hash SHA-256 (content-addressed)
+CREATE TABLE IF NOT EXISTS attachment_blobs (
+    sha256 CHAR(64) PRIMARY KEY,
+    storage_key VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Satu baris per file lampiran yang memakai blob; jumlah baris = reference count.
+-- Juga dipakai menghitung pemakaian kuota per mahasiswa / per prestasi.
+CREATE TABLE IF NOT EXISTS attachment_blob_refs (
+    id VARCHAR(24) PRIMARY KEY,
+    attachment_id VARCHAR(24) NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    student_id UUID NOT NULL,
+    achievement_ref_id UUID NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_attachment_blob_refs_sha256 ON attachment_blob_refs(sha256);
+CREATE INDEX IF NOT EXISTS idx_attachment_blob_refs_student ON attachment_blob_refs(student_id);
+CREATE INDEX IF NOT EXISTS idx_attachment_blob_refs_achievement ON attachment_blob_refs(achievement_ref_id);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 008_create_attachment_blobs executed successfully")
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementSLA(db *sql.DB) error {
+	query := `
+-- Penanda SLA verifikasi per pengajuan. submitted_at menyimpan waktu submit yang
+-- ditandai; submit ulang (setelah revisi) memulai SLA baru.
+CREATE TABLE IF NOT EXISTS achievement_sla (
+    achievement_ref_id UUID PRIMARY KEY,
+    submitted_at TIMESTAMP NOT NULL,
+    overdue_at TIMESTAMP,
+    escalated_at TIMESTAMP
+);
+
+-- Notifikasi in-app per user
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    achievement_ref_id UUID,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 009_create_achievement_sla executed successfully")
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAdvisorDelegations(db *sql.DB) error {
+	query := `
+-- Delegasi verifikasi sementara dari dosen wali (mis. cuti) ke dosen lain.
+-- advisor_id dan delegate_id adalah user ID; aktif selama starts_at <= NOW() < ends_at
+-- dan belum dicabut.
+CREATE TABLE IF NOT EXISTS advisor_delegations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    advisor_id UUID NOT NULL,
+    delegate_id UUID NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    revoked_at TIMESTAMP,
+    revoked_by UUID
+);
+
+-- Dosen wali asli saat perubahan status dilakukan oleh delegasinya
+ALTER TABLE achievement_status_history ADD COLUMN IF NOT EXISTS on_behalf_of UUID;
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_advisor_delegations_delegate ON advisor_delegations(delegate_id, ends_at);
+CREATE INDEX IF NOT EXISTS idx_advisor_delegations_advisor ON advisor_delegations(advisor_id, ends_at);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 010_create_advisor_delegations executed successfully")
+	return nil
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateApprovalChains(db *sql.DB) error {
+	query := `
+-- Konfigurasi rantai persetujuan per jenis prestasi dan tingkat kompetisi
+-- (kolom NULL = berlaku untuk semua nilai). stages: daftar tahap berurutan
+-- [{"name", "role", "scope"}].
+CREATE TABLE IF NOT EXISTS approval_chains (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_type VARCHAR(50),
+    competition_level VARCHAR(30),
+    stages JSONB NOT NULL DEFAULT '[]'::jsonb,
+    description TEXT,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Cakupan approver (mis. Kaprodi untuk program studi tertentu)
+CREATE TABLE IF NOT EXISTS approver_scopes (
+    user_id UUID NOT NULL,
+    scope VARCHAR(30) NOT NULL,
+    value VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, scope, value)
+);
+
+-- Tahap persetujuan per pengajuan, disalin dari rantai yang berlaku saat submit.
+-- submitted_at menyimpan waktu submit; submit ulang membuat tahap baru.
+CREATE TABLE IF NOT EXISTS achievement_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_ref_id UUID NOT NULL,
+    submitted_at TIMESTAMP NOT NULL,
+    stage_order INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    scope VARCHAR(30) NOT NULL,
+    scope_value VARCHAR(100),
+    decision VARCHAR(30),
+    actor_id UUID,
+    on_behalf_of UUID,
+    note TEXT,
+    decided_at TIMESTAMP,
+    UNIQUE (achievement_ref_id, submitted_at, stage_order)
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_approval_chains_type ON approval_chains(achievement_type);
+CREATE INDEX IF NOT EXISTS idx_achievement_approvals_pending ON achievement_approvals(role, scope, scope_value) WHERE decision IS NULL;
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 011_create_approval_chains executed successfully")
+	return nil
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementMembers(db *sql.DB) error {
+	query := `
+-- Anggota tim prestasi. Pemilik (pengaju) ikut tercatat dan otomatis confirmed;
+-- prestasi tanpa baris anggota adalah prestasi perorangan.
+CREATE TABLE IF NOT EXISTS achievement_members (
+    achievement_ref_id UUID NOT NULL,
+    student_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    invited_by UUID,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (achievement_ref_id, student_id)
+);
+
+-- Pembagian poin prestasi tim per rule: duplicate (setiap anggota mendapat
+-- poin penuh) atau split (dibagi rata). NULL = kebijakan default aplikasi.
+ALTER TABLE point_rules ADD COLUMN IF NOT EXISTS team_policy VARCHAR(20);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_achievement_members_student ON achievement_members(student_id, status);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 012_create_achievement_members executed successfully")
+	return nil
+}
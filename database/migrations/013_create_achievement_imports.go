@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementImports(db *sql.DB) error {
+	query := `
+-- Riwayat import prestasi massal (CSV / XLSX) oleh admin. Error per baris
+-- disimpan agar laporan baris yang gagal bisa diunduh kembali.
+CREATE TABLE IF NOT EXISTS achievement_imports (
+    id UUID PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    initial_status VARCHAR(20) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    imported INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Index
+CREATE INDEX IF NOT EXISTS idx_achievement_imports_created_at ON achievement_imports(created_at DESC);
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 013_create_achievement_imports executed successfully")
+	return nil
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func AlterAchievementSLAStage(db *sql.DB) error {
+	query := `
+-- SLA dihitung per tahap persetujuan: penanda menyimpan waktu tahap aktif mulai
+-- menunggu (waktu submit untuk tahap pertama), bukan lagi waktu submit
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'achievement_sla' AND column_name = 'submitted_at'
+    ) THEN
+        ALTER TABLE achievement_sla RENAME COLUMN submitted_at TO started_at;
+    END IF;
+END $$;
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 014_alter_achievement_sla_stage executed successfully")
+	return nil
+}
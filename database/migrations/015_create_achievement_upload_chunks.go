@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func CreateAchievementUploadChunks(db *sql.DB) error {
+	query := `
+-- Data upload resumable disimpan per chunk di storage lampiran (bukan disk
+-- lokal) agar setiap instance API bisa melanjutkan upload yang sama. Chunk
+-- dicatat sebelum ditulis ke storage; committed = sudah menjadi bagian upload.
+CREATE TABLE IF NOT EXISTS achievement_upload_chunks (
+    storage_key VARCHAR(255) PRIMARY KEY,
+    upload_id UUID NOT NULL,
+    chunk_offset BIGINT NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    committed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_upload_chunks_upload ON achievement_upload_chunks(upload_id, chunk_offset);
+
+-- Finalize diklaim lewat kolom ini agar dua request tidak menyimpan lampiran yang sama
+ALTER TABLE achievement_uploads ADD COLUMN IF NOT EXISTS finalizing_at TIMESTAMP;
+`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("migration failed: %v", err)
+	}
+
+	fmt.Println("Migration 015_create_achievement_upload_chunks executed successfully")
+	return nil
+}
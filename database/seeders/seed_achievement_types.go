@@ -0,0 +1,73 @@
+package seeders
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func SeedAchievementTypes(db *sql.DB) error {
+	query := `
+INSERT INTO achievement_types (code, name, description, schema) VALUES
+('competition', 'Kompetisi', 'Lomba / kompetisi akademik maupun non-akademik', '{
+    "required": ["competitionName", "competitionLevel", "eventDate"],
+    "properties": {
+        "competitionName": {"type": "string", "title": "Nama Kompetisi"},
+        "competitionLevel": {"type": "string", "title": "Tingkat", "enum": ["international", "national", "regional", "campus"]},
+        "rank": {"type": "integer", "title": "Peringkat"},
+        "medalType": {"type": "string", "title": "Medali", "enum": ["gold", "silver", "bronze"]},
+        "isTeam": {"type": "boolean", "title": "Beregu"},
+        "organizer": {"type": "string", "title": "Penyelenggara"},
+        "eventDate": {"type": "string", "title": "Tanggal", "format": "date"}
+    }
+}'),
+('publication', 'Publikasi', 'Publikasi ilmiah (jurnal, konferensi, buku)', '{
+    "required": ["publicationType", "publicationTitle", "publisher", "publishedDate"],
+    "properties": {
+        "publicationType": {"type": "string", "title": "Jenis Publikasi", "enum": ["journal", "conference", "book"]},
+        "publicationTitle": {"type": "string", "title": "Judul Publikasi"},
+        "authors": {"type": "string", "title": "Penulis"},
+        "publisher": {"type": "string", "title": "Penerbit"},
+        "issn": {"type": "string", "title": "ISSN/ISBN"},
+        "publishedDate": {"type": "string", "title": "Tanggal Terbit", "format": "date"}
+    }
+}'),
+('organization', 'Organisasi', 'Kepengurusan organisasi', '{
+    "required": ["organizationName", "position", "periodStart"],
+    "properties": {
+        "organizationName": {"type": "string", "title": "Nama Organisasi"},
+        "position": {"type": "string", "title": "Jabatan"},
+        "periodStart": {"type": "string", "title": "Mulai", "format": "date"},
+        "periodEnd": {"type": "string", "title": "Selesai", "format": "date"}
+    }
+}'),
+('certification', 'Sertifikasi', 'Sertifikasi kompetensi / profesi', '{
+    "required": ["certificationName", "issuedBy", "issuedDate"],
+    "properties": {
+        "certificationName": {"type": "string", "title": "Nama Sertifikasi"},
+        "issuedBy": {"type": "string", "title": "Penerbit"},
+        "certificationNumber": {"type": "string", "title": "Nomor Sertifikat"},
+        "issuedDate": {"type": "string", "title": "Tanggal Terbit", "format": "date"},
+        "validUntil": {"type": "string", "title": "Berlaku Hingga", "format": "date"}
+    }
+}'),
+('community_service', 'Pengabdian Masyarakat', 'Kegiatan pengabdian kepada masyarakat', '{
+    "required": ["activityName", "location", "eventDate"],
+    "properties": {
+        "activityName": {"type": "string", "title": "Nama Kegiatan"},
+        "location": {"type": "string", "title": "Lokasi"},
+        "role": {"type": "string", "title": "Peran"},
+        "hours": {"type": "integer", "title": "Jumlah Jam"},
+        "eventDate": {"type": "string", "title": "Tanggal", "format": "date"}
+    }
+}')
+ON CONFLICT (code) DO NOTHING;
+`
+
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("seeding failed: %v", err)
+	}
+
+	fmt.Println("Seeder seed_achievement_types executed successfully")
+	return nil
+}
@@ -0,0 +1,29 @@
+package seeders
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func SeedApprovalChains(db *sql.DB) error {
+	query := `
+-- Kompetisi nasional & internasional: dosen wali lalu kaprodi program studi mahasiswa.
+-- Prestasi lain tanpa rantai cukup diverifikasi dosen wali.
+INSERT INTO approval_chains (id, achievement_type, competition_level, stages, description) VALUES
+('950e8400-e29b-41d4-a716-446655440001', 'competition', 'national',
+ '[{"name": "Dosen Wali", "role": "Dosen Wali", "scope": "advisor"}, {"name": "Kaprodi", "role": "Kaprodi", "scope": "program_study"}]'::jsonb,
+ 'Kompetisi nasional: persetujuan dosen wali dan kaprodi'),
+('950e8400-e29b-41d4-a716-446655440002', 'competition', 'international',
+ '[{"name": "Dosen Wali", "role": "Dosen Wali", "scope": "advisor"}, {"name": "Kaprodi", "role": "Kaprodi", "scope": "program_study"}]'::jsonb,
+ 'Kompetisi internasional: persetujuan dosen wali dan kaprodi')
+ON CONFLICT (id) DO NOTHING;
+`
+
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("seeding failed: %v", err)
+	}
+
+	fmt.Println("Seeder seed_approval_chains executed successfully")
+	return nil
+}
@@ -0,0 +1,37 @@
+package seeders
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func SeedPointRules(db *sql.DB) error {
+	query := `
+-- Default: kompetisi berdasarkan tingkat
+INSERT INTO point_rules (id, achievement_type, competition_level, rank, is_team, points, description) VALUES
+('850e8400-e29b-41d4-a716-446655440001', 'competition', 'international', NULL, NULL, 50, 'Kompetisi tingkat internasional'),
+('850e8400-e29b-41d4-a716-446655440002', 'competition', 'national', NULL, NULL, 30, 'Kompetisi tingkat nasional'),
+('850e8400-e29b-41d4-a716-446655440003', 'competition', 'regional', NULL, NULL, 20, 'Kompetisi tingkat regional'),
+('850e8400-e29b-41d4-a716-446655440004', 'competition', 'campus', NULL, NULL, 10, 'Kompetisi tingkat kampus')
+ON CONFLICT (id) DO NOTHING;
+
+-- Juara 1 mendapat poin lebih tinggi
+INSERT INTO point_rules (id, achievement_type, competition_level, rank, is_team, points, description) VALUES
+('850e8400-e29b-41d4-a716-446655440005', 'competition', 'international', 1, NULL, 100, 'Juara 1 internasional'),
+('850e8400-e29b-41d4-a716-446655440006', 'competition', 'national', 1, NULL, 60, 'Juara 1 nasional')
+ON CONFLICT (id) DO NOTHING;
+
+-- Fallback untuk semua jenis prestasi lain
+INSERT INTO point_rules (id, achievement_type, competition_level, rank, is_team, points, description) VALUES
+('850e8400-e29b-41d4-a716-446655440099', NULL, NULL, NULL, NULL, 5, 'Poin dasar prestasi')
+ON CONFLICT (id) DO NOTHING;
+`
+
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("seeding failed: %v", err)
+	}
+
+	fmt.Println("Seeder seed_point_rules executed successfully")
+	return nil
+}
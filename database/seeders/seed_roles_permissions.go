@@ -20,6 +20,10 @@ INSERT INTO roles (id, name, description) VALUES
 ('550e8400-e29b-41d4-a716-446655440003', 'Dosen Wali', 'Dosen wali yang memverifikasi prestasi')
 ON CONFLICT (id) DO NOTHING;
 
+INSERT INTO roles (id, name, description) VALUES
+('550e8400-e29b-41d4-a716-446655440004', 'Kaprodi', 'Ketua program studi, menyetujui tahap lanjutan rantai persetujuan')
+ON CONFLICT (id) DO NOTHING;
+
 -- Seed permissions
 INSERT INTO permissions (id, name, resource, action, description) VALUES
 ('650e8400-e29b-41d4-a716-446655440001', 'achievement:create', 'achievement', 'create', 'Membuat prestasi baru')
@@ -45,6 +49,10 @@ INSERT INTO permissions (id, name, resource, action, description) VALUES
 ('650e8400-e29b-41d4-a716-446655440006', 'user:manage', 'user', 'manage', 'Mengelola user')
 ON CONFLICT (id) DO NOTHING;
 
+INSERT INTO permissions (id, name, resource, action, description) VALUES
+('650e8400-e29b-41d4-a716-446655440007', 'achievement:configure', 'achievement', 'configure', 'Mengelola konfigurasi prestasi (aturan poin, dll)')
+ON CONFLICT (id) DO NOTHING;
+
 -- Admin: full access
 INSERT INTO role_permissions (role_id, permission_id) VALUES
 ('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440001'),
@@ -52,7 +60,8 @@ INSERT INTO role_permissions (role_id, permission_id) VALUES
 ('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440003'),
 ('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440004'),
 ('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440005'),
-('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440006')
+('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440006'),
+('550e8400-e29b-41d4-a716-446655440001', '650e8400-e29b-41d4-a716-446655440007')
 ON CONFLICT DO NOTHING;
 
 -- Mahasiswa
@@ -68,6 +77,12 @@ INSERT INTO role_permissions (role_id, permission_id) VALUES
 ('550e8400-e29b-41d4-a716-446655440003', '650e8400-e29b-41d4-a716-446655440005')
 ON CONFLICT DO NOTHING;
 
+-- Kaprodi
+INSERT INTO role_permissions (role_id, permission_id) VALUES
+('550e8400-e29b-41d4-a716-446655440004', '650e8400-e29b-41d4-a716-446655440002'),
+('550e8400-e29b-41d4-a716-446655440004', '650e8400-e29b-41d4-a716-446655440005')
+ON CONFLICT DO NOTHING;
+
 -- Mahasiswa
 INSERT INTO users (id, username, email, password_hash, full_name, role_id, is_active)
 VALUES (
@@ -0,0 +1,93 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+
+	"go-fiber/app/model"
+)
+
+// DetailString mengambil nilai string dari details achievement
+func DetailString(details map[string]interface{}, key string) (string, bool) {
+	v, ok := details[key]
+	if !ok || v == nil {
+		return "", false
+	}
+	str, ok := v.(string)
+	if !ok {
+		return "", false
+	}
+	str = strings.TrimSpace(str)
+	return str, str != ""
+}
+
+// DetailInt mengambil nilai integer dari details (angka JSON atau string angka)
+func DetailInt(details map[string]interface{}, key string) (int, bool) {
+	switch v := details[key].(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// DetailBool mengambil nilai boolean dari details
+func DetailBool(details map[string]interface{}, key string) (bool, bool) {
+	switch v := details[key].(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, err == nil
+	}
+	return false, false
+}
+
+// CoerceDetails mengubah nilai details berupa teks (mis. dari sel CSV / XLSX)
+// ke tipe di skema jenis prestasi: angka menjadi float64 seperti hasil decode
+// JSON, boolean menerima true/false, ya/tidak, yes/no, dan field tanggal
+// dinormalkan ke YYYY-MM-DD. Nilai yang tidak bisa diubah dibiarkan sebagai
+// string agar dilaporkan oleh ValidateAchievementDetails; sel kosong dilewati.
+func CoerceDetails(schema model.DetailSchema, raw map[string]string) map[string]interface{} {
+	details := map[string]interface{}{}
+	for name, value := range raw {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		details[name] = value
+
+		prop, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		switch prop.Type {
+		case "number", "integer":
+			if f, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64); err == nil {
+				details[name] = f
+			}
+		case "boolean":
+			switch strings.ToLower(value) {
+			case "true", "1", "ya", "yes", "y":
+				details[name] = true
+			case "false", "0", "tidak", "no", "n":
+				details[name] = false
+			}
+		case "string":
+			if prop.Format == "date" {
+				if t, err := ParseTableDate(value); err == nil {
+					details[name] = t.Format("2006-01-02")
+				}
+			}
+		}
+	}
+	return details
+}
@@ -0,0 +1,346 @@
+package helper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// Format file ekspor
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+	ExportPDF  = "pdf"
+)
+
+var exportContentTypes = map[string]string{
+	ExportCSV:  "text/csv; charset=utf-8",
+	ExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ExportPDF:  "application/pdf",
+}
+
+// ExportContentType Content-Type untuk format ekspor; false jika format tidak dikenal
+func ExportContentType(format string) (string, bool) {
+	ct, ok := exportContentTypes[format]
+	return ct, ok
+}
+
+// TableWriter menulis satu atau beberapa tabel (section) ke file ekspor.
+// CSV ditulis langsung ke writer; XLSX memakai stream writer excelize dan
+// dikirim saat Close; PDF dibangun di memori dan dikirim saat Close.
+type TableWriter interface {
+	// Section memulai tabel baru: sheet baru di XLSX, judul dan header di PDF,
+	// baris kosong lalu header di CSV. widths lebar relatif kolom (khusus PDF).
+	Section(name string, headers []string, widths ...float64) error
+	Row(values ...interface{}) error
+	// Flush mengirim baris CSV yang sudah ditulis ke writer
+	Flush() error
+	Close() error
+}
+
+// NewTableWriter membuat TableWriter untuk format; title dipakai sebagai judul dokumen PDF
+func NewTableWriter(format string, w io.Writer, title string) (TableWriter, error) {
+	switch format {
+	case ExportCSV:
+		return &csvTableWriter{w: csv.NewWriter(w)}, nil
+	case ExportXLSX:
+		return newXLSXTableWriter(w)
+	case ExportPDF:
+		return newPDFTableWriter(w, title), nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// FormatCell nilai sel sebagai teks: waktu dalam waktu lokal, nil sebagai sel kosong
+func FormatCell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Local().Format("2006-01-02 15:04:05")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return FormatCell(*v)
+	}
+	return fmt.Sprint(v)
+}
+
+// EscapeCSVCell mencegah CSV/formula injection: teks yang diawali =, +, -, @,
+// tab atau CR diberi awalan ' agar tidak dijalankan sebagai formula saat file
+// dibuka di spreadsheet
+func EscapeCSVCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+type csvTableWriter struct {
+	w        *csv.Writer
+	sections int
+}
+
+func (t *csvTableWriter) Section(name string, headers []string, widths ...float64) error {
+	if t.sections > 0 {
+		if err := t.w.Write([]string{}); err != nil {
+			return err
+		}
+	}
+	t.sections++
+	return t.w.Write(headers)
+}
+
+// Row menulis satu baris; hanya nilai teks yang di-escape agar angka negatif tetap angka
+func (t *csvTableWriter) Row(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case int, float64:
+			record[i] = FormatCell(v)
+		default:
+			record[i] = EscapeCSVCell(FormatCell(v))
+		}
+	}
+	return t.w.Write(record)
+}
+
+func (t *csvTableWriter) Flush() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+func (t *csvTableWriter) Close() error {
+	return t.Flush()
+}
+
+type xlsxTableWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	bold   int
+	row    int
+}
+
+func newXLSXTableWriter(w io.Writer) (*xlsxTableWriter, error) {
+	f := excelize.NewFile()
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &xlsxTableWriter{out: w, file: f, bold: bold}, nil
+}
+
+// sheetName nama sheet yang valid: tanpa karakter []:*?/\ dan maksimal 31 karakter
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+	return name
+}
+
+func (t *xlsxTableWriter) Section(name string, headers []string, widths ...float64) error {
+	name = sheetName(name)
+	if t.stream == nil {
+		// Sheet bawaan file baru dipakai untuk section pertama
+		if err := t.file.SetSheetName(t.file.GetSheetName(0), name); err != nil {
+			return err
+		}
+	} else {
+		if err := t.stream.Flush(); err != nil {
+			return err
+		}
+		if _, err := t.file.NewSheet(name); err != nil {
+			return err
+		}
+	}
+
+	stream, err := t.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	t.stream, t.row = stream, 1
+
+	cells := make([]interface{}, len(headers))
+	for i, h := range headers {
+		cells[i] = h
+	}
+	return t.writeRow(cells, excelize.RowOpts{StyleID: t.bold})
+}
+
+// Row menulis angka sebagai angka dan nilai lain sebagai teks. Teks ditulis
+// sebagai inline string, jadi isi seperti "=HYPERLINK(...)" tidak pernah
+// menjadi formula.
+func (t *xlsxTableWriter) Row(values ...interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case int, float64:
+			cells[i] = v
+		default:
+			cells[i] = FormatCell(v)
+		}
+	}
+	return t.writeRow(cells)
+}
+
+func (t *xlsxTableWriter) writeRow(cells []interface{}, opts ...excelize.RowOpts) error {
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+	t.row++
+	return t.stream.SetRow(cell, cells, opts...)
+}
+
+func (t *xlsxTableWriter) Flush() error {
+	return nil
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.file.Close()
+	if t.stream != nil {
+		if err := t.stream.Flush(); err != nil {
+			return err
+		}
+	}
+	return t.file.Write(t.out)
+}
+
+// Ukuran tabel PDF (mm, A4 landscape)
+const (
+	pdfMargin     = 10.0
+	pdfRowHeight  = 6.0
+	pdfFontSize   = 8.0
+	pdfPageHeight = 210.0
+)
+
+type pdfTableWriter struct {
+	out       io.Writer
+	pdf       *fpdf.Fpdf
+	tr        func(string) string
+	headers   []string
+	widths    []float64
+	hasHeader bool
+}
+
+func newPDFTableWriter(w io.Writer, title string) *pdfTableWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont("Helvetica", "I", pdfFontSize)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s - %d/{nb}", time.Now().Format("2006-01-02 15:04"), pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	t := &pdfTableWriter{out: w, pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, t.tr(title), "", 1, "L", false, 0, "")
+	return t
+}
+
+func (t *pdfTableWriter) Section(name string, headers []string, widths ...float64) error {
+	pageWidth, _ := t.pdf.GetPageSize()
+	available := pageWidth - 2*pdfMargin
+
+	total := 0.0
+	t.widths = make([]float64, len(headers))
+	for i := range headers {
+		t.widths[i] = 1
+		if i < len(widths) && widths[i] > 0 {
+			t.widths[i] = widths[i]
+		}
+		total += t.widths[i]
+	}
+	for i := range t.widths {
+		t.widths[i] = t.widths[i] / total * available
+	}
+	t.headers = headers
+
+	// Judul section tidak dibiarkan sendirian di bawah halaman
+	if t.pdf.GetY()+4*pdfRowHeight > pdfPageHeight-2*pdfMargin {
+		t.pdf.AddPage()
+	} else if t.hasHeader {
+		t.pdf.Ln(pdfRowHeight)
+	}
+	t.pdf.SetFont("Helvetica", "B", 11)
+	t.pdf.CellFormat(0, 8, t.tr(name), "", 1, "L", false, 0, "")
+	t.writeHeader()
+	t.hasHeader = true
+	return t.pdf.Error()
+}
+
+func (t *pdfTableWriter) writeHeader() {
+	t.pdf.SetFont("Helvetica", "B", pdfFontSize)
+	t.pdf.SetFillColor(230, 230, 230)
+	for i, h := range t.headers {
+		t.pdf.CellFormat(t.widths[i], pdfRowHeight, t.fit(h, t.widths[i]), "1", 0, "L", true, 0, "")
+	}
+	t.pdf.Ln(-1)
+	t.pdf.SetFont("Helvetica", "", pdfFontSize)
+}
+
+// fit memotong teks yang lebih lebar dari kolom dan menambahkan "..."
+func (t *pdfTableWriter) fit(text string, width float64) string {
+	text = t.tr(strings.Join(strings.Fields(text), " "))
+	max := width - 2
+	if t.pdf.GetStringWidth(text) <= max {
+		return text
+	}
+	for len(text) > 0 && t.pdf.GetStringWidth(text+"...") > max {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+func (t *pdfTableWriter) Row(values ...interface{}) error {
+	if t.pdf.GetY()+pdfRowHeight > pdfPageHeight-2*pdfMargin {
+		t.pdf.AddPage()
+		t.writeHeader()
+	}
+	for i, v := range values {
+		if i >= len(t.widths) {
+			break
+		}
+		align := "L"
+		switch v.(type) {
+		case int, float64:
+			align = "R"
+		}
+		t.pdf.CellFormat(t.widths[i], pdfRowHeight, t.fit(FormatCell(v), t.widths[i]), "1", 0, align, false, 0, "")
+	}
+	t.pdf.Ln(-1)
+	return t.pdf.Error()
+}
+
+func (t *pdfTableWriter) Flush() error {
+	return nil
+}
+
+func (t *pdfTableWriter) Close() error {
+	return t.pdf.Output(t.out)
+}
@@ -0,0 +1,121 @@
+package helper_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+
+	"go-fiber/helper"
+)
+
+// Test TableWriter CSV - section berikutnya dipisah baris kosong, nil dan waktu diformat
+func TestTableWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := helper.NewTableWriter(helper.ExportCSV, &buf, "Prestasi")
+	assert.NoError(t, err)
+
+	at := time.Date(2024, 1, 15, 8, 30, 0, 0, time.Local)
+	assert.NoError(t, table.Section("Prestasi", []string{"Judul", "Poin", "Diverifikasi"}))
+	assert.NoError(t, table.Row("Lomba Debat, Nasional", 100, &at))
+	assert.NoError(t, table.Row("Gemastik", 50, (*time.Time)(nil)))
+	assert.NoError(t, table.Section("Ringkasan", []string{"Status", "Jumlah"}))
+	assert.NoError(t, table.Row("verified", 2))
+	assert.NoError(t, table.Close())
+
+	assert.Equal(t, "Judul,Poin,Diverifikasi\n"+
+		"\"Lomba Debat, Nasional\",100,2024-01-15 08:30:00\n"+
+		"Gemastik,50,\n"+
+		"\n"+
+		"Status,Jumlah\n"+
+		"verified,2\n", buf.String())
+}
+
+// Test TableWriter XLSX - satu sheet per section, bisa dibaca kembali oleh ReadTable
+func TestTableWriter_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := helper.NewTableWriter(helper.ExportXLSX, &buf, "Statistik")
+	assert.NoError(t, err)
+
+	assert.NoError(t, table.Section("By Status", []string{"Status", "Count"}))
+	assert.NoError(t, table.Row("verified", 3))
+	assert.NoError(t, table.Section("Top Students: Points", []string{"NIM", "Points"}))
+	assert.NoError(t, table.Row("2201", 150))
+	assert.NoError(t, table.Close())
+
+	data := buf.Bytes()
+	headers, rows, err := helper.ReadTable("statistik.xlsx", bytes.NewReader(data), "By Status")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Status", "Count"}, headers)
+	assert.Equal(t, [][]string{{"verified", "3"}}, rows)
+
+	// Karakter yang tidak boleh di nama sheet diganti
+	headers, rows, err = helper.ReadTable("statistik.xlsx", bytes.NewReader(data), "Top Students- Points")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NIM", "Points"}, headers)
+	assert.Equal(t, [][]string{{"2201", "150"}}, rows)
+}
+
+// Test TableWriter CSV - teks yang diawali karakter formula di-escape, angka negatif tidak
+func TestTableWriter_CSVFormulaInjection(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := helper.NewTableWriter(helper.ExportCSV, &buf, "Prestasi")
+	assert.NoError(t, err)
+
+	assert.NoError(t, table.Section("Prestasi", []string{"Judul", "Poin"}))
+	assert.NoError(t, table.Row(`=HYPERLINK("http://x","y")`, -5))
+	assert.NoError(t, table.Row("+62 812", 0))
+	assert.NoError(t, table.Row("@SUM(A1)", 1))
+	assert.NoError(t, table.Row("\tjudul", 2))
+	assert.NoError(t, table.Close())
+
+	assert.Equal(t, "Judul,Poin\n"+
+		"\"'=HYPERLINK(\"\"http://x\"\",\"\"y\"\")\",-5\n"+
+		"'+62 812,0\n"+
+		"'@SUM(A1),1\n"+
+		"'\tjudul,2\n", buf.String())
+}
+
+// Test TableWriter XLSX - teks yang mirip formula ditulis sebagai teks, bukan formula
+func TestTableWriter_XLSXFormulaAsText(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := helper.NewTableWriter(helper.ExportXLSX, &buf, "Prestasi")
+	assert.NoError(t, err)
+	assert.NoError(t, table.Section("Prestasi", []string{"Judul"}))
+	assert.NoError(t, table.Row("=1+1"))
+	assert.NoError(t, table.Close())
+
+	f, err := excelize.OpenReader(&buf)
+	assert.NoError(t, err)
+	defer f.Close()
+	formula, err := f.GetCellFormula("Prestasi", "A2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	value, err := f.GetCellValue("Prestasi", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "=1+1", value)
+}
+
+// Test TableWriter PDF - tabel panjang berlanjut ke halaman berikutnya
+func TestTableWriter_PDF(t *testing.T) {
+	var buf bytes.Buffer
+	table, err := helper.NewTableWriter(helper.ExportPDF, &buf, "Prestasi Mahasiswa")
+	assert.NoError(t, err)
+
+	assert.NoError(t, table.Section("Prestasi", []string{"Judul", "Poin"}, 4, 1))
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, table.Row("Juara 1 Lomba Karya Tulis Ilmiah Tingkat Nasional – Universitas Indonesia", i))
+	}
+	assert.NoError(t, table.Close())
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Contains(t, buf.String(), "/Count 4")
+}
+
+// Test NewTableWriter - format tidak dikenal
+func TestNewTableWriter_Unsupported(t *testing.T) {
+	_, err := helper.NewTableWriter("xls", &bytes.Buffer{}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Panjang maksimum nama file lampiran setelah disanitasi
+const maxFilenameLength = 120
+
+// HashSHA256 menghitung SHA-256 (hex) dari isi file
+func HashSHA256(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SanitizeFilename membuang path dan karakter selain huruf, angka, ".", "-", "_"
+// dari nama file kiriman client, dan membatasi panjangnya
+func SanitizeFilename(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	name = strings.TrimLeft(name, ".")
+	if len(name) > maxFilenameLength {
+		ext := path.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:maxFilenameLength-len(ext)], "") + ext
+	}
+	if name == "" {
+		name = "file"
+	}
+	return name
+}
+
+// SniffContentType menentukan tipe file dari isinya (512 byte pertama),
+// bukan dari header Content-Type kiriman client
+func SniffContentType(r io.Reader) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	return contentType, nil
+}
+
+// AttachmentKey membuat key storage unik untuk lampiran sebuah prestasi:
+// achievements/<mongoId>/<uuid>_<nama file aman>
+func AttachmentKey(achievementID, filename string) string {
+	return "achievements/" + achievementID + "/" + uuid.New().String() + "_" + SanitizeFilename(filename)
+}
+
+// BlobKey key storage isi file berdasarkan hash SHA-256-nya:
+// blobs/sha256/<2 karakter pertama>/<hash>
+func BlobKey(sum string) string {
+	return "blobs/sha256/" + sum[:2] + "/" + sum
+}
+
+// ParseTusMetadata mengurai header Upload-Metadata protokol tus:
+// pasangan "key base64value" dipisah koma; value boleh kosong
+func ParseTusMetadata(header string) (map[string]string, error) {
+	meta := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata %q: %v", key, err)
+		}
+		meta[key] = string(value)
+	}
+	return meta, nil
+}
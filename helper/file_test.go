@@ -0,0 +1,37 @@
+package helper_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/helper"
+)
+
+// Test SanitizeFilename - path, karakter khusus dan nama kosong
+func TestSanitizeFilename(t *testing.T) {
+	assert.Equal(t, "sertifikat_lomba.pdf", helper.SanitizeFilename("sertifikat lomba.pdf"))
+	assert.Equal(t, "passwd", helper.SanitizeFilename("../../etc/passwd"))
+	assert.Equal(t, "evil.pdf", helper.SanitizeFilename(`C:\Users\x\evil.pdf`))
+	assert.Equal(t, "htaccess", helper.SanitizeFilename(".htaccess"))
+	assert.Equal(t, "file", helper.SanitizeFilename(".."))
+
+	long := helper.SanitizeFilename(strings.Repeat("a", 300) + ".pdf")
+	assert.Len(t, long, 120)
+	assert.True(t, strings.HasSuffix(long, ".pdf"))
+}
+
+// Test ParseTusMetadata - value base64, value kosong dan base64 tidak valid
+func TestParseTusMetadata(t *testing.T) {
+	meta, err := helper.ParseTusMetadata("filename c2VydGlmaWthdC5wZGY=,category Y2VydGlmaWNhdGU=,is_confidential")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"filename":        "sertifikat.pdf",
+		"category":        "certificate",
+		"is_confidential": "",
+	}, meta)
+
+	_, err = helper.ParseTusMetadata("filename !!!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"reflect"
+	"sort"
+
+	"go-fiber/app/model"
+)
+
+// DiffRevisions membandingkan dua revisi field per field. Details dibandingkan
+// per key ("details.<key>"); key yang hanya ada di satu sisi bernilai nil di sisi lain.
+func DiffRevisions(from, to *model.AchievementRevision) []model.FieldChange {
+	changes := []model.FieldChange{}
+
+	add := func(field string, old, new interface{}) {
+		if !reflect.DeepEqual(old, new) {
+			changes = append(changes, model.FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("achievementType", from.AchievementType, to.AchievementType)
+	add("title", from.Title, to.Title)
+	add("description", from.Description, to.Description)
+	add("tags", normalizeTags(from.Tags), normalizeTags(to.Tags))
+
+	keys := map[string]bool{}
+	for k := range from.Details {
+		keys[k] = true
+	}
+	for k := range to.Details {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		add("details."+k, from.Details[k], to.Details[k])
+	}
+
+	return changes
+}
+
+// normalizeTags menyamakan nil dan slice kosong
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
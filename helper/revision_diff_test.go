@@ -0,0 +1,43 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/helper"
+)
+
+// Test DiffRevisions - perubahan title, tags dan details per key
+func TestDiffRevisions_Changes(t *testing.T) {
+	from := &model.AchievementRevision{
+		Revision: 1,
+		Title:    "Juara 2 Hackathon",
+		Tags:     []string{"it"},
+		Details:  map[string]interface{}{"rank": float64(2), "organizer": "Kominfo"},
+	}
+	to := &model.AchievementRevision{
+		Revision: 2,
+		Title:    "Juara 1 Hackathon",
+		Tags:     []string{"it"},
+		Details:  map[string]interface{}{"rank": float64(1), "isTeam": true},
+	}
+
+	changes := helper.DiffRevisions(from, to)
+
+	assert.Equal(t, []model.FieldChange{
+		{Field: "title", Old: "Juara 2 Hackathon", New: "Juara 1 Hackathon"},
+		{Field: "details.isTeam", Old: nil, New: true},
+		{Field: "details.organizer", Old: "Kominfo", New: nil},
+		{Field: "details.rank", Old: float64(2), New: float64(1)},
+	}, changes)
+}
+
+// Test DiffRevisions - revisi identik (tags nil vs kosong dianggap sama)
+func TestDiffRevisions_NoChanges(t *testing.T) {
+	from := &model.AchievementRevision{Title: "Sertifikasi AWS", Tags: nil}
+	to := &model.AchievementRevision{Title: "Sertifikasi AWS", Tags: []string{}}
+
+	assert.Empty(t, helper.DiffRevisions(from, to))
+}
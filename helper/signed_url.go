@@ -0,0 +1,64 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ErrNoSigningSecret ATTACHMENT_URL_SECRET dan JWT_SECRET sama-sama kosong;
+// signature dengan key kosong bisa dipalsukan siapa saja
+var ErrNoSigningSecret = errors.New("ATTACHMENT_URL_SECRET or JWT_SECRET must be set to sign attachment urls")
+
+// signingSecret: ATTACHMENT_URL_SECRET, fallback ke JWT_SECRET
+func signingSecret() []byte {
+	if secret := os.Getenv("ATTACHMENT_URL_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte(os.Getenv("JWT_SECRET"))
+}
+
+// CheckSigningSecret dipanggil saat startup agar server tidak berjalan tanpa key
+func CheckSigningSecret() error {
+	if len(signingSecret()) == 0 {
+		return ErrNoSigningSecret
+	}
+	return nil
+}
+
+func attachmentMAC(secret []byte, refID, attachmentID string, expires int64) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(refID + "/" + attachmentID + "/" + strconv.FormatInt(expires, 10)))
+	return mac.Sum(nil)
+}
+
+// SignAttachment membuat signature HMAC-SHA256 (hex) untuk URL lampiran yang berlaku sampai expires
+func SignAttachment(refID, attachmentID string, expires time.Time) (string, error) {
+	secret := signingSecret()
+	if len(secret) == 0 {
+		return "", ErrNoSigningSecret
+	}
+	return hex.EncodeToString(attachmentMAC(secret, refID, attachmentID, expires.Unix())), nil
+}
+
+// VerifyAttachmentSignature memeriksa signature dan masa berlaku URL lampiran.
+// Tanpa key semua signature ditolak.
+func VerifyAttachmentSignature(refID, attachmentID, expires, signature string) bool {
+	secret := signingSecret()
+	if len(secret) == 0 {
+		return false
+	}
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return false
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sig, attachmentMAC(secret, refID, attachmentID, exp))
+}
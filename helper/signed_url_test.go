@@ -0,0 +1,50 @@
+package helper_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/helper"
+)
+
+// Test VerifyAttachmentSignature - signature valid, diubah, atau sudah kedaluwarsa
+func TestVerifyAttachmentSignature(t *testing.T) {
+	t.Setenv("ATTACHMENT_URL_SECRET", "rahasia")
+
+	expires := time.Now().Add(time.Minute)
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	sig, err := helper.SignAttachment("ref-1", "att-1", expires)
+	assert.NoError(t, err)
+
+	assert.True(t, helper.VerifyAttachmentSignature("ref-1", "att-1", exp, sig))
+	assert.False(t, helper.VerifyAttachmentSignature("ref-1", "att-2", exp, sig))
+	assert.False(t, helper.VerifyAttachmentSignature("ref-1", "att-1", strconv.FormatInt(expires.Unix()+60, 10), sig))
+
+	past := time.Now().Add(-time.Minute)
+	pastSig, err := helper.SignAttachment("ref-1", "att-1", past)
+	assert.NoError(t, err)
+	assert.False(t, helper.VerifyAttachmentSignature("ref-1", "att-1", strconv.FormatInt(past.Unix(), 10), pastSig))
+}
+
+// Test SignAttachment - tanpa ATTACHMENT_URL_SECRET maupun JWT_SECRET tidak ada yang ditandatangani
+func TestSignAttachment_NoSecret(t *testing.T) {
+	t.Setenv("ATTACHMENT_URL_SECRET", "")
+	t.Setenv("JWT_SECRET", "")
+
+	expires := time.Now().Add(time.Minute)
+	_, err := helper.SignAttachment("ref-1", "att-1", expires)
+	assert.ErrorIs(t, err, helper.ErrNoSigningSecret)
+	assert.ErrorIs(t, helper.CheckSigningSecret(), helper.ErrNoSigningSecret)
+
+	// signature dari HMAC dengan key kosong tetap ditolak
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	mac := hmac.New(sha256.New, nil)
+	mac.Write([]byte("ref-1/att-1/" + exp))
+	assert.False(t, helper.VerifyAttachmentSignature("ref-1", "att-1", exp, hex.EncodeToString(mac.Sum(nil))))
+}
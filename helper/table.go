@@ -0,0 +1,107 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnsupportedTable format file tabel selain CSV / XLSX
+var ErrUnsupportedTable = errors.New("unsupported file type, use .csv or .xlsx")
+
+// ReadTable membaca file tabel (CSV atau XLSX, dilihat dari ekstensi nama file)
+// menjadi baris header dan baris data. sheet hanya dipakai untuk XLSX; kosong =
+// sheet pertama. Nilai sel XLSX dibaca mentah (tanggal sebagai serial Excel).
+func ReadTable(fileName string, r io.Reader, sheet string) ([]string, [][]string, error) {
+	var rows [][]string
+	var err error
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		rows, err = readCSV(r)
+	case ".xlsx":
+		rows, err = readXLSX(r, sheet)
+	default:
+		return nil, nil, ErrUnsupportedTable
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, errors.New("file has no header row")
+	}
+
+	headers := make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		headers[i] = strings.TrimSpace(h)
+	}
+	return headers, rows[1:], nil
+}
+
+// readCSV membaca CSV dengan pemisah "," atau ";" (ekspor Excel berlokal
+// Indonesia memakai ";"), ditentukan dari baris header
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	header := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		header = data[:i]
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %v", err)
+	}
+	return rows, nil
+}
+
+func readXLSX(r io.Reader, sheet string) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx: %v", err)
+	}
+	defer f.Close()
+
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	if idx, err := f.GetSheetIndex(sheet); err != nil || idx < 0 {
+		return nil, fmt.Errorf("sheet %q not found", sheet)
+	}
+	return f.GetRows(sheet, excelize.Options{RawCellValue: true})
+}
+
+// ParseTableDate membaca tanggal dari sel tabel: YYYY-MM-DD, DD/MM/YYYY,
+// RFC3339, atau serial tanggal Excel
+func ParseTableDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "02/01/2006", time.RFC3339, "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+		}
+	}
+	return time.Time{}, errors.New("must be a date (YYYY-MM-DD)")
+}
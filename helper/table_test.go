@@ -0,0 +1,77 @@
+package helper_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+
+	"go-fiber/app/model"
+	"go-fiber/helper"
+)
+
+// Test ReadTable - CSV berpemisah titik koma dengan BOM dari Excel
+func TestReadTable_CSVSemicolon(t *testing.T) {
+	data := "\xef\xbb\xbfNIM;Nama Lomba;Juara\n2201;Lomba Debat, Nasional;1\n2202;Gemastik;2\n"
+
+	headers, rows, err := helper.ReadTable("prestasi.CSV", strings.NewReader(data), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NIM", "Nama Lomba", "Juara"}, headers)
+	assert.Equal(t, [][]string{
+		{"2201", "Lomba Debat, Nasional", "1"},
+		{"2202", "Gemastik", "2"},
+	}, rows)
+}
+
+// Test ReadTable - XLSX dibaca dari sheet yang dipilih
+func TestReadTable_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	f.NewSheet("Prestasi")
+	f.SetSheetRow("Prestasi", "A1", &[]interface{}{"NIM", "Judul"})
+	f.SetSheetRow("Prestasi", "A2", &[]interface{}{"2201", "Gemastik"})
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+
+	headers, rows, err := helper.ReadTable("prestasi.xlsx", &buf, "Prestasi")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NIM", "Judul"}, headers)
+	assert.Equal(t, [][]string{{"2201", "Gemastik"}}, rows)
+}
+
+// Test ReadTable - ekstensi selain csv / xlsx ditolak
+func TestReadTable_Unsupported(t *testing.T) {
+	_, _, err := helper.ReadTable("prestasi.xls", strings.NewReader(""), "")
+	assert.ErrorIs(t, err, helper.ErrUnsupportedTable)
+}
+
+// Test CoerceDetails - teks sel diubah sesuai tipe di skema
+func TestCoerceDetails(t *testing.T) {
+	schema := model.DetailSchema{Properties: map[string]model.DetailSchemaProperty{
+		"rank":      {Type: "integer"},
+		"score":     {Type: "number"},
+		"isTeam":    {Type: "boolean"},
+		"eventDate": {Type: "string", Format: "date"},
+		"organizer": {Type: "string"},
+	}}
+
+	details := helper.CoerceDetails(schema, map[string]string{
+		"rank":      "1",
+		"score":     "87,5",
+		"isTeam":    "Ya",
+		"eventDate": "45306", // serial tanggal Excel
+		"organizer": " Kemendikbud ",
+		"extra":     "",
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"rank":      float64(1),
+		"score":     87.5,
+		"isTeam":    true,
+		"eventDate": "2024-01-15",
+		"organizer": "Kemendikbud",
+	}, details)
+}
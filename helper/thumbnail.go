@@ -0,0 +1,129 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+// Batas jumlah piksel gambar yang mau didecode untuk thumbnail. Gambar
+// didecode utuh ke memori (±4 byte per piksel), jadi dimensi dicek lebih dulu
+// dari header.
+const maxThumbnailPixels = 25_000_000
+
+// ErrImageTooLarge dimensi gambar melebihi maxThumbnailPixels
+var ErrImageTooLarge = errors.New("image dimensions too large")
+
+// checkImageSize membaca dimensi dari header gambar tanpa mendecode pikselnya
+func checkImageSize(r io.Reader) error {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxThumbnailPixels {
+		return fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+	return nil
+}
+
+// ImageThumbnail memperkecil gambar (JPEG/PNG) agar muat dalam kotak size x size,
+// mengikuti orientasi EXIF, dan mengembalikannya sebagai JPEG. Gambar di atas
+// maxThumbnailPixels ditolak dengan ErrImageTooLarge.
+func ImageThumbnail(r io.Reader, size int) ([]byte, error) {
+	// Byte yang dibaca DecodeConfig disimpan lalu disambung lagi untuk Decode
+	var header bytes.Buffer
+	if err := checkImageSize(io.TeeReader(r, &header)); err != nil {
+		return nil, err
+	}
+	img, err := imaging.Decode(io.MultiReader(&header, r), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, err
+	}
+	return encodeThumbnail(img, size)
+}
+
+func encodeThumbnail(img image.Image, size int) ([]byte, error) {
+	thumb := imaging.Fit(img, size, size, imaging.Lanczos)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderPDFPreview merender halaman pertama PDF menjadi PNG (sisi terpanjang = size)
+// dengan pdftoppm (poppler-utils) yang dipanggil secara lokal
+func RenderPDFPreview(ctx context.Context, renderer string, r io.Reader, size int) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "pdf-preview-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	f, err := os.Create(input)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	output := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, renderer,
+		"-png", "-f", "1", "-l", "1", "-singlefile",
+		"-scale-to", strconv.Itoa(size),
+		input, output,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, &RenderError{Err: err, Output: stderr.String()}
+	}
+	return os.ReadFile(output + ".png")
+}
+
+// ThumbnailFromPNG membuat thumbnail JPEG dari PNG (mis. hasil RenderPDFPreview)
+func ThumbnailFromPNG(data []byte, size int) ([]byte, error) {
+	if err := checkImageSize(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return encodeThumbnail(img, size)
+}
+
+// RenderError kegagalan renderer eksternal beserta output stderr-nya
+type RenderError struct {
+	Err    error
+	Output string
+}
+
+func (e *RenderError) Error() string {
+	if e.Output == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Output
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
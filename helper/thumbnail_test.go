@@ -0,0 +1,53 @@
+package helper_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/helper"
+)
+
+// Test ImageThumbnail - gambar diperkecil dengan rasio tetap dan dikembalikan sebagai JPEG
+func TestImageThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 1200, 600))
+	for x := 0; x < 1200; x++ {
+		src.Set(x, x%600, color.RGBA{R: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, src))
+
+	thumb, err := helper.ImageThumbnail(&buf, 320)
+	assert.NoError(t, err)
+
+	img, err := jpeg.Decode(bytes.NewReader(thumb))
+	assert.NoError(t, err)
+	assert.Equal(t, 320, img.Bounds().Dx())
+	assert.Equal(t, 160, img.Bounds().Dy())
+}
+
+// Test ImageThumbnail - isi bukan gambar
+func TestImageThumbnail_InvalidImage(t *testing.T) {
+	_, err := helper.ImageThumbnail(bytes.NewReader([]byte("%PDF-1.4")), 320)
+	assert.Error(t, err)
+}
+
+// Test ImageThumbnail - dimensi di header melebihi batas, ditolak sebelum didecode
+func TestImageThumbnail_TooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+	// IHDR: lebar dan tinggi di byte 16-23, CRC chunk di byte 29-32
+	copy(data[16:24], []byte{0, 0, 0x4e, 0x20, 0, 0, 0x4e, 0x20}) // 20000 x 20000
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := helper.ImageThumbnail(bytes.NewReader(data), 320)
+	assert.ErrorIs(t, err, helper.ErrImageTooLarge)
+}
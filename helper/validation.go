@@ -1,6 +1,13 @@
 package helper
 
-import "go-fiber/app/model"
+import (
+    "math"
+    "sort"
+    "strings"
+    "time"
+
+    "go-fiber/app/model"
+)
 
 func ValidateLoginRequest(req *model.LoginRequest) []string {
     var errs []string
@@ -15,3 +22,75 @@ func ValidateLoginRequest(req *model.LoginRequest) []string {
 
     return errs
 }
+
+// ValidateAchievementDetails memvalidasi details prestasi terhadap skema jenis prestasinya
+func ValidateAchievementDetails(schema model.DetailSchema, details map[string]interface{}) []model.ValidationError {
+    var errs []model.ValidationError
+
+    for _, name := range schema.Required {
+        if v, ok := details[name]; !ok || v == nil || v == "" {
+            errs = append(errs, model.ValidationError{
+                Field:   "details." + name,
+                Message: "field is required",
+            })
+        }
+    }
+
+    names := make([]string, 0, len(schema.Properties))
+    for name := range schema.Properties {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    for _, name := range names {
+        prop := schema.Properties[name]
+        v, ok := details[name]
+        if !ok || v == nil || v == "" {
+            continue
+        }
+        if msg := validateDetailValue(prop, v); msg != "" {
+            errs = append(errs, model.ValidationError{
+                Field:   "details." + name,
+                Message: msg,
+            })
+        }
+    }
+
+    return errs
+}
+
+func validateDetailValue(prop model.DetailSchemaProperty, v interface{}) string {
+    switch prop.Type {
+    case "string":
+        str, ok := v.(string)
+        if !ok {
+            return "must be a string"
+        }
+        if prop.Format == "date" {
+            if _, err := time.Parse("2006-01-02", str); err != nil {
+                return "must be a date (YYYY-MM-DD)"
+            }
+        }
+        if len(prop.Enum) > 0 {
+            for _, allowed := range prop.Enum {
+                if strings.EqualFold(allowed, str) {
+                    return ""
+                }
+            }
+            return "must be one of: " + strings.Join(prop.Enum, ", ")
+        }
+    case "number":
+        if _, ok := v.(float64); !ok {
+            return "must be a number"
+        }
+    case "integer":
+        if f, ok := v.(float64); !ok || f != math.Trunc(f) {
+            return "must be an integer"
+        }
+    case "boolean":
+        if _, ok := v.(bool); !ok {
+            return "must be a boolean"
+        }
+    }
+    return ""
+}
@@ -0,0 +1,69 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-fiber/app/model"
+	"go-fiber/helper"
+)
+
+func competitionSchema() model.DetailSchema {
+	return model.DetailSchema{
+		Required: []string{"competitionName", "competitionLevel"},
+		Properties: map[string]model.DetailSchemaProperty{
+			"competitionName":  {Type: "string"},
+			"competitionLevel": {Type: "string", Enum: []string{"international", "national", "regional", "campus"}},
+			"rank":             {Type: "integer"},
+			"isTeam":           {Type: "boolean"},
+			"eventDate":        {Type: "string", Format: "date"},
+		},
+	}
+}
+
+// Test ValidateAchievementDetails - details valid
+func TestValidateAchievementDetails_Valid(t *testing.T) {
+	errs := helper.ValidateAchievementDetails(competitionSchema(), map[string]interface{}{
+		"competitionName":  "Gemastik",
+		"competitionLevel": "national",
+		"rank":             float64(2),
+		"isTeam":           true,
+		"eventDate":        "2024-10-12",
+	})
+
+	assert.Empty(t, errs)
+}
+
+// Test ValidateAchievementDetails - field wajib kosong
+func TestValidateAchievementDetails_MissingRequired(t *testing.T) {
+	errs := helper.ValidateAchievementDetails(competitionSchema(), map[string]interface{}{
+		"competitionName": "",
+	})
+
+	assert.Equal(t, []model.ValidationError{
+		{Field: "details.competitionName", Message: "field is required"},
+		{Field: "details.competitionLevel", Message: "field is required"},
+	}, errs)
+}
+
+// Test ValidateAchievementDetails - tipe, enum dan format salah
+func TestValidateAchievementDetails_InvalidValues(t *testing.T) {
+	errs := helper.ValidateAchievementDetails(competitionSchema(), map[string]interface{}{
+		"competitionName":  "Gemastik",
+		"competitionLevel": "galaxy",
+		"rank":             1.5,
+		"isTeam":           "yes",
+		"eventDate":        "12/10/2024",
+	})
+
+	fields := map[string]string{}
+	for _, e := range errs {
+		fields[e.Field] = e.Message
+	}
+	assert.Len(t, errs, 4)
+	assert.Contains(t, fields["details.competitionLevel"], "must be one of")
+	assert.Equal(t, "must be an integer", fields["details.rank"])
+	assert.Equal(t, "must be a boolean", fields["details.isTeam"])
+	assert.Equal(t, "must be a date (YYYY-MM-DD)", fields["details.eventDate"])
+}
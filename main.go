@@ -1,13 +1,25 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
+	"go-fiber/app/model"
+	"go-fiber/app/repository"
+	"go-fiber/app/service"
 	"go-fiber/config"
 	"go-fiber/database"
+
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // @title Prestasi Backend API
@@ -43,9 +55,18 @@ func main() {
 		case "seed":
 			database.Seed(db)
 			return
+		case "reconcile":
+			reconcile(db, os.Args[2:])
+			return
+		case "purge":
+			purge(db, os.Args[2:])
+			return
+		case "import":
+			importAchievements(db, os.Args[2:])
+			return
 		default:
 			log.Println("Unknown command:", os.Args[1])
-			log.Println("Available commands: migrate, seed")
+			log.Println("Available commands: migrate, seed, reconcile, purge, import")
 			return
 		}
 	}
@@ -74,3 +95,163 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// newConsistencyService menyiapkan ConsistencyService untuk perintah CLI
+func newConsistencyService(db *sql.DB) (*service.ConsistencyService, func()) {
+	mongoDB, closeMongo := connectMongo()
+	return buildConsistencyService(db, mongoDB), closeMongo
+}
+
+func connectMongo() (*mongo.Database, func()) {
+	mongoClient, err := config.NewMongoClient()
+	if err != nil {
+		log.Fatal("Failed to connect MongoDB:", err)
+	}
+	return config.GetMongoDatabase(mongoClient), func() { mongoClient.Disconnect(context.Background()) }
+}
+
+func buildConsistencyService(db *sql.DB, mongoDB *mongo.Database) *service.ConsistencyService {
+	fileStorage, err := config.NewStorage(mongoDB)
+	if err != nil {
+		log.Fatal("Failed to init attachment storage:", err)
+	}
+
+	return service.NewConsistencyService(
+		repository.NewSagaRepository(db),
+		repository.NewAchievementRepository(db),
+		repository.NewMongoAchievementRepository(mongoDB.Collection("achievements")),
+		repository.NewMongoRevisionRepository(mongoDB.Collection("achievement_revisions")),
+		service.NewBlobService(repository.NewBlobRepository(db), fileStorage),
+		config.TrashRetention(),
+	)
+}
+
+// reconcile memeriksa konsistensi reference Postgres dengan dokumen Mongo.
+// Pemakaian: reconcile [-repair] [-grace 10m]
+func reconcile(db *sql.DB, args []string) {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	repair := fs.Bool("repair", false, "perbaiki temuan (default hanya laporan)")
+	grace := fs.Duration("grace", 10*time.Minute, "lewati data yang lebih baru dari durasi ini")
+	fs.Parse(args)
+
+	consistencyService, closeMongo := newConsistencyService(db)
+	defer closeMongo()
+
+	report, err := consistencyService.Reconcile(context.Background(), *grace, *repair)
+	if err != nil {
+		log.Fatal("Reconcile failed:", err)
+	}
+
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	out.Encode(report)
+
+	log.Printf("Reconcile done: %d reference(s), %d document(s), %d issue(s), %d saga(s) recovered (repair=%v)",
+		report.References, report.Documents, len(report.Issues), report.SagasRecovered, *repair)
+}
+
+// purge menghapus permanen prestasi di trash yang lebih lama dari masa simpan.
+// Pemakaian: purge [-days 30]
+func purge(db *sql.DB, args []string) {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", config.GetEnvInt("TRASH_RETENTION_DAYS", 30), "masa simpan trash (hari)")
+	fs.Parse(args)
+
+	consistencyService, closeMongo := newConsistencyService(db)
+	defer closeMongo()
+
+	n, err := consistencyService.Purge(context.Background(), time.Duration(*days)*24*time.Hour)
+	if err != nil {
+		log.Fatal("Purge failed:", err)
+	}
+	log.Printf("Purge done: %d achievement(s) older than %d day(s) permanently deleted", n, *days)
+}
+
+// importAchievements mengimpor prestasi historis dari file CSV / XLSX.
+// Pemakaian: import -file prestasi.xlsx [-mapping mapping.json] [-sheet Sheet1]
+// [-status verified] [-dry-run] [-report errors.csv]
+func importAchievements(db *sql.DB, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("file", "", "file .csv atau .xlsx")
+	mappingArg := fs.String("mapping", "", "mapping field -> judul kolom: JSON langsung atau path file JSON")
+	sheet := fs.String("sheet", "", "nama sheet XLSX (default sheet pertama)")
+	status := fs.String("status", "verified", "status awal: draft | submitted | verified")
+	dryRun := fs.Bool("dry-run", false, "hanya validasi dan pratinjau, tanpa menyimpan prestasi")
+	reportPath := fs.String("report", "", "tulis laporan baris yang gagal (CSV) ke path ini")
+	fs.Parse(args)
+
+	if *file == "" {
+		log.Fatal("Import failed: -file is required")
+	}
+	opts := model.ImportOptions{
+		FileName: filepath.Base(*file),
+		Sheet:    *sheet,
+		Status:   *status,
+		DryRun:   *dryRun,
+	}
+	if raw := strings.TrimSpace(*mappingArg); raw != "" {
+		if !strings.HasPrefix(raw, "{") {
+			data, err := os.ReadFile(raw)
+			if err != nil {
+				log.Fatal("Import failed: ", err)
+			}
+			raw = string(data)
+		}
+		if err := json.Unmarshal([]byte(raw), &opts.Mapping); err != nil {
+			log.Fatal("Import failed: invalid mapping: ", err)
+		}
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal("Import failed: ", err)
+	}
+	defer f.Close()
+
+	mongoDB, closeMongo := connectMongo()
+	defer closeMongo()
+
+	achievementRepo := repository.NewAchievementRepository(db)
+	mongoAchievementRepo := repository.NewMongoAchievementRepository(mongoDB.Collection("achievements"))
+	importService := service.NewImportService(
+		repository.NewImportRepository(db),
+		repository.NewStudentRepository(db),
+		repository.NewAchievementTypeRepository(db),
+		achievementRepo,
+		mongoAchievementRepo,
+		service.NewPointService(repository.NewPointRuleRepository(db), achievementRepo, mongoAchievementRepo),
+		service.NewApprovalService(repository.NewApprovalRepository(db), repository.NewUserRepository(db)),
+		buildConsistencyService(db, mongoDB),
+		repository.NewMongoRevisionRepository(mongoDB.Collection("achievement_revisions")),
+		config.GetEnvInt("IMPORT_MAX_ROWS", 5000),
+	)
+
+	imp, errs, err := importService.Run(context.Background(), f, opts)
+	if err != nil {
+		log.Fatal("Import failed: ", err)
+	}
+	if len(errs) > 0 {
+		for _, e := range errs {
+			log.Printf("%s: %s", e.Field, e.Message)
+		}
+		log.Fatal("Import failed: validation failed")
+	}
+
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	out.Encode(imp)
+
+	if *reportPath != "" && len(imp.Errors) > 0 {
+		report, err := os.Create(*reportPath)
+		if err != nil {
+			log.Fatal("Failed to write error report: ", err)
+		}
+		defer report.Close()
+		if err := service.WriteErrorReport(report, imp.Errors); err != nil {
+			log.Fatal("Failed to write error report: ", err)
+		}
+	}
+
+	log.Printf("Import %s done: %d row(s), %d imported, %d failed (status=%s, dry-run=%v)",
+		imp.ID, imp.TotalRows, imp.Imported, imp.Failed, imp.InitialStatus, imp.DryRun)
+}
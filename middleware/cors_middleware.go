@@ -8,11 +8,12 @@ import (
 // CORSMiddleware konfigurasi CORS
 func CORSMiddleware() fiber.Handler {
 	return cors.New(cors.Config{
-		AllowOrigins:     "http://localhost:3000,http://localhost:5173",
-		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
+		AllowOrigins: "http://localhost:3000,http://localhost:5173",
+		AllowMethods: "GET,POST,PUT,PATCH,HEAD,DELETE,OPTIONS",
+		// Header protokol tus untuk upload resumable
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,Tus-Resumable,Upload-Length,Upload-Metadata,Upload-Offset",
 		AllowCredentials: true,
-		ExposeHeaders:    "Content-Length",
+		ExposeHeaders:    "Content-Length,Content-Disposition,Location,Tus-Resumable,Tus-Version,Tus-Extension,Tus-Max-Size,Upload-Max-Chunk-Size,Upload-Offset,Upload-Length,Upload-Expires",
 		MaxAge:           3600,
 	})
 }
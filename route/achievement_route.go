@@ -8,7 +8,7 @@ import (
 	"go-fiber/middleware"
 )
 
-func SetupAchievementRoutes(app fiber.Router, svc *service.AchievementService) {
+func SetupAchievementRoutes(app fiber.Router, svc *service.AchievementService, imports *service.ImportService) {
 
 	ach := app.Group("/achievements",
 		middleware.AuthMiddleware(),
@@ -17,6 +17,43 @@ func SetupAchievementRoutes(app fiber.Router, svc *service.AchievementService) {
 		middleware.RequirePermission("achievement:read"),
 		svc.List,
 	)
+	// bulk, import, export, trash, overdue dan approval-queue didaftarkan sebelum /:id agar tidak dianggap sebagai id
+	ach.Post("/bulk/verify",
+		middleware.RequirePermission("achievement:verify"),
+		svc.BulkVerify,
+	)
+	ach.Post("/bulk/reject",
+		middleware.RequirePermission("achievement:verify"),
+		svc.BulkReject,
+	)
+	ach.Post("/import",
+		middleware.RequirePermission("achievement:configure"),
+		imports.Import,
+	)
+	ach.Get("/imports/:id",
+		middleware.RequirePermission("achievement:configure"),
+		imports.Get,
+	)
+	ach.Get("/imports/:id/errors",
+		middleware.RequirePermission("achievement:configure"),
+		imports.ErrorReport,
+	)
+	ach.Get("/export",
+		middleware.RequirePermission("achievement:read"),
+		svc.Export,
+	)
+	ach.Get("/trash",
+		middleware.RequirePermission("achievement:read"),
+		svc.Trash,
+	)
+	ach.Get("/overdue",
+		middleware.RequirePermission("achievement:verify"),
+		svc.Overdue,
+	)
+	ach.Get("/approval-queue",
+		middleware.RequirePermission("achievement:verify"),
+		svc.ApprovalQueue,
+	)
 	ach.Get("/:id",
 		middleware.RequirePermission("achievement:read"),
 		svc.Detail,
@@ -33,6 +70,10 @@ func SetupAchievementRoutes(app fiber.Router, svc *service.AchievementService) {
 		middleware.RequirePermission("achievement:delete"),
 		svc.Delete,
 	)
+	ach.Post("/:id/restore",
+		middleware.RequirePermission("achievement:delete"),
+		svc.Restore,
+	)
 	ach.Post("/:id/submit",
 		middleware.RequirePermission("achievement:update"),
 		svc.Submit,
@@ -45,12 +86,101 @@ func SetupAchievementRoutes(app fiber.Router, svc *service.AchievementService) {
 		middleware.RequirePermission("achievement:verify"),
 		svc.Reject,
 	)
+	ach.Post("/:id/request-revision",
+		middleware.RequirePermission("achievement:verify"),
+		svc.RequestRevision,
+	)
+	ach.Get("/:id/comments",
+		middleware.RequirePermission("achievement:read"),
+		svc.ListComments,
+	)
+	ach.Post("/:id/comments",
+		middleware.RequirePermission("achievement:read"),
+		svc.AddComment,
+	)
+	ach.Get("/:id/possible-duplicates",
+		middleware.RequirePermission("achievement:verify"),
+		svc.PossibleDuplicates,
+	)
+	ach.Get("/:id/members",
+		middleware.RequirePermission("achievement:read"),
+		svc.ListTeamMembers,
+	)
+	ach.Put("/:id/members",
+		middleware.RequirePermission("achievement:update"),
+		svc.UpdateTeamMembers,
+	)
+	ach.Post("/:id/members/confirm",
+		middleware.RequirePermission("achievement:update"),
+		svc.ConfirmTeamMembership,
+	)
+	ach.Post("/:id/members/decline",
+		middleware.RequirePermission("achievement:update"),
+		svc.DeclineTeamMembership,
+	)
 	ach.Get("/:id/history",
 		middleware.RequirePermission("achievement:read"),
 		svc.History,
 	)
+	// diff didaftarkan sebelum /:revision
+	ach.Get("/:id/revisions/diff",
+		middleware.RequirePermission("achievement:read"),
+		svc.DiffRevisions,
+	)
+	ach.Get("/:id/revisions",
+		middleware.RequirePermission("achievement:read"),
+		svc.ListRevisions,
+	)
+	ach.Get("/:id/revisions/:revision",
+		middleware.RequirePermission("achievement:read"),
+		svc.GetRevision,
+	)
 	ach.Post("/:id/attachments",
 		middleware.RequirePermission("achievement:update"),
 		svc.UploadAttachment,
 	)
+	// order didaftarkan sebelum /:attachmentId
+	ach.Put("/:id/attachments/order",
+		middleware.RequirePermission("achievement:update"),
+		svc.ReorderAttachments,
+	)
+	ach.Put("/:id/attachments/:attachmentId",
+		middleware.RequirePermission("achievement:update"),
+		svc.ReplaceAttachment,
+	)
+	ach.Delete("/:id/attachments/:attachmentId",
+		middleware.RequirePermission("achievement:update"),
+		svc.DeleteAttachment,
+	)
+	ach.Get("/:id/attachments/:attachmentId",
+		middleware.RequirePermission("achievement:read"),
+		svc.DownloadAttachment,
+	)
+	ach.Post("/:id/attachments/:attachmentId/signed-url",
+		middleware.RequirePermission("achievement:read"),
+		svc.AttachmentSignedURL,
+	)
+
+	ach.Post("/:id/uploads",
+		middleware.RequirePermission("achievement:update"),
+		svc.CreateUpload,
+	)
+
+	// Upload resumable (protokol tus); lokasi dikembalikan oleh CreateUpload.
+	// OPTIONS (discovery) tanpa token, didaftarkan sebelum middleware group.
+	app.Options("/uploads", svc.UploadOptions)
+	uploads := app.Group("/uploads",
+		middleware.AuthMiddleware(),
+		middleware.RequirePermission("achievement:update"),
+	)
+	uploads.Head("/:uploadId", svc.UploadOffset).Name("upload")
+	uploads.Patch("/:uploadId", svc.PatchUpload)
+	uploads.Delete("/:uploadId", svc.TerminateUpload)
+	uploads.Post("/:uploadId/finalize", svc.FinalizeUpload)
+
+	// Signed URL lampiran: tanpa bearer token, akses dijaga oleh signature HMAC
+	files := app.Group("/files")
+	files.Get("/achievements/:id/attachments/:attachmentId",
+		svc.SignedAttachment,
+	).Name("attachment.signed")
 }
@@ -0,0 +1,36 @@
+// @tag.name Achievement Types
+// @tag.description Registry jenis prestasi dan skema details
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-fiber/app/service"
+	"go-fiber/middleware"
+)
+
+func SetupAchievementTypeRoutes(app fiber.Router, svc *service.AchievementTypeService) {
+	types := app.Group("/achievement-types",
+		middleware.AuthMiddleware(),
+	)
+
+	types.Get("/",
+		middleware.RequirePermission("achievement:read"),
+		svc.List,
+	)
+	types.Get("/:code",
+		middleware.RequirePermission("achievement:read"),
+		svc.Get,
+	)
+	types.Post("/",
+		middleware.RequirePermission("achievement:configure"),
+		svc.Create,
+	)
+	types.Put("/:code",
+		middleware.RequirePermission("achievement:configure"),
+		svc.Update,
+	)
+	types.Delete("/:code",
+		middleware.RequirePermission("achievement:configure"),
+		svc.Delete,
+	)
+}
@@ -0,0 +1,23 @@
+// @tag.name Approval Chains
+// @tag.description Konfigurasi rantai persetujuan prestasi bertahap dan cakupan approver
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-fiber/app/service"
+	"go-fiber/middleware"
+)
+
+func SetupApprovalChainRoutes(app fiber.Router, svc *service.ApprovalService) {
+	chains := app.Group("/approval-chains",
+		middleware.AuthMiddleware(),
+		middleware.RequirePermission("achievement:configure"),
+	)
+
+	chains.Get("/", svc.List)
+	chains.Post("/", svc.Create)
+	chains.Put("/:id", svc.Update)
+	chains.Delete("/:id", svc.Delete)
+	chains.Get("/approvers/:userId/scopes", svc.Scopes)
+	chains.Put("/approvers/:userId/scopes", svc.UpdateScopes)
+}
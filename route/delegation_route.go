@@ -0,0 +1,20 @@
+// @tag.name Delegations
+// @tag.description Delegasi verifikasi sementara dari dosen wali ke dosen lain
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-fiber/app/service"
+	"go-fiber/middleware"
+)
+
+func SetupDelegationRoutes(app fiber.Router, svc *service.DelegationService) {
+
+	delegation := app.Group("/delegations",
+		middleware.AuthMiddleware(),
+		middleware.RequirePermission("achievement:verify"),
+	)
+	delegation.Get("/", svc.List)
+	delegation.Post("/", svc.Create)
+	delegation.Delete("/:id", svc.Revoke)
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-fiber/app/service"
+	"go-fiber/middleware"
+)
+
+// SetupMeRoutes endpoint milik user yang sedang login
+func SetupMeRoutes(app fiber.Router, achievementService *service.AchievementService) {
+
+	me := app.Group("/me",
+		middleware.AuthMiddleware(),
+	)
+	me.Get("/storage",
+		middleware.RequirePermission("achievement:create"),
+		achievementService.MyStorage,
+	)
+	me.Get("/team-invitations",
+		middleware.RequirePermission("achievement:update"),
+		achievementService.TeamInvitations,
+	)
+}
@@ -0,0 +1,18 @@
+// @tag.name Notifications
+// @tag.description Notifikasi in-app user yang login
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-fiber/app/service"
+	"go-fiber/middleware"
+)
+
+func SetupNotificationRoutes(app fiber.Router, svc *service.NotificationService) {
+
+	notif := app.Group("/notifications",
+		middleware.AuthMiddleware(),
+	)
+	notif.Get("/", svc.List)
+	notif.Post("/:id/read", svc.MarkRead)
+}
@@ -0,0 +1,22 @@
+// @tag.name Point Rules
+// @tag.description Konfigurasi aturan poin prestasi
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-fiber/app/service"
+	"go-fiber/middleware"
+)
+
+func SetupPointRuleRoutes(app fiber.Router, svc *service.PointService) {
+	rules := app.Group("/point-rules",
+		middleware.AuthMiddleware(),
+		middleware.RequirePermission("achievement:configure"),
+	)
+
+	rules.Get("/", svc.List)
+	rules.Post("/", svc.Create)
+	rules.Post("/dry-run", svc.DryRun)
+	rules.Put("/:id", svc.Update)
+	rules.Delete("/:id", svc.Delete)
+}
@@ -18,6 +18,11 @@ func SetupReportRoutes(app fiber.Router, svc *service.ReportService) {
 		svc.Statistics,
 	)
 
+	reports.Get("/statistics/export",
+		middleware.RequirePermission("achievement:read"),
+		svc.ExportStatistics,
+	)
+
 	reports.Get("/student/:id",
 		middleware.RequirePermission("achievement:read"),
 		svc.StudentStatistics,